@@ -1,6 +1,8 @@
 // Code generated for package assets by go-bindata DO NOT EDIT. (@generated)
 // sources:
 // templates/cloudformation/iam_user_osdCcsAdmin.json
+// templates/cloudformation/rosa_network.yaml
+// templates/cloudformation/rosa_shared_vpc.yaml
 package assets
 
 import (
@@ -83,6 +85,409 @@ func templatesCloudformationIam_user_osdccsadminJson() (*asset, error) {
 	return a, nil
 }
 
+var _templatesCloudformationRosa_networkYaml = []byte(`AWSTemplateFormatVersion: "2010-09-09"
+Description: VPC, subnets, NAT gateways and route tables ready to be used by ROSA clusters
+
+Parameters:
+  Name:
+    Type: String
+    Description: Name used for the VPC and as prefix for the rest of the resources
+  VpcCidr:
+    Type: String
+    Default: 10.0.0.0/16
+    Description: CIDR block of the VPC
+  SubnetCidrBits:
+    Type: Number
+    Default: 13
+    Description: Number of host bits of each subnet, the VPC is split in eight subnets of the same size
+  AvailabilityZoneCount:
+    Type: Number
+    Default: 1
+    AllowedValues: ["1", "3"]
+    Description: Number of availability zones to create subnets in
+  PrivateOnly:
+    Type: String
+    Default: "false"
+    AllowedValues: ["true", "false"]
+    Description: Skip the public subnets, internet gateway and NAT gateways
+
+Conditions:
+  MultiAZ: !Equals [!Ref AvailabilityZoneCount, "3"]
+  HasPublic: !Equals [!Ref PrivateOnly, "false"]
+  MultiAZPublic: !And [!Condition MultiAZ, !Condition HasPublic]
+
+Resources:
+  VPC:
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock: !Ref VpcCidr
+      EnableDnsSupport: true
+      EnableDnsHostnames: true
+      Tags:
+        - Key: Name
+          Value: !Ref Name
+
+  PrivateSubnet1:
+    Type: AWS::EC2::Subnet
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [0, !GetAZs ""]
+      CidrBlock: !Select [0, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-1"
+        - Key: kubernetes.io/role/internal-elb
+          Value: "1"
+  PrivateSubnet2:
+    Type: AWS::EC2::Subnet
+    Condition: MultiAZ
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [1, !GetAZs ""]
+      CidrBlock: !Select [1, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-2"
+        - Key: kubernetes.io/role/internal-elb
+          Value: "1"
+  PrivateSubnet3:
+    Type: AWS::EC2::Subnet
+    Condition: MultiAZ
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [2, !GetAZs ""]
+      CidrBlock: !Select [2, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-3"
+        - Key: kubernetes.io/role/internal-elb
+          Value: "1"
+
+  PublicSubnet1:
+    Type: AWS::EC2::Subnet
+    Condition: HasPublic
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [0, !GetAZs ""]
+      CidrBlock: !Select [4, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      MapPublicIpOnLaunch: true
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-public-1"
+        - Key: kubernetes.io/role/elb
+          Value: "1"
+  PublicSubnet2:
+    Type: AWS::EC2::Subnet
+    Condition: MultiAZPublic
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [1, !GetAZs ""]
+      CidrBlock: !Select [5, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      MapPublicIpOnLaunch: true
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-public-2"
+        - Key: kubernetes.io/role/elb
+          Value: "1"
+  PublicSubnet3:
+    Type: AWS::EC2::Subnet
+    Condition: MultiAZPublic
+    Properties:
+      VpcId: !Ref VPC
+      AvailabilityZone: !Select [2, !GetAZs ""]
+      CidrBlock: !Select [6, !Cidr [!Ref VpcCidr, 8, !Ref SubnetCidrBits]]
+      MapPublicIpOnLaunch: true
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-public-3"
+        - Key: kubernetes.io/role/elb
+          Value: "1"
+
+  InternetGateway:
+    Type: AWS::EC2::InternetGateway
+    Condition: HasPublic
+    Properties:
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-igw"
+  InternetGatewayAttachment:
+    Type: AWS::EC2::VPCGatewayAttachment
+    Condition: HasPublic
+    Properties:
+      VpcId: !Ref VPC
+      InternetGatewayId: !Ref InternetGateway
+
+  PublicRouteTable:
+    Type: AWS::EC2::RouteTable
+    Condition: HasPublic
+    Properties:
+      VpcId: !Ref VPC
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-public"
+  PublicDefaultRoute:
+    Type: AWS::EC2::Route
+    Condition: HasPublic
+    DependsOn: InternetGatewayAttachment
+    Properties:
+      RouteTableId: !Ref PublicRouteTable
+      DestinationCidrBlock: 0.0.0.0/0
+      GatewayId: !Ref InternetGateway
+  PublicSubnet1RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Condition: HasPublic
+    Properties:
+      RouteTableId: !Ref PublicRouteTable
+      SubnetId: !Ref PublicSubnet1
+  PublicSubnet2RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Condition: MultiAZPublic
+    Properties:
+      RouteTableId: !Ref PublicRouteTable
+      SubnetId: !Ref PublicSubnet2
+  PublicSubnet3RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Condition: MultiAZPublic
+    Properties:
+      RouteTableId: !Ref PublicRouteTable
+      SubnetId: !Ref PublicSubnet3
+
+  NatGateway1EIP:
+    Type: AWS::EC2::EIP
+    Condition: HasPublic
+    DependsOn: InternetGatewayAttachment
+    Properties:
+      Domain: vpc
+  NatGateway1:
+    Type: AWS::EC2::NatGateway
+    Condition: HasPublic
+    Properties:
+      AllocationId: !GetAtt NatGateway1EIP.AllocationId
+      SubnetId: !Ref PublicSubnet1
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-nat-1"
+  NatGateway2EIP:
+    Type: AWS::EC2::EIP
+    Condition: MultiAZPublic
+    DependsOn: InternetGatewayAttachment
+    Properties:
+      Domain: vpc
+  NatGateway2:
+    Type: AWS::EC2::NatGateway
+    Condition: MultiAZPublic
+    Properties:
+      AllocationId: !GetAtt NatGateway2EIP.AllocationId
+      SubnetId: !Ref PublicSubnet2
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-nat-2"
+  NatGateway3EIP:
+    Type: AWS::EC2::EIP
+    Condition: MultiAZPublic
+    DependsOn: InternetGatewayAttachment
+    Properties:
+      Domain: vpc
+  NatGateway3:
+    Type: AWS::EC2::NatGateway
+    Condition: MultiAZPublic
+    Properties:
+      AllocationId: !GetAtt NatGateway3EIP.AllocationId
+      SubnetId: !Ref PublicSubnet3
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-nat-3"
+
+  PrivateRouteTable1:
+    Type: AWS::EC2::RouteTable
+    Properties:
+      VpcId: !Ref VPC
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-1"
+  PrivateDefaultRoute1:
+    Type: AWS::EC2::Route
+    Condition: HasPublic
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable1
+      DestinationCidrBlock: 0.0.0.0/0
+      NatGatewayId: !Ref NatGateway1
+  PrivateSubnet1RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable1
+      SubnetId: !Ref PrivateSubnet1
+  PrivateRouteTable2:
+    Type: AWS::EC2::RouteTable
+    Condition: MultiAZ
+    Properties:
+      VpcId: !Ref VPC
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-2"
+  PrivateDefaultRoute2:
+    Type: AWS::EC2::Route
+    Condition: MultiAZPublic
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable2
+      DestinationCidrBlock: 0.0.0.0/0
+      NatGatewayId: !Ref NatGateway2
+  PrivateSubnet2RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Condition: MultiAZ
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable2
+      SubnetId: !Ref PrivateSubnet2
+  PrivateRouteTable3:
+    Type: AWS::EC2::RouteTable
+    Condition: MultiAZ
+    Properties:
+      VpcId: !Ref VPC
+      Tags:
+        - Key: Name
+          Value: !Sub "${Name}-private-3"
+  PrivateDefaultRoute3:
+    Type: AWS::EC2::Route
+    Condition: MultiAZPublic
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable3
+      DestinationCidrBlock: 0.0.0.0/0
+      NatGatewayId: !Ref NatGateway3
+  PrivateSubnet3RouteTableAssociation:
+    Type: AWS::EC2::SubnetRouteTableAssociation
+    Condition: MultiAZ
+    Properties:
+      RouteTableId: !Ref PrivateRouteTable3
+      SubnetId: !Ref PrivateSubnet3
+
+Outputs:
+  VpcId:
+    Value: !Ref VPC
+  PrivateSubnet1:
+    Value: !Ref PrivateSubnet1
+  PrivateSubnet2:
+    Condition: MultiAZ
+    Value: !Ref PrivateSubnet2
+  PrivateSubnet3:
+    Condition: MultiAZ
+    Value: !Ref PrivateSubnet3
+  PublicSubnet1:
+    Condition: HasPublic
+    Value: !Ref PublicSubnet1
+  PublicSubnet2:
+    Condition: MultiAZPublic
+    Value: !Ref PublicSubnet2
+  PublicSubnet3:
+    Condition: MultiAZPublic
+    Value: !Ref PublicSubnet3
+`)
+
+func templatesCloudformationRosa_networkYamlBytes() ([]byte, error) {
+	return _templatesCloudformationRosa_networkYaml, nil
+}
+
+func templatesCloudformationRosa_networkYaml() (*asset, error) {
+	bytes, err := templatesCloudformationRosa_networkYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cloudformation/rosa_network.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesCloudformationRosa_shared_vpcYaml = []byte(`AWSTemplateFormatVersion: "2010-09-09"
+Description: Private hosted zone and cross-account role that allow a ROSA cluster to be installed in a shared VPC
+
+Parameters:
+  ClusterName:
+    Type: String
+    Description: Name of the cluster that will be installed in the shared VPC
+  BaseDomain:
+    Type: String
+    Description: Base DNS domain reserved for the cluster
+  VpcId:
+    Type: AWS::EC2::VPC::Id
+    Description: ID of the shared VPC
+  RoleName:
+    Type: String
+    Description: Name of the role assumed from the cluster account to manage the DNS records of the cluster
+  TrustedRoleArns:
+    Type: CommaDelimitedList
+    Description: ARNs of the installer and ingress operator roles of the cluster account
+
+Resources:
+  PrivateHostedZone:
+    Type: AWS::Route53::HostedZone
+    Properties:
+      Name: !Sub "${ClusterName}.${BaseDomain}"
+      HostedZoneConfig:
+        Comment: !Sub "Private hosted zone of ROSA cluster ${ClusterName}"
+      VPCs:
+        - VPCId: !Ref VpcId
+          VPCRegion: !Ref AWS::Region
+
+  SharedVpcRole:
+    Type: AWS::IAM::Role
+    Properties:
+      RoleName: !Ref RoleName
+      Description: !Sub "Allows ROSA cluster ${ClusterName} to manage its DNS records in the shared VPC"
+      AssumeRolePolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Principal:
+              AWS: !Ref TrustedRoleArns
+            Action: sts:AssumeRole
+      Policies:
+        - PolicyName: !Sub "${RoleName}-route53"
+          PolicyDocument:
+            Version: "2012-10-17"
+            Statement:
+              - Sid: ManageClusterRecords
+                Effect: Allow
+                Action:
+                  - route53:ChangeResourceRecordSets
+                  - route53:ChangeTagsForResource
+                  - route53:GetHostedZone
+                  - route53:ListResourceRecordSets
+                  - route53:ListTagsForResource
+                Resource: !Sub "arn:${AWS::Partition}:route53:::hostedzone/${PrivateHostedZone}"
+              - Sid: ReadRoute53
+                Effect: Allow
+                Action:
+                  - route53:GetAccountLimit
+                  - route53:GetChange
+                  - route53:ListHostedZones
+                  - route53:ListHostedZonesByName
+                  - tag:GetResources
+                Resource: "*"
+
+Outputs:
+  HostedZoneId:
+    Description: ID of the private hosted zone, to be used as '--private-hosted-zone-id'
+    Value: !Ref PrivateHostedZone
+  RoleArn:
+    Description: ARN of the cross-account role, to be used as '--shared-vpc-role-arn'
+    Value: !GetAtt SharedVpcRole.Arn
+`)
+
+func templatesCloudformationRosa_shared_vpcYamlBytes() ([]byte, error) {
+	return _templatesCloudformationRosa_shared_vpcYaml, nil
+}
+
+func templatesCloudformationRosa_shared_vpcYaml() (*asset, error) {
+	bytes, err := templatesCloudformationRosa_shared_vpcYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cloudformation/rosa_shared_vpc.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -136,6 +541,8 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/cloudformation/iam_user_osdCcsAdmin.json": templatesCloudformationIam_user_osdccsadminJson,
+	"templates/cloudformation/rosa_network.yaml": templatesCloudformationRosa_networkYaml,
+	"templates/cloudformation/rosa_shared_vpc.yaml": templatesCloudformationRosa_shared_vpcYaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -182,6 +589,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
 		"cloudformation": &bintree{nil, map[string]*bintree{
 			"iam_user_osdCcsAdmin.json": &bintree{templatesCloudformationIam_user_osdccsadminJson, map[string]*bintree{}},
+			"rosa_network.yaml": &bintree{templatesCloudformationRosa_networkYaml, map[string]*bintree{}},
+			"rosa_shared_vpc.yaml": &bintree{templatesCloudformationRosa_shared_vpcYaml, map[string]*bintree{}},
 		}},
 	}},
 }}
@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clear
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear the cache of OCM responses",
+	Long:  "Remove all the responses of OCM stored in the on-disk cache.",
+	Example: `  # Remove the cached responses
+  rosa cache clear`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporter()
+	err := ocm.ClearCache()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Cache cleared")
+}
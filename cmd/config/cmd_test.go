@@ -95,6 +95,38 @@ var _ = Describe("Run Command", Ordered, func() {
 			Expect(err).To(BeNil())
 			Expect(strconv.FormatBool(currentConfig.FedRAMP)).To(Equal(fedramp))
 
+			cache := "true"
+			err = set.SaveConfig("cache", cache)
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(strconv.FormatBool(currentConfig.Cache)).To(Equal(cache))
+
+			err = set.SaveConfig("aws_endpoints", "iam=http://localhost:4566, sts=http://localhost:4566")
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.AWSEndpoints).To(Equal(map[string]string{
+				"iam": "http://localhost:4566",
+				"sts": "http://localhost:4566",
+			}))
+
+			err = set.SaveConfig("aws_endpoints", "lambda=http://localhost:4566")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Unknown AWS service 'lambda'"))
+
+			err = set.SaveConfig("audit", "true")
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.Audit).To(BeTrue())
+
+			err = set.SaveConfig("audit_webhook", "https://audit.example.com/rosa")
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.AuditWebhook).To(Equal("https://audit.example.com/rosa"))
+
 			insecure = "Incorrect"
 			err = set.SaveConfig("insecure", insecure)
 			Expect(err).NotTo(BeNil())
@@ -145,6 +177,22 @@ var _ = Describe("Run Command", Ordered, func() {
 			Expect(err).To(BeNil())
 			Expect(buf.String()).To(ContainSubstring(strconv.FormatBool(currentConfig.FedRAMP)))
 
+			err = get.PrintConfig("cache")
+			Expect(err).To(BeNil())
+			Expect(buf.String()).To(ContainSubstring(strconv.FormatBool(currentConfig.Cache)))
+
+			err = get.PrintConfig("aws_endpoints")
+			Expect(err).To(BeNil())
+			Expect(buf.String()).To(ContainSubstring("iam=http://localhost:4566,sts=http://localhost:4566"))
+
+			err = get.PrintConfig("audit")
+			Expect(err).To(BeNil())
+			Expect(buf.String()).To(ContainSubstring(strconv.FormatBool(currentConfig.Audit)))
+
+			err = get.PrintConfig("audit_webhook")
+			Expect(err).To(BeNil())
+			Expect(buf.String()).To(ContainSubstring(currentConfig.AuditWebhook))
+
 			err = get.PrintConfig("test")
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(Equal("'test' is not a supported setting"))
@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws/endpoints"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -98,6 +99,14 @@ func PrintConfig(arg string) error {
 		fmt.Fprintf(Writer, "%s\n", cfg.URL)
 	case "fedramp":
 		fmt.Fprintf(Writer, "%v\n", cfg.FedRAMP)
+	case "cache":
+		fmt.Fprintf(Writer, "%v\n", cfg.Cache)
+	case "aws_endpoints":
+		fmt.Fprintf(Writer, "%s\n", endpoints.Format(cfg.AWSEndpoints))
+	case "audit":
+		fmt.Fprintf(Writer, "%v\n", cfg.Audit)
+	case "audit_webhook":
+		fmt.Fprintf(Writer, "%s\n", cfg.AuditWebhook)
 	default:
 		return fmt.Errorf("'%s' is not a supported setting", arg)
 	}
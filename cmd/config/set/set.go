@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws/endpoints"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -89,6 +90,27 @@ func SaveConfig(arg, value string) error {
 		if err != nil {
 			return fmt.Errorf("Failed to set fedramp: %v", value)
 		}
+	case "cache":
+		cfg.Cache, err = strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Failed to set cache: %v", value)
+		}
+	case "aws_endpoints":
+		cfg.AWSEndpoints, err = endpoints.Parse(value)
+		if err != nil {
+			return fmt.Errorf("Failed to set aws_endpoints: %v", err)
+		}
+		err = endpoints.Validate(cfg.AWSEndpoints)
+		if err != nil {
+			return fmt.Errorf("Failed to set aws_endpoints: %v", err)
+		}
+	case "audit":
+		cfg.Audit, err = strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Failed to set audit: %v", value)
+		}
+	case "audit_webhook":
+		cfg.AuditWebhook = value
 	default:
 		return fmt.Errorf("'%s' is not a supported setting", arg)
 	}
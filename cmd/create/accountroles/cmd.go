@@ -253,6 +253,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
+			Flag:     "prefix",
 			Help:     cmd.Flags().Lookup("prefix").Usage,
 			Default:  prefix,
 			Required: true,
@@ -283,6 +284,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
+			Flag:     "permissions-boundary",
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
@@ -307,6 +309,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Path",
+			Flag:     "path",
 			Help:     cmd.Flags().Lookup("path").Usage,
 			Default:  path,
 			Validators: []interactive.Validator{
@@ -328,6 +331,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -356,6 +360,7 @@ func run(cmd *cobra.Command, argv []string) {
 	} else if interactive.Enabled() && !isClassicValueSet && !isHostedCPValueSet {
 		createClassic, err = interactive.GetBool(interactive.Input{
 			Question: "Create Classic account roles",
+			Flag:     "classic",
 			Help:     cmd.Flags().Lookup("classic").Usage,
 			Default:  true,
 			Required: false,
@@ -371,6 +376,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && !isHostedCPValueSet && !cmd.Flags().Changed("classic") && !r.Creator.IsGovcloud {
 		createHostedCP, err = interactive.GetBool(interactive.Input{
 			Question: "Create Hosted CP account roles",
+			Flag:     "hosted-cp",
 			Help:     cmd.Flags().Lookup("hosted-cp").Usage,
 			Default:  false,
 			Required: false,
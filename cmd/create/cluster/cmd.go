@@ -22,8 +22,10 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
@@ -48,6 +50,8 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
+	"github.com/openshift/rosa/pkg/completion"
+	"github.com/openshift/rosa/pkg/debug"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
@@ -144,6 +148,9 @@ var args struct {
 	// unless using PrivateLink, in which case it should only be one private per availability zone
 	subnetIDs []string
 
+	// Add the load balancer role tags that the subnets are missing
+	fixSubnetTags bool
+
 	// Selecting availability zones for a non-BYOVPC cluster
 	availabilityZones []string
 
@@ -516,6 +523,15 @@ func initFlags(cmd *cobra.Command) {
 			"Leave empty for installer provisioned subnet IDs.",
 	)
 
+	flags.BoolVar(
+		&args.fixSubnetTags,
+		"fix-subnet-tags",
+		false,
+		fmt.Sprintf("Add the '%s' tag to the public subnets and the '%s' tag to the private subnets "+
+			"that are missing it, so that the load balancers of the cluster can discover them.",
+			aws.ELBRoleTag, aws.InternalELBRoleTag),
+	)
+
 	flags.StringSliceVar(
 		&args.availabilityZones,
 		"availability-zones",
@@ -595,6 +611,7 @@ func initFlags(cmd *cobra.Command) {
 	)
 	flags.MarkHidden("network-type")
 	cmd.RegisterFlagCompletionFunc("network-type", networkTypeCompletion)
+	cmd.RegisterFlagCompletionFunc("version", completion.Versions)
 
 	flags.IPNetVar(
 		&args.machineCIDR,
@@ -831,6 +848,7 @@ func initFlags(cmd *cobra.Command) {
 
 	aws.AddModeFlag(cmd)
 	interactive.AddFlag(flags)
+	interactive.AddAnswersFlags(flags)
 	output.AddFlag(cmd)
 	confirm.AddFlag(flags)
 }
@@ -850,6 +868,12 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	err = interactive.LoadAnswers(cmd.Flags())
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	for _, val := range userSpecifiedAutoscalerValues {
 		if val.Changed && !args.autoscalingEnabled {
 			r.Reporter.Errorf("Using autoscaling flag '%s', requires flag '--enable-autoscaling'. "+
@@ -923,6 +947,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		clusterName, err = interactive.GetString(interactive.Input{
 			Question: "Cluster name",
+			Flag:     "cluster-name",
 			Help:     cmd.Flags().Lookup("cluster-name").Usage,
 			Default:  clusterName,
 			Required: true,
@@ -952,6 +977,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		domainPrefix, err = interactive.GetString(interactive.Input{
 			Question: "Domain prefix",
+			Flag:     "domain-prefix",
 			Help:     cmd.Flags().Lookup("domain-prefix").Usage,
 			Default:  domainPrefix,
 			Required: false,
@@ -990,6 +1016,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !fedramp.Enabled() {
 		isHostedCP, err = interactive.GetBool(interactive.Input{
 			Question: "Deploy cluster with Hosted Control Plane",
+			Flag:     "hosted-cp",
 			Help:     cmd.Flags().Lookup("hosted-cp").Usage,
 			Default:  isHostedCP,
 			Required: false,
@@ -1138,6 +1165,7 @@ func run(cmd *cobra.Command, _ []string) {
 				if len(billingAccounts) > 0 {
 					billingAccount, err = interactive.GetOption(interactive.Input{
 						Question: "Billing Account",
+						Flag:     "billing-account",
 						Help:     cmd.Flags().Lookup("billing-account").Usage,
 						Default:  billingAccount,
 						Required: true,
@@ -1213,6 +1241,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && (!isSTS && !isIAM) {
 		isSTS, err = interactive.GetBool(interactive.Input{
 			Question: "Deploy cluster using AWS STS",
+			Flag:     "sts",
 			Help:     cmd.Flags().Lookup("sts").Usage,
 			Default:  true,
 			Required: true,
@@ -1275,6 +1304,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		version, err = interactive.GetOption(interactive.Input{
 			Question: "OpenShift version",
+			Flag:     "version",
 			Help:     cmd.Flags().Lookup("version").Usage,
 			Options:  versionList,
 			Default:  version,
@@ -1305,6 +1335,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() {
 			httpTokens, err = interactive.GetOption(interactive.Input{
 				Question: "Configure the use of IMDSv2 for ec2 instances",
+				Flag:     Ec2MetadataHttpTokensFlag,
 				Options:  []string{string(v1.Ec2MetadataHttpTokensOptional), string(v1.Ec2MetadataHttpTokensRequired)},
 				Help:     cmd.Flags().Lookup(Ec2MetadataHttpTokensFlag).Usage,
 				Required: true,
@@ -1400,6 +1431,7 @@ func run(cmd *cobra.Command, _ []string) {
 			} else {
 				roleARN, err = interactive.GetOption(interactive.Input{
 					Question: fmt.Sprintf("%s role ARN", role.Name),
+					Flag:     role.Flag,
 					Help:     cmd.Flags().Lookup(role.Flag).Usage,
 					Options:  roleARNs,
 					Default:  defaultRoleARN,
@@ -1509,6 +1541,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if isSTS && !hasRoles && interactive.Enabled() {
 		roleARN, err = interactive.GetString(interactive.Input{
 			Question: "Role ARN",
+			Flag:     "role-arn",
 			Help:     cmd.Flags().Lookup("role-arn").Usage,
 			Default:  roleARN,
 			Required: isSTS,
@@ -1539,6 +1572,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if isSTS && interactive.Enabled() {
 		externalID, err = interactive.GetString(interactive.Input{
 			Question: "External ID",
+			Flag:     "external-id",
 			Help:     cmd.Flags().Lookup("external-id").Usage,
 			Validators: []interactive.Validator{
 				interactive.RegExp(`^[\w+=,.@:\/-]*$`),
@@ -1558,6 +1592,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if isSTS && !hasRoles && interactive.Enabled() {
 		supportRoleARN, err = interactive.GetString(interactive.Input{
 			Question: "Support Role ARN",
+			Flag:     "support-role-arn",
 			Help:     cmd.Flags().Lookup("support-role-arn").Usage,
 			Default:  supportRoleARN,
 			Required: true,
@@ -1590,6 +1625,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if isSTS && !hasRoles && interactive.Enabled() {
 			controlPlaneRoleARN, err = interactive.GetString(interactive.Input{
 				Question: "Control plane IAM Role ARN",
+				Flag:     "controlplane-iam-role",
 				Help:     cmd.Flags().Lookup("controlplane-iam-role").Usage,
 				Default:  controlPlaneRoleARN,
 				Required: true,
@@ -1622,6 +1658,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if isSTS && !hasRoles && interactive.Enabled() {
 		workerRoleARN, err = interactive.GetString(interactive.Input{
 			Question: "Worker IAM Role ARN",
+			Flag:     "worker-iam-role",
 			Help:     cmd.Flags().Lookup("worker-iam-role").Usage,
 			Default:  workerRoleARN,
 			Required: true,
@@ -1697,6 +1734,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() {
 			operatorRolesPrefix, err = interactive.GetString(interactive.Input{
 				Question: "Operator roles prefix",
+				Flag:     "operator-roles-prefix",
 				Help:     cmd.Flags().Lookup("operator-roles-prefix").Usage,
 				Required: true,
 				Default:  operatorRolesPrefix,
@@ -1817,6 +1855,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		tagsInput, err := interactive.GetString(interactive.Input{
 			Question: "Tags",
+			Flag:     "tags",
 			Help:     cmd.Flags().Lookup("tags").Usage,
 			Default:  strings.Join(_tags, ","),
 			Validators: []interactive.Validator{
@@ -1849,6 +1888,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !isHostedCP {
 		multiAZ, err = interactive.GetBool(interactive.Input{
 			Question: "Multiple availability zones",
+			Flag:     "multi-az",
 			Help:     cmd.Flags().Lookup("multi-az").Usage,
 			Default:  multiAZ,
 		})
@@ -1898,6 +1938,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		region, err = interactive.GetOption(interactive.Input{
 			Question: "AWS region",
+			Flag:     "region",
 			Help:     cmd.Flags().Lookup("region").Usage,
 			Options:  regionList,
 			Default:  region,
@@ -1943,6 +1984,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !fedramp.Enabled() && !isPrivateHostedCP {
 		privateLink, err = interactive.GetBool(interactive.Input{
 			Question: "PrivateLink cluster",
+			Flag:     "private-link",
 			Help:     fmt.Sprintf("%s %s", cmd.Flags().Lookup("private-link").Usage, privateLinkWarning),
 			Default:  privateLink || (isSTS && args.private),
 		})
@@ -1970,6 +2012,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() {
 			private, err = interactive.GetBool(interactive.Input{
 				Question: "Private cluster",
+				Flag:     "private",
 				Help:     fmt.Sprintf("%s %s", cmd.Flags().Lookup("private").Usage, privateWarning),
 				Default:  private,
 			})
@@ -2026,6 +2069,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		machineCIDR, err = interactive.GetIPNet(interactive.Input{
 			Question: "Machine CIDR",
+			Flag:     "machine-cidr",
 			Help:     cmd.Flags().Lookup("machine-cidr").Usage,
 			Default:  machineCIDR,
 		})
@@ -2043,6 +2087,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		serviceCIDR, err = interactive.GetIPNet(interactive.Input{
 			Question: "Service CIDR",
+			Flag:     "service-cidr",
 			Help:     cmd.Flags().Lookup("service-cidr").Usage,
 			Default:  serviceCIDR,
 		})
@@ -2059,6 +2104,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		podCIDR, err = interactive.GetIPNet(interactive.Input{
 			Question: "Pod CIDR",
+			Flag:     "pod-cidr",
 			Help:     cmd.Flags().Lookup("pod-cidr").Usage,
 			Default:  podCIDR,
 		})
@@ -2199,6 +2245,7 @@ func run(cmd *cobra.Command, _ []string) {
 			len(options) > 0 && (!multiAZ || len(mapAZCreated) >= 3) {
 			subnetIDs, err = interactive.GetMultipleOptions(interactive.Input{
 				Question: "Subnet IDs",
+				Flag:     "subnet-ids",
 				Help:     cmd.Flags().Lookup("subnet-ids").Usage,
 				Required: false,
 				Options:  options,
@@ -2336,6 +2383,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !enableCustomerManagedKey {
 		enableCustomerManagedKey, err = interactive.GetBool(interactive.Input{
 			Question: "Enable Customer Managed key",
+			Flag:     "enable-customer-managed-key",
 			Help:     cmd.Flags().Lookup("enable-customer-managed-key").Usage,
 			Default:  enableCustomerManagedKey,
 			Required: false,
@@ -2349,6 +2397,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if enableCustomerManagedKey && (kmsKeyARN == "" || interactive.Enabled()) {
 		kmsKeyARN, err = interactive.GetString(interactive.Input{
 			Question: "KMS Key ARN",
+			Flag:     "kms-key-arn",
 			Help:     cmd.Flags().Lookup("kms-key-arn").Usage,
 			Default:  kmsKeyARN,
 			Required: enableCustomerManagedKey,
@@ -2382,6 +2431,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		computeMachineType, err = interactive.GetOption(interactive.Input{
 			Question: "Compute nodes instance type",
+			Flag:     "compute-machine-type",
 			Help:     cmd.Flags().Lookup("compute-machine-type").Usage,
 			Options:  computeMachineTypeList.GetAvailableIDs(multiAZ),
 			Default:  computeMachineType,
@@ -2405,6 +2455,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if !isReplicasSet && !autoscaling && !isAutoscalingSet && interactive.Enabled() {
 		autoscaling, err = interactive.GetBool(interactive.Input{
 			Question: "Enable autoscaling",
+			Flag:     "enable-autoscaling",
 			Help:     cmd.Flags().Lookup("enable-autoscaling").Usage,
 			Default:  autoscaling,
 			Required: false,
@@ -2439,6 +2490,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Min replicas",
+				Flag:     "min-replicas",
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  minReplicas,
 				Required: true,
@@ -2460,6 +2512,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() || !isMaxReplicasSet {
 			maxReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Max replicas",
+				Flag:     "max-replicas",
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  maxReplicas,
 				Required: true,
@@ -2509,6 +2562,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if interactive.Enabled() {
 			computeNodes, err = interactive.GetInt(interactive.Input{
 				Question: "Compute nodes",
+				Flag:     "compute-nodes",
 				Help:     cmd.Flags().Lookup("compute-nodes").Usage,
 				Default:  computeNodes,
 				Validators: []interactive.Validator{
@@ -2527,11 +2581,31 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	if args.fixSubnetTags && len(subnetIDs) == 0 {
+		r.Reporter.Errorf("Option '--fix-subnet-tags' can only be used with existing subnets")
+		os.Exit(1)
+	}
+	var subnetTags map[string][]string
+	if len(subnetIDs) > 0 {
+		nodes := computeNodes
+		if autoscaling {
+			nodes = maxReplicas
+		}
+		subnetTags = validateSubnetLayout(r, awsClient, awsCreator.AccountID, &aws.ClusterTopology{
+			ComputeInstanceType: computeMachineType,
+			ComputeNodes:        nodes,
+			MultiAZ:             multiAZ,
+			HostedCP:            isHostedCP,
+			ExistingVPC:         true,
+		}, privateLink, subnets, subnetIDs, args.fixSubnetTags)
+	}
+
 	// Worker machine pool labels
 	labels := args.defaultMachinePoolLabels
 	if interactive.Enabled() && !isHostedCP {
 		labels, err = interactive.GetString(interactive.Input{
 			Question: "Worker machine pool labels",
+			Flag:     arguments.NewDefaultMPLabelsFlag,
 			Help:     cmd.Flags().Lookup(arguments.NewDefaultMPLabelsFlag).Usage,
 			Default:  labels,
 			Validators: []interactive.Validator{
@@ -2585,6 +2659,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if cmd.Flags().Changed("network-type") && interactive.Enabled() {
 		args.networkType, err = interactive.GetOption(interactive.Input{
 			Question: "Network Type",
+			Flag:     "network-type",
 			Help:     cmd.Flags().Lookup("network-type").Usage,
 			Options:  ocm.NetworkTypes,
 			Default:  args.networkType,
@@ -2603,6 +2678,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		hostPrefix, err = interactive.GetInt(interactive.Input{
 			Question: "Host prefix",
+			Flag:     "host-prefix",
 			Help:     cmd.Flags().Lookup("host-prefix").Usage,
 			Default:  hostPrefix,
 			Validators: []interactive.Validator{
@@ -2640,6 +2716,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if cmd.Flags().Changed("no-cni") && interactive.Enabled() {
 		noCni, err = interactive.GetBool(interactive.Input{
 			Question: "Disable CNI",
+			Flag:     "no-cni",
 			Help:     cmd.Flags().Lookup("no-cni").Usage,
 			Default:  noCni,
 		})
@@ -2657,6 +2734,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !fedramp.Enabled() && !isHostedCP {
 		fips, err = interactive.GetBool(interactive.Input{
 			Question: "Enable FIPS support",
+			Flag:     "fips",
 			Help:     cmd.Flags().Lookup("fips").Usage,
 			Default:  fips,
 		})
@@ -2681,6 +2759,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !(fips || etcdEncryptionKmsARN != "") {
 		etcdEncryption, err = interactive.GetBool(interactive.Input{
 			Question: "Encrypt etcd data",
+			Flag:     "etcd-encryption",
 			Help:     cmd.Flags().Lookup("etcd-encryption").Usage,
 			Default:  etcdEncryption,
 		})
@@ -2701,6 +2780,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if etcdEncryption && isHostedCP && (etcdEncryptionKmsARN == "" || interactive.Enabled()) {
 		etcdEncryptionKmsARN, err = interactive.GetString(interactive.Input{
 			Question: "Etcd encryption KMS ARN",
+			Flag:     "etcd-encryption-kms-arn",
 			Help:     cmd.Flags().Lookup("etcd-encryption-kms-arn").Usage,
 			Default:  etcdEncryptionKmsARN,
 			Required: true,
@@ -2723,10 +2803,38 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if isSTS && (kmsKeyARN != "" || etcdEncryptionKmsARN != "") {
+		kmsKeyRoles := aws.KMSKeyRoles{
+			InstallerRoleARN:    roleARN,
+			SupportRoleARN:      supportRoleARN,
+			ControlPlaneRoleARN: controlPlaneRoleARN,
+			WorkerRoleARN:       workerRoleARN,
+			OperatorRoleARNs:    map[aws.KMSKeyOperator]string{},
+		}
+		// Operator roles of classic clusters are usually created after the cluster, so they can only be
+		// checked when they already exist:
+		for _, operatorRole := range computedOperatorIamRoleList {
+			roleName, _ := aws.GetResourceIdFromARN(operatorRole.RoleARN)
+			if helper.Contains(operatorRoles, roleName) {
+				operator := aws.KMSKeyOperator{Namespace: operatorRole.Namespace, Name: operatorRole.Name}
+				kmsKeyRoles.OperatorRoleARNs[operator] = operatorRole.RoleARN
+			}
+		}
+		if kmsKeyARN != "" {
+			validateKMSKeyPolicy(r, awsClient, kmsKeyARN, aws.KMSKeyPurposeEBS, isHostedCP, kmsKeyRoles,
+				clusterName, operatorRolesPrefix)
+		}
+		if etcdEncryptionKmsARN != "" {
+			validateKMSKeyPolicy(r, awsClient, etcdEncryptionKmsARN, aws.KMSKeyPurposeEtcd, isHostedCP, kmsKeyRoles,
+				clusterName, operatorRolesPrefix)
+		}
+	}
+
 	disableWorkloadMonitoring := args.disableWorkloadMonitoring
 	if interactive.Enabled() {
 		disableWorkloadMonitoring, err = interactive.GetBool(interactive.Input{
 			Question: "Disable Workload monitoring",
+			Flag:     "disable-workload-monitoring",
 			Help:     cmd.Flags().Lookup("disable-workload-monitoring").Usage,
 			Default:  disableWorkloadMonitoring,
 		})
@@ -2753,6 +2861,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if enableProxy && interactive.Enabled() {
 		httpProxy, err = interactive.GetString(interactive.Input{
 			Question: "HTTP proxy",
+			Flag:     "http-proxy",
 			Help:     cmd.Flags().Lookup("http-proxy").Usage,
 			Default:  httpProxy,
 			Validators: []interactive.Validator{
@@ -2773,6 +2882,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if enableProxy && interactive.Enabled() {
 		httpsProxy, err = interactive.GetString(interactive.Input{
 			Question: "HTTPS proxy",
+			Flag:     "https-proxy",
 			Help:     cmd.Flags().Lookup("https-proxy").Usage,
 			Default:  httpsProxy,
 			Validators: []interactive.Validator{
@@ -2793,6 +2903,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if enableProxy && interactive.Enabled() {
 		noProxyInput, err := interactive.GetString(interactive.Input{
 			Question: "No proxy",
+			Flag:     "no-proxy",
 			Help:     cmd.Flags().Lookup("no-proxy").Usage,
 			Default:  strings.Join(noProxySlice, ","),
 			Validators: []interactive.Validator{
@@ -2830,6 +2941,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if useExistingVPC && interactive.Enabled() {
 		additionalTrustBundleFile, err = interactive.GetCert(interactive.Input{
 			Question: "Additional trust bundle file path",
+			Flag:     "additional-trust-bundle-file",
 			Help:     cmd.Flags().Lookup("additional-trust-bundle-file").Usage,
 			Default:  additionalTrustBundleFile,
 			Validators: []interactive.Validator{
@@ -2889,6 +3001,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 			auditLogRoleARN, err = interactive.GetString(interactive.Input{
 				Question: "Audit log forwarding role ARN",
+				Flag:     "audit-log-arn",
 				Help:     cmd.Flags().Lookup("audit-log-arn").Usage,
 				Default:  auditLogRoleARN,
 				Required: true,
@@ -2954,6 +3067,7 @@ func run(cmd *cobra.Command, _ []string) {
 		} else if interactive.Enabled() && !isHostedCP && shouldAskCustomIngress {
 			routeSelectorArg, err := interactive.GetString(interactive.Input{
 				Question: "Router Ingress Sharding: Route Selector (e.g. 'route=external')",
+				Flag:     ingress.DefaultIngressRouteSelectorFlag,
 				Help:     cmd.Flags().Lookup(ingress.DefaultIngressRouteSelectorFlag).Usage,
 				Default:  args.defaultIngressRouteSelectors,
 				Validators: []interactive.Validator{
@@ -2984,6 +3098,7 @@ func run(cmd *cobra.Command, _ []string) {
 		} else if interactive.Enabled() && !isHostedCP && shouldAskCustomIngress {
 			excludedNamespacesArg, err := interactive.GetString(interactive.Input{
 				Question: "Router Ingress Sharding: Namespace exclusion",
+				Flag:     ingress.DefaultIngressExcludedNamespacesFlag,
 				Help:     cmd.Flags().Lookup(ingress.DefaultIngressExcludedNamespacesFlag).Usage,
 				Default:  args.defaultIngressExcludedNamespaces,
 			})
@@ -3009,6 +3124,7 @@ func run(cmd *cobra.Command, _ []string) {
 				}
 				wildcardPolicyArg, err := interactive.GetOption(interactive.Input{
 					Question: "Route Admission: Wildcard Policy",
+					Flag:     ingress.DefaultIngressWildcardPolicyFlag,
 					Options:  ingress.ValidWildcardPolicies,
 					Help:     cmd.Flags().Lookup(ingress.DefaultIngressWildcardPolicyFlag).Usage,
 					Default:  defaultIngressWildcardSelection,
@@ -3038,6 +3154,7 @@ func run(cmd *cobra.Command, _ []string) {
 				}
 				namespaceOwnershipPolicyArg, err := interactive.GetOption(interactive.Input{
 					Question: "Route Admission: Namespace Ownership Policy",
+					Flag:     ingress.DefaultIngressNamespaceOwnershipPolicyFlag,
 					Options:  ingress.ValidNamespaceOwnershipPolicies,
 					Help:     cmd.Flags().Lookup(ingress.DefaultIngressNamespaceOwnershipPolicyFlag).Usage,
 					Default:  defaultIngressNamespaceOwnershipSelection,
@@ -3183,6 +3300,12 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	err = interactive.SaveAnswers()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Creating cluster '%s'", clusterName)
 		if interactive.Enabled() {
@@ -3217,6 +3340,9 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
+	// The missing subnet tags are only added once the cluster is being created:
+	tagSubnets(r, awsClient, subnetTags)
+
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Cluster '%s' has been created.", clusterName)
 		r.Reporter.Infof(
@@ -3386,6 +3512,42 @@ func validateOperatorRolesAvailabilityUnderUserAwsAccount(awsClient aws.Client,
 	return nil
 }
 
+// validateKMSKeyPolicy checks that the policy of a customer managed key grants the roles of the
+// cluster the permissions they need, as a wrong key policy is only detected during the installation
+func validateKMSKeyPolicy(r *rosa.Runtime, awsClient aws.Client, keyARN string, purpose string, isHostedCP bool,
+	roles aws.KMSKeyRoles, clusterName string, operatorRolesPrefix string) {
+	grants := aws.KMSKeyGrants(purpose, isHostedCP, roles)
+	missing, unverified, err := awsClient.ValidateKMSKeyPolicy(keyARN, grants)
+	if err != nil {
+		r.Reporter.Warnf("Unable to check the policy of KMS key '%s': %v", keyARN, err)
+		return
+	}
+	if len(missing) == 0 && len(unverified) == 0 {
+		r.Reporter.Debugf("Policy of KMS key '%s' grants the permissions required by the cluster", keyARN)
+		return
+	}
+	command := fmt.Sprintf("rosa create kms-key --cluster-name %s --purpose %s --operator-roles-prefix %s "+
+		"--kms-key-arn %s", clusterName, purpose, operatorRolesPrefix, keyARN)
+	if isHostedCP {
+		command += " --hosted-cp"
+	}
+	accountRolesPrefix, err := getAccountRolePrefix(isHostedCP, roles.InstallerRoleARN, aws.InstallerAccountRole)
+	if err == nil && accountRolesPrefix != aws.DefaultPrefix {
+		command += fmt.Sprintf(" --prefix %s", accountRolesPrefix)
+	}
+	if len(missing) == 0 {
+		// The key policy delegates to the IAM policies of the account, or uses conditions, so the
+		// permissions may still be granted:
+		r.Reporter.Warnf("Unable to verify that the policy of KMS key '%s' grants all the permissions "+
+			"required by the cluster:\n  %s\nTo grant them in the key policy, run:\n\n  %s\n",
+			keyARN, strings.Join(unverified, "\n  "), command)
+		return
+	}
+	r.Reporter.Errorf("The policy of KMS key '%s' doesn't grant all the permissions required by the cluster:"+
+		"\n  %s\nTo update the key policy, run:\n\n  %s\n", keyARN, strings.Join(missing, "\n  "), command)
+	os.Exit(1)
+}
+
 func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, isHostedCP bool) *v1.OidcConfig {
 	if !isSTS {
 		return nil
@@ -3437,6 +3599,86 @@ func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, is
 	return oidcConfig
 }
 
+// validateSubnetLayout checks the tags, free addresses and routes of the selected subnets and
+// prints a report of the issues found. When requested, it returns the missing load balancer tags
+// so that they are added once the cluster is created.
+func validateSubnetLayout(r *rosa.Runtime, awsClient aws.Client, accountID string,
+	topology *aws.ClusterTopology, privateLink bool, subnets []ec2types.Subnet, subnetIDs []string,
+	fixTags bool) map[string][]string {
+	selected := []ec2types.Subnet{}
+	for _, subnet := range subnets {
+		if helper.Contains(subnetIDs, awssdk.ToString(subnet.SubnetId)) {
+			selected = append(selected, subnet)
+		}
+	}
+	report, err := awsClient.ValidateSubnets(topology, privateLink, accountID, selected)
+	if err != nil {
+		r.Reporter.Warnf("Failed to validate the subnets: %v", err)
+		return nil
+	}
+
+	if len(report.Issues) > 0 || debug.Enabled() {
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "SUBNET\tAZ\tCIDR\tTYPE\tROUTE TABLE\tFREE IPS\tREQUIRED IPS\n")
+		for _, check := range report.Subnets {
+			subnetType := "private"
+			if check.Public {
+				subnetType = "public"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%d\t%d\n", check.SubnetID, check.AvailabilityZone,
+				check.CIDR, subnetType, check.RouteTableID, check.AvailableIPs, check.RequiredIPs)
+		}
+		writer.Flush()
+		fmt.Println()
+	}
+
+	missingTags := report.MissingTags()
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Severity == aws.SubnetIssueError:
+			r.Reporter.Errorf("Subnet '%s': %s", issue.SubnetID, issue.Message)
+		case issue.MissingTag != "" && fixTags:
+			r.Reporter.Infof("Subnet '%s': %s", issue.SubnetID, issue.Message)
+		default:
+			r.Reporter.Warnf("Subnet '%s': %s", issue.SubnetID, issue.Message)
+		}
+	}
+	if report.HasErrors() {
+		os.Exit(1)
+	}
+
+	if len(missingTags) == 0 {
+		return nil
+	}
+	if !fixTags {
+		r.Reporter.Warnf("Load balancers may not be created in the right subnets. " +
+			"Run with '--fix-subnet-tags' to add the missing tags")
+		return nil
+	}
+	if args.dryRun {
+		r.Reporter.Infof("The missing subnet tags will be added when the cluster is created")
+	}
+	return missingTags
+}
+
+// tagSubnets adds the given tags, indexed by subnet ID, to the subnets
+func tagSubnets(r *rosa.Runtime, awsClient aws.Client, missingTags map[string][]string) {
+	if len(missingTags) == 0 {
+		return
+	}
+	err := awsClient.TagSubnets(missingTags)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	taggedSubnetIDs := helper.MapKeys(missingTags)
+	sort.Strings(taggedSubnetIDs)
+	for _, subnetID := range taggedSubnetIDs {
+		r.Reporter.Infof("Added tags %s to subnet '%s'",
+			helper.SliceToSortedString(missingTags[subnetID]), subnetID)
+	}
+}
+
 func filterPrivateSubnets(initialSubnets []ec2types.Subnet, r *rosa.Runtime) []ec2types.Subnet {
 	excludedSubnetsDueToPublic := []string{}
 	filteredSubnets := []ec2types.Subnet{}
@@ -3619,6 +3861,7 @@ func selectAvailabilityZonesInteractively(cmd *cobra.Command, optionsAvailabilit
 	if multiAZ {
 		availabilityZones, err = interactive.GetMultipleOptions(interactive.Input{
 			Question: "Availability zones",
+			Flag:     "availability-zones",
 			Help:     cmd.Flags().Lookup("availability-zones").Usage,
 			Required: true,
 			Options:  optionsAvailabilityZones,
@@ -3633,6 +3876,7 @@ func selectAvailabilityZonesInteractively(cmd *cobra.Command, optionsAvailabilit
 		var availabilityZone string
 		availabilityZone, err = interactive.GetOption(interactive.Input{
 			Question: "Availability zone",
+			Flag:     "availability-zones",
 			Help:     cmd.Flags().Lookup("availability-zones").Usage,
 			Required: true,
 			Options:  optionsAvailabilityZones,
@@ -4106,6 +4350,7 @@ func getMachinePoolRootDisk(r *rosa.Runtime, cmd *cobra.Command, version string,
 			// Also, if nothing is given, we want to display the default value fetched from the OCM API
 			machinePoolRootDiskSizeStr, err = interactive.GetString(interactive.Input{
 				Question: "Machine pool root disk size (GiB or TiB)",
+				Flag:     workerDiskSizeFlag,
 				Help:     cmd.Flags().Lookup(workerDiskSizeFlag).Usage,
 				Default:  machinePoolRootDiskSizeStr,
 				Validators: []interactive.Validator{
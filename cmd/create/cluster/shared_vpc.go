@@ -31,6 +31,7 @@ func isSubnetBelongToSharedVpc(r *rosa.Runtime, accountID string, subnetIDs []st
 func getPrivateHostedZoneID(cmd *cobra.Command, privateHostedZoneID string) (string, error) {
 	res, err := interactive.GetString(interactive.Input{
 		Question: "Private hosted zone ID",
+		Flag:     "private-hosted-zone-id",
 		Help:     cmd.Flags().Lookup("private-hosted-zone-id").Usage,
 		Default:  privateHostedZoneID,
 		Required: true,
@@ -45,6 +46,7 @@ func getPrivateHostedZoneID(cmd *cobra.Command, privateHostedZoneID string) (str
 func getSharedVpcRoleArn(cmd *cobra.Command, sharedVpcRoleArn string) (string, error) {
 	res, err := interactive.GetString(interactive.Input{
 		Question: "Shared VPC role ARN",
+		Flag:     "shared-vpc-role-arn",
 		Help:     cmd.Flags().Lookup("shared-vpc-role-arn").Usage,
 		Default:  sharedVpcRoleArn,
 		Required: true,
@@ -67,6 +69,7 @@ func getBaseDomain(r *rosa.Runtime, cmd *cobra.Command, baseDomain string) (stri
 
 	res, err := interactive.GetOption(interactive.Input{
 		Question: "Base Domain",
+		Flag:     "base-domain",
 		Help:     cmd.Flags().Lookup("base-domain").Usage,
 		Default:  baseDomain,
 		Required: true,
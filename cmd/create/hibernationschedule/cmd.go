@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	hibernate string
+	resume    string
+	timezone  string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "hibernation-schedule",
+	Aliases: []string{"hibernationschedule", "hibernation-schedules"},
+	Short:   "Create a hibernation schedule for a cluster",
+	Long: "Create a schedule that hibernates and resumes a cluster at the given times. Schedules are stored " +
+		"locally and executed by 'rosa hibernation run', which is meant to run as a cron job or in a container.",
+	Example: `  # Hibernate the cluster named "dev" on weekday evenings and resume it on weekday mornings
+  rosa create hibernation-schedule --cluster=dev --hibernate "0 19 * * 1-5" --resume "0 7 * * 1-5" \
+  --timezone Europe/Prague`,
+	Run:    run,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.hibernate,
+		"hibernate",
+		"",
+		"Cron expression for the times at which the cluster will be hibernated.",
+	)
+
+	flags.StringVar(
+		&args.resume,
+		"resume",
+		"",
+		"Cron expression for the times at which the cluster will be resumed.",
+	)
+
+	flags.StringVar(
+		&args.timezone,
+		"timezone",
+		"UTC",
+		"IANA time zone in which the cron expressions are evaluated, for example 'Europe/Prague'.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime) error {
+	if args.hibernate == "" || args.resume == "" {
+		return fmt.Errorf("Both '--hibernate' and '--resume' schedules are required")
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.Hypershift().Enabled() {
+		return fmt.Errorf("Hosted Control Plane clusters do not support hibernation")
+	}
+
+	enabled, err := r.OCMClient.IsCapabilityEnabled(ocm.HibernateCapability)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("The '%s' capability is not set for current org", ocm.HibernateCapability)
+	}
+
+	schedule := &hibernation.Schedule{
+		ClusterID:   cluster.ID(),
+		ClusterName: cluster.Name(),
+		Hibernate:   args.hibernate,
+		Resume:      args.resume,
+		Timezone:    args.timezone,
+		CreatedAt:   time.Now().UTC(),
+	}
+	err = schedule.Validate()
+	if err != nil {
+		return err
+	}
+
+	path, err := hibernation.Location()
+	if err != nil {
+		return err
+	}
+	err = hibernation.Update(path, func(spec *hibernation.Spec) error {
+		if spec.Find(cluster.ID()) != nil {
+			return fmt.Errorf("Cluster '%s' already has a hibernation schedule. "+
+				"Delete it first with 'rosa delete hibernation-schedule -c %s'", clusterKey, clusterKey)
+		}
+		spec.Schedules = append(spec.Schedules, schedule)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	action, at, err := schedule.Next(time.Now())
+	if err != nil {
+		return err
+	}
+	r.Reporter.Infof("Created hibernation schedule for cluster '%s' in '%s'", clusterKey, path)
+	r.Reporter.Infof("The next action is to %s the cluster at %s", action, at.Format(time.RFC3339))
+	r.Reporter.Infof("Schedules are only executed while 'rosa hibernation run' runs periodically, " +
+		"for example from a cron job or with '--interval' in a container")
+	return nil
+}
@@ -357,6 +357,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		idpType, err = interactive.GetOption(interactive.Input{
 			Question: "Type of identity provider",
+			Flag:     "type",
 			Options:  validIdps,
 			Required: true,
 			Default:  idpType,
@@ -430,6 +431,7 @@ func run(cmd *cobra.Command, _ []string) {
 func getIDPName(cmd *cobra.Command, idpName string, r *rosa.Runtime) string {
 	idpName, err := interactive.GetString(interactive.Input{
 		Question: "Identity provider name",
+		Flag:     "name",
 		Help:     cmd.Flags().Lookup("name").Usage,
 		Default:  idpName,
 		Required: true,
@@ -78,6 +78,7 @@ func buildGithubIdp(cmd *cobra.Command,
 		if restrictType == "organizations" {
 			organizations, err = interactive.GetString(interactive.Input{
 				Question: "GitHub organizations",
+				Flag:     "organizations",
 				Help:     fmt.Sprintf("%s\n%s", cmd.Flags().Lookup("organizations").Usage, orgHelp),
 				Default:  organizations,
 				Required: true,
@@ -88,6 +89,7 @@ func buildGithubIdp(cmd *cobra.Command,
 		} else if restrictType == "teams" {
 			teams, err = interactive.GetString(interactive.Input{
 				Question: "GitHub teams",
+				Flag:     "teams",
 				Help:     fmt.Sprintf("%s%s", cmd.Flags().Lookup("teams").Usage, orgHelp),
 				Default:  teams,
 				Required: true,
@@ -187,6 +189,7 @@ func buildGithubIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		githubHostname, err = interactive.GetString(interactive.Input{
 			Question: "GitHub Enterprise Hostname",
+			Flag:     "hostname",
 			Help:     cmd.Flags().Lookup("hostname").Usage,
 			Default:  githubHostname,
 			Validators: []interactive.Validator{
@@ -212,6 +215,7 @@ func buildGithubIdp(cmd *cobra.Command,
 		if interactive.Enabled() {
 			caPath, err = interactive.GetCert(interactive.Input{
 				Question: "CA file path",
+				Flag:     "ca",
 				Help:     cmd.Flags().Lookup("ca").Usage,
 				Default:  caPath,
 			})
@@ -45,6 +45,7 @@ func buildGitlabIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		gitlabURL, err = interactive.GetString(interactive.Input{
 			Question: "URL",
+			Flag:     "host-url",
 			Help:     cmd.Flags().Lookup("host-url").Usage,
 			Default:  gitlabURL,
 			Required: true,
@@ -113,6 +114,7 @@ func buildGitlabIdp(cmd *cobra.Command,
 	if interactive.Enabled() && gitlabURL != cmd.Flags().Lookup("host-url").DefValue {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
+			Flag:     "ca",
 			Help:     cmd.Flags().Lookup("ca").Usage,
 			Default:  caPath,
 		})
@@ -101,6 +101,7 @@ func buildGoogleIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		hostedDomain, err = interactive.GetString(interactive.Input{
 			Question: "Hosted domain",
+			Flag:     "hosted-domain",
 			Help:     cmd.Flags().Lookup("hosted-domain").Usage,
 			Default:  hostedDomain,
 			Required: mappingMethod != "lookup",
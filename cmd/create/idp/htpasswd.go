@@ -126,6 +126,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 		var err error
 		htpasswdFile, err = interactive.GetString(interactive.Input{
 			Question: "Configure users from HTPasswd file",
+			Flag:     "from-file",
 			Help:     cmd.Flags().Lookup("from-file").Usage,
 			Default:  htpasswdFile,
 			Required: false,
@@ -199,6 +200,7 @@ func GetIdpUserNameFromPrompt(cmd *cobra.Command, r *rosa.Runtime,
 	}
 	username, err := interactive.GetString(interactive.Input{
 		Question:   "Username",
+		Flag:       usernameKey,
 		Help:       cmd.Flags().Lookup(usernameKey).Usage,
 		Default:    defaultUsername,
 		Required:   true,
@@ -214,6 +216,7 @@ func GetIdpPasswordFromPrompt(cmd *cobra.Command, r *rosa.Runtime,
 	passwordKey, defaultPassword string) string {
 	password, err := interactive.GetPassword(interactive.Input{
 		Question: "Password",
+		Flag:     passwordKey,
 		Help:     cmd.Flags().Lookup(passwordKey).Usage,
 		Default:  defaultPassword,
 		Required: true,
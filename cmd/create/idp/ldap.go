@@ -57,6 +57,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		ldapURL, err = interactive.GetString(interactive.Input{
 			Question: "LDAP URL",
+			Flag:     "url",
 			Help:     cmd.Flags().Lookup("url").Usage,
 			Default:  ldapURL,
 			Required: true,
@@ -80,6 +81,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() && !needsSecure {
 		ldapInsecure, err = interactive.GetBool(interactive.Input{
 			Question: "Insecure",
+			Flag:     "insecure",
 			Help:     cmd.Flags().Lookup("insecure").Usage,
 			Default:  !needsSecure,
 		})
@@ -95,6 +97,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() && !ldapInsecure {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
+			Flag:     "ca",
 			Help:     cmd.Flags().Lookup("ca").Usage,
 			Default:  caPath,
 		})
@@ -125,6 +128,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		ldapBindDN, err = interactive.GetString(interactive.Input{
 			Question: "Bind DN",
+			Flag:     "bind-dn",
 			Help:     cmd.Flags().Lookup("bind-dn").Usage,
 			Default:  ldapBindDN,
 		})
@@ -135,6 +139,7 @@ func buildLdapIdp(cmd *cobra.Command,
 		if ldapBindDN != "" {
 			ldapBindPassword, err = interactive.GetPassword(interactive.Input{
 				Question: "Bind password",
+				Flag:     "bind-password",
 				Help:     cmd.Flags().Lookup("bind-password").Usage,
 				Required: true,
 			})
@@ -156,6 +161,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		ldapIDs, err = interactive.GetString(interactive.Input{
 			Question: "ID",
+			Flag:     "id-attributes",
 			Help:     cmd.Flags().Lookup("id-attributes").Usage,
 			Default:  ldapIDs,
 			Required: true,
@@ -174,6 +180,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		ldapUsernames, err = interactive.GetString(interactive.Input{
 			Question: "Preferred username",
+			Flag:     "username-attributes",
 			Help:     cmd.Flags().Lookup("username-attributes").Usage,
 			Default:  ldapUsernames,
 		})
@@ -183,6 +190,7 @@ func buildLdapIdp(cmd *cobra.Command,
 
 		ldapDisplayNames, err = interactive.GetString(interactive.Input{
 			Question: "Name",
+			Flag:     "name-attributes",
 			Help:     cmd.Flags().Lookup("name-attributes").Usage,
 			Default:  ldapDisplayNames,
 		})
@@ -192,6 +200,7 @@ func buildLdapIdp(cmd *cobra.Command,
 
 		ldapEmails, err = interactive.GetString(interactive.Input{
 			Question: "Email",
+			Flag:     "email-attributes",
 			Help:     cmd.Flags().Lookup("email-attributes").Usage,
 			Default:  ldapEmails,
 		})
@@ -98,6 +98,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		issuerURL, err = interactive.GetString(interactive.Input{
 			Question: "Issuer URL",
+			Flag:     "issuer-url",
 			Help:     cmd.Flags().Lookup("issuer-url").Usage,
 			Default:  issuerURL,
 			Required: true,
@@ -120,6 +121,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
+			Flag:     "ca",
 			Help:     cmd.Flags().Lookup("ca").Usage,
 			Default:  caPath,
 		})
@@ -154,6 +156,7 @@ separated by commas.`,
 
 		email, err = interactive.GetString(interactive.Input{
 			Question: "Email",
+			Flag:     "email-claims",
 			Help:     cmd.Flags().Lookup("email-claims").Usage,
 			Default:  email,
 		})
@@ -162,6 +165,7 @@ separated by commas.`,
 		}
 		name, err = interactive.GetString(interactive.Input{
 			Question: "Name",
+			Flag:     "name-claims",
 			Help:     cmd.Flags().Lookup("name-claims").Usage,
 			Default:  name,
 		})
@@ -170,6 +174,7 @@ separated by commas.`,
 		}
 		username, err = interactive.GetString(interactive.Input{
 			Question: "Preferred username",
+			Flag:     "username-claims",
 			Help:     cmd.Flags().Lookup("username-claims").Usage,
 			Default:  username,
 		})
@@ -178,6 +183,7 @@ separated by commas.`,
 		}
 		groups, err = interactive.GetString(interactive.Input{
 			Question: "Groups",
+			Flag:     "groups-claims",
 			Help:     cmd.Flags().Lookup("groups-claims").Usage,
 			Default:  groups,
 		})
@@ -210,6 +216,7 @@ separated by commas.`,
 	if interactive.Enabled() {
 		scopes, err = interactive.GetString(interactive.Input{
 			Question: "Extra scopes",
+			Flag:     "extra-scopes",
 			Help:     cmd.Flags().Lookup("extra-scopes").Usage,
 			Default:  scopes,
 		})
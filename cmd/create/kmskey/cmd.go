@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmskey
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	clusterName         string
+	purpose             string
+	hostedCP            bool
+	prefix              string
+	operatorRolesPrefix string
+	kmsKeyARN           string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "kms-key",
+	Aliases: []string{"kmskey"},
+	Short:   "Create a KMS key with the key policy required by a cluster",
+	Long: "Create a customer managed KMS key, or update the policy of an existing one, granting the " +
+		"account and operator roles of a cluster the permissions they need to use it. The operator " +
+		"roles need to exist before they can be added to the key policy.",
+	Example: `  # Create a key to encrypt the EBS volumes of cluster "mycluster"
+  rosa create kms-key --cluster-name mycluster --operator-roles-prefix mycluster-a1b2
+
+  # Create a key to encrypt etcd of a hosted control plane cluster
+  rosa create kms-key --cluster-name mycluster --purpose etcd --hosted-cp \
+    --operator-roles-prefix mycluster-a1b2
+
+  # Update the policy of an existing key
+  rosa create kms-key --cluster-name mycluster --operator-roles-prefix mycluster-a1b2 \
+    --kms-key-arn arn:aws:kms:us-east-1:123456789012:key/1a2b3c4d-5e6f-1a2b-3c4d-5e6f1a2b3c4d`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterName,
+		"cluster-name",
+		"c",
+		"",
+		"Name of the cluster that will use the key. It is used to name and tag the key.",
+	)
+	flags.StringVar(
+		&args.purpose,
+		"purpose",
+		aws.KMSKeyPurposeEBS,
+		fmt.Sprintf("What the key will be used for. Valid values are %s. Keys for etcd "+
+			"can only be used by hosted control plane clusters.", helper.SliceToSortedString(aws.KMSKeyPurposes)),
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Grant the permissions needed by a cluster with a hosted control plane.",
+	)
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles of the cluster.",
+	)
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles of the cluster.",
+	)
+	flags.StringVar(
+		&args.kmsKeyARN,
+		"kms-key-arn",
+		"",
+		"ARN of an existing key whose policy will be updated. "+
+			"By default the key is found by its alias, and created if it doesn't exist.",
+	)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if (args.clusterName == "" || args.operatorRolesPrefix == "") && !interactive.Enabled() {
+		interactive.Enable()
+	}
+	if interactive.Enabled() {
+		var err error
+		args.clusterName, err = interactive.GetString(interactive.Input{
+			Question: "Cluster name",
+			Flag:     "cluster-name",
+			Help:     cmd.Flags().Lookup("cluster-name").Usage,
+			Default:  args.clusterName,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid cluster name: %s", err)
+			os.Exit(1)
+		}
+		args.purpose, err = interactive.GetOption(interactive.Input{
+			Question: "Purpose",
+			Flag:     "purpose",
+			Help:     cmd.Flags().Lookup("purpose").Usage,
+			Options:  aws.KMSKeyPurposes,
+			Default:  args.purpose,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid purpose: %s", err)
+			os.Exit(1)
+		}
+		args.hostedCP, err = interactive.GetBool(interactive.Input{
+			Question: "Hosted control plane",
+			Flag:     "hosted-cp",
+			Help:     cmd.Flags().Lookup("hosted-cp").Usage,
+			Default:  args.hostedCP,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid hosted-cp value: %s", err)
+			os.Exit(1)
+		}
+		args.prefix, err = interactive.GetString(interactive.Input{
+			Question: "Account roles prefix",
+			Flag:     "prefix",
+			Help:     cmd.Flags().Lookup("prefix").Usage,
+			Default:  args.prefix,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid account roles prefix: %s", err)
+			os.Exit(1)
+		}
+		args.operatorRolesPrefix, err = interactive.GetString(interactive.Input{
+			Question: "Operator roles prefix",
+			Flag:     "operator-roles-prefix",
+			Help:     cmd.Flags().Lookup("operator-roles-prefix").Usage,
+			Default:  args.operatorRolesPrefix,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid operator roles prefix: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	err := validateArgs(args.clusterName, args.purpose, args.hostedCP, args.operatorRolesPrefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	roles, err := findRoles(r, args.purpose, args.hostedCP, args.prefix, args.operatorRolesPrefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	grants := aws.KMSKeyGrants(args.purpose, args.hostedCP, roles)
+
+	alias := aws.KMSKeyAlias(args.clusterName, args.purpose)
+	keyARN := args.kmsKeyARN
+	if keyARN == "" {
+		keyARN, err = r.AWSClient.FindKMSKeyByAlias(alias)
+		if err != nil {
+			r.Reporter.Errorf("Failed to find KMS key with alias '%s': %v", alias, err)
+			os.Exit(1)
+		}
+	}
+
+	if keyARN == "" {
+		policy := aws.BuildKMSKeyPolicy(r.Creator.Partition, r.Creator.AccountID, grants)
+		keyARN, err = r.AWSClient.CreateKMSKey(alias,
+			fmt.Sprintf("Key used to encrypt %s of ROSA cluster '%s'", args.purpose, args.clusterName),
+			policy,
+			map[string]string{
+				tags.ClusterName:   args.clusterName,
+				tags.KMSKeyPurpose: args.purpose,
+				tags.RedHatManaged: tags.True,
+			})
+		if err != nil {
+			r.Reporter.Errorf("Failed to create KMS key: %v", err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Created KMS key '%s' with alias '%s'", keyARN, alias)
+	} else {
+		policy, err := r.AWSClient.GetKMSKeyPolicy(keyARN)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the policy of KMS key '%s': %v", keyARN, err)
+			os.Exit(1)
+		}
+		// The permissions that may be granted by the IAM policies of the account are added to the
+		// key policy as well, so that the cluster doesn't depend on them:
+		missing, unverified := aws.MissingKMSKeyPermissions(policy, grants)
+		missing = append(missing, unverified...)
+		if len(missing) == 0 {
+			r.Reporter.Infof("The policy of KMS key '%s' already grants the permissions required by the cluster",
+				keyARN)
+		} else {
+			r.Reporter.Infof("The policy of KMS key '%s' doesn't grant all the permissions required "+
+				"by the cluster:\n  %s", keyARN, strings.Join(missing, "\n  "))
+			if !confirm.Prompt(true, "Update the policy of KMS key '%s'?", keyARN) {
+				os.Exit(0)
+			}
+			err = r.AWSClient.PutKMSKeyPolicy(keyARN, aws.AddKMSKeyGrants(policy, grants))
+			if err != nil {
+				r.Reporter.Errorf("Failed to update the policy of KMS key '%s': %v", keyARN, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Updated the policy of KMS key '%s'", keyARN)
+		}
+	}
+
+	flag := "kms-key-arn"
+	if args.purpose == aws.KMSKeyPurposeEtcd {
+		flag = "etcd-encryption-kms-arn"
+	}
+	r.Reporter.Infof("To create a cluster using this key, run:\n\n  "+
+		"rosa create cluster --cluster-name %s --operator-roles-prefix %s --%s %s\n",
+		args.clusterName, args.operatorRolesPrefix, flag, keyARN)
+}
+
+func validateArgs(clusterName string, purpose string, hostedCP bool, operatorRolesPrefix string) error {
+	if !ocm.IsValidClusterName(clusterName) {
+		return fmt.Errorf("Cluster name must consist of no more than 54 lowercase alphanumeric " +
+			"characters or '-', start with a letter, and end with an alphanumeric character.")
+	}
+	if !helper.Contains(aws.KMSKeyPurposes, purpose) {
+		return fmt.Errorf("Expected a valid purpose, valid values are %s",
+			helper.SliceToSortedString(aws.KMSKeyPurposes))
+	}
+	if purpose == aws.KMSKeyPurposeEtcd && !hostedCP {
+		return fmt.Errorf("Keys to encrypt etcd can only be used by hosted control plane clusters, " +
+			"use '--hosted-cp'")
+	}
+	if operatorRolesPrefix == "" {
+		return fmt.Errorf("Expected a prefix for the operator roles")
+	}
+	if !aws.RoleNameRE.MatchString(operatorRolesPrefix) {
+		return fmt.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
+	}
+	return nil
+}
+
+// findRoles finds the ARNs of the account and operator roles that need access to the key. KMS
+// rejects policies with principals that don't exist, so missing operator roles are skipped.
+func findRoles(r *rosa.Runtime, purpose string, hostedCP bool, prefix string,
+	operatorRolesPrefix string) (aws.KMSKeyRoles, error) {
+	roles := aws.KMSKeyRoles{
+		OperatorRoleARNs: map[aws.KMSKeyOperator]string{},
+	}
+
+	accountRoles := aws.AccountRoles
+	if hostedCP {
+		accountRoles = aws.HCPAccountRoles
+	}
+	accountRoleARNs := map[string]*string{
+		aws.InstallerAccountRole:    &roles.InstallerRoleARN,
+		aws.SupportAccountRole:      &roles.SupportRoleARN,
+		aws.ControlPlaneAccountRole: &roles.ControlPlaneRoleARN,
+		aws.WorkerAccountRole:       &roles.WorkerRoleARN,
+	}
+	for roleType, role := range accountRoles {
+		roleName := common.GetRoleName(prefix, role.Name)
+		exists, roleARN, err := r.AWSClient.CheckRoleExists(roleName)
+		if err != nil {
+			return roles, fmt.Errorf("Failed to get account role '%s': %v", roleName, err)
+		}
+		if !exists {
+			return roles, fmt.Errorf("Account role '%s' doesn't exist, create the account roles with "+
+				"'rosa create account-roles' first", roleName)
+		}
+		*accountRoleARNs[roleType] = roleARN
+	}
+
+	for _, operator := range aws.KMSKeyOperators(purpose, hostedCP) {
+		roleName := aws.GetOperatorRoleName(operatorRolesPrefix, operator.Namespace, operator.Name)
+		exists, roleARN, err := r.AWSClient.CheckRoleExists(roleName)
+		if err != nil {
+			return roles, fmt.Errorf("Failed to get operator role '%s': %v", roleName, err)
+		}
+		if !exists {
+			r.Reporter.Warnf("Operator role '%s' doesn't exist yet and will not be added to the key policy. "+
+				"Run this command again once the operator roles are created.", roleName)
+			continue
+		}
+		roles.OperatorRoleARNs[operator] = roleARN
+	}
+	return roles, nil
+}
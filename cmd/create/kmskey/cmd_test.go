@@ -0,0 +1,32 @@
+package kmskey
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Create KMS key", func() {
+	It("Accepts valid options", func() {
+		Expect(validateArgs("mycluster", aws.KMSKeyPurposeEtcd, true, "mycluster-a1b2")).To(Succeed())
+	})
+
+	DescribeTable("Fails with invalid options",
+		func(clusterName string, purpose string, hostedCP bool, prefix string, message string) {
+			err := validateArgs(clusterName, purpose, hostedCP, prefix)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("invalid cluster name", "MyCluster", aws.KMSKeyPurposeEBS, false, "mycluster",
+			"Cluster name must consist"),
+		Entry("invalid purpose", "mycluster", "s3", false, "mycluster",
+			"Expected a valid purpose"),
+		Entry("etcd key for a classic cluster", "mycluster", aws.KMSKeyPurposeEtcd, false, "mycluster",
+			"use '--hosted-cp'"),
+		Entry("missing operator roles prefix", "mycluster", aws.KMSKeyPurposeEBS, false, "",
+			"Expected a prefix"),
+		Entry("invalid operator roles prefix", "mycluster", aws.KMSKeyPurposeEBS, false, "my cluster",
+			"Expected valid operator roles prefix"),
+	)
+})
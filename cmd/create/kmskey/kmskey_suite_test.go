@@ -0,0 +1,13 @@
+package kmskey
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKMSKey(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Create KMS key suite")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/completion"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/securitygroups"
@@ -232,6 +233,7 @@ func init() {
 
 	interactive.AddFlag(flags)
 	output.AddFlag(Cmd)
+	Cmd.RegisterFlagCompletionFunc("version", completion.Versions)
 }
 
 func run(cmd *cobra.Command, _ []string) {
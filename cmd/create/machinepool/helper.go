@@ -31,6 +31,7 @@ func getSubnetFromUser(cmd *cobra.Command, r *rosa.Runtime, isSubnetSet bool, cl
 	if !isSubnetSet && interactive.Enabled() {
 		selectSubnet, err = interactive.GetBool(interactive.Input{
 			Question: question,
+			Flag:     "subnet",
 			Help:     cmd.Flags().Lookup("subnet").Usage,
 			Default:  false,
 			Required: false,
@@ -52,6 +53,7 @@ func getSubnetFromUser(cmd *cobra.Command, r *rosa.Runtime, isSubnetSet bool, cl
 
 		subnetOption, err := interactive.GetOption(interactive.Input{
 			Question: "Subnet ID",
+			Flag:     "subnet",
 			Help:     cmd.Flags().Lookup("subnet").Usage,
 			Options:  subnetOptions,
 			Default:  subnetOptions[0],
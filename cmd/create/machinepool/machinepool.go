@@ -104,6 +104,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	if name == "" || interactive.Enabled() {
 		name, err = interactive.GetString(interactive.Input{
 			Question: "Machine pool name",
+			Flag:     "name",
 			Default:  name,
 			Required: true,
 			Validators: []interactive.Validator{
@@ -140,6 +141,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		if !isMultiAvailabilityZoneSet && interactive.Enabled() && !confirm.Yes() {
 			multiAZMachinePool, err = interactive.GetBool(interactive.Input{
 				Question: "Create multi-AZ machine pool",
+				Flag:     "multi-availability-zone",
 				Help:     cmd.Flags().Lookup("multi-availability-zone").Usage,
 				Default:  true,
 				Required: false,
@@ -164,6 +166,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				if !isAvailabilityZoneSet && interactive.Enabled() {
 					availabilityZone, err = interactive.GetOption(interactive.Input{
 						Question: "AWS availability zone",
+						Flag:     "availability-zone",
 						Help:     cmd.Flags().Lookup("availability-zone").Usage,
 						Options:  cluster.Nodes().AvailabilityZones(),
 						Default:  availabilityZone,
@@ -200,6 +203,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	if !isReplicasSet && !autoscaling && !isAutoscalingSet && interactive.Enabled() {
 		autoscaling, err = interactive.GetBool(interactive.Input{
 			Question: "Enable autoscaling",
+			Flag:     "enable-autoscaling",
 			Help:     cmd.Flags().Lookup("enable-autoscaling").Usage,
 			Default:  autoscaling,
 			Required: false,
@@ -219,6 +223,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Min replicas",
+				Flag:     "min-replicas",
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  minReplicas,
 				Required: true,
@@ -240,6 +245,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		if interactive.Enabled() || !isMaxReplicasSet {
 			maxReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Max replicas",
+				Flag:     "max-replicas",
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  maxReplicas,
 				Required: true,
@@ -266,6 +272,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		if interactive.Enabled() || !isReplicasSet {
 			replicas, err = interactive.GetInt(interactive.Input{
 				Question: "Replicas",
+				Flag:     "replicas",
 				Help:     cmd.Flags().Lookup("replicas").Usage,
 				Default:  replicas,
 				Required: true,
@@ -343,6 +350,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		}
 		instanceType, err = interactive.GetOption(interactive.Input{
 			Question: "Instance type",
+			Flag:     "instance-type",
 			Help:     cmd.Flags().Lookup("instance-type").Usage,
 			Options:  instanceTypeList.GetAvailableIDs(cluster.MultiAZ()),
 			Default:  instanceType,
@@ -394,6 +402,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	if !isSpotSet && !isSpotMaxPriceSet && !isLocalZone && interactive.Enabled() {
 		useSpotInstances, err = interactive.GetBool(interactive.Input{
 			Question: "Use spot instances",
+			Flag:     "use-spot-instances",
 			Help:     cmd.Flags().Lookup("use-spot-instances").Usage,
 			Default:  useSpotInstances,
 			Required: false,
@@ -407,6 +416,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	if useSpotInstances && !isSpotMaxPriceSet && interactive.Enabled() {
 		spotMaxPrice, err = interactive.GetString(interactive.Input{
 			Question: "Spot instance max price",
+			Flag:     "spot-max-price",
 			Help:     cmd.Flags().Lookup("spot-max-price").Usage,
 			Required: false,
 			Default:  spotMaxPrice,
@@ -490,6 +500,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 			// Also, if nothing is given, we want to display the default value fetched from the OCM API
 			rootDiskSizeStr, err = interactive.GetString(interactive.Input{
 				Question: "Root disk size (GiB or TiB)",
+				Flag:     "disk-size",
 				Help:     cmd.Flags().Lookup("disk-size").Usage,
 				Default:  rootDiskSizeStr,
 				Validators: []interactive.Validator{
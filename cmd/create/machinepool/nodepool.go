@@ -39,6 +39,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 	if name == "" || interactive.Enabled() {
 		name, err = interactive.GetString(interactive.Input{
 			Question: "Machine pool name",
+			Flag:     "name",
 			Default:  name,
 			Required: true,
 			Validators: []interactive.Validator{
@@ -91,6 +92,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		if interactive.Enabled() {
 			version, err = interactive.GetOption(interactive.Input{
 				Question: "OpenShift version",
+				Flag:     "version",
 				Help:     cmd.Flags().Lookup("version").Usage,
 				Options:  filteredVersionList,
 				Default:  version,
@@ -136,6 +138,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 	if !isReplicasSet && !autoscaling && !isAutoscalingSet && interactive.Enabled() {
 		autoscaling, err = interactive.GetBool(interactive.Input{
 			Question: "Enable autoscaling",
+			Flag:     "enable-autoscaling",
 			Help:     cmd.Flags().Lookup("enable-autoscaling").Usage,
 			Default:  autoscaling,
 			Required: false,
@@ -156,6 +159,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Min replicas",
+				Flag:     "min-replicas",
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  minReplicas,
 				Required: true,
@@ -177,6 +181,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		if interactive.Enabled() || !isMaxReplicasSet {
 			maxReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Max replicas",
+				Flag:     "max-replicas",
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  maxReplicas,
 				Required: true,
@@ -203,6 +208,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		if interactive.Enabled() || !isReplicasSet {
 			replicas, err = interactive.GetInt(interactive.Input{
 				Question: "Replicas",
+				Flag:     "replicas",
 				Help:     cmd.Flags().Lookup("replicas").Usage,
 				Default:  replicas,
 				Required: true,
@@ -313,6 +319,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		}
 		instanceType, err = interactive.GetOption(interactive.Input{
 			Question: "Instance type",
+			Flag:     "instance-type",
 			Help:     cmd.Flags().Lookup("instance-type").Usage,
 			Options:  instanceTypeList.GetAvailableIDs(cluster.MultiAZ()),
 			Default:  instanceType,
@@ -334,6 +341,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 	if interactive.Enabled() {
 		autorepair, err = interactive.GetBool(interactive.Input{
 			Question: "Autorepair",
+			Flag:     "autorepair",
 			Help:     cmd.Flags().Lookup("autorepair").Usage,
 			Default:  autorepair,
 			Required: false,
@@ -368,6 +376,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		if len(availableTuningConfigs) > 0 {
 			inputTuningConfig, err = interactive.GetMultipleOptions(interactive.Input{
 				Question: "Tuning configs",
+				Flag:     "tuning-configs",
 				Help:     cmd.Flags().Lookup("tuning-configs").Usage,
 				Options:  availableTuningConfigs,
 				Default:  inputTuningConfig,
@@ -390,6 +399,7 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 	if interactive.Enabled() {
 		nodeDrainGracePeriod, err = interactive.GetString(interactive.Input{
 			Question: "Node drain grace period",
+			Flag:     "node-drain-grace-period",
 			Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
 			Default:  nodeDrainGracePeriod,
 			Required: false,
@@ -468,6 +478,7 @@ func getSubnetFromAvailabilityZone(cmd *cobra.Command, r *rosa.Runtime, isAvaila
 	if !isAvailabilityZoneSet && interactive.Enabled() {
 		availabilityZone, err = interactive.GetOption(interactive.Input{
 			Question: "AWS availability zone",
+			Flag:     "availability-zone",
 			Help:     cmd.Flags().Lookup("availability-zone").Usage,
 			Options:  availabilityZones,
 			Default:  availabilityZone,
@@ -0,0 +1,237 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	defaultCidr = "10.0.0.0/16"
+	// The VPC is split in eight subnets of the same size, so its prefix must leave room for them
+	minCidrPrefix = 16
+	maxCidrPrefix = 24
+)
+
+var nameRE = regexp.MustCompile(`^[a-zA-Z][-a-zA-Z0-9]{0,127}$`)
+
+var args struct {
+	name        string
+	cidr        string
+	multiAZ     bool
+	privateOnly bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Create a VPC ready to be used by ROSA clusters",
+	Long: "Create a VPC with private subnets and, unless it is private only, public subnets, internet " +
+		"and NAT gateways, using a CloudFormation stack. The subnets are tagged for load balancers and " +
+		"their IDs are printed so that they can be used to create clusters.",
+	Example: `  # Create a single availability zone network named "mynetwork"
+  rosa create network --name mynetwork
+
+  # Create a multi availability zone network without public subnets
+  rosa create network --name mynetwork --multi-az --private-only --cidr 10.10.0.0/16`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.name,
+		"name",
+		"n",
+		"",
+		"Name of the network, also used as the name of the CloudFormation stack.",
+	)
+	flags.StringVar(
+		&args.cidr,
+		"cidr",
+		defaultCidr,
+		"CIDR block of the VPC. It is split in eight subnets of the same size.",
+	)
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Create subnets in three availability zones instead of one.",
+	)
+	flags.BoolVar(
+		&args.privateOnly,
+		"private-only",
+		false,
+		"Only create private subnets, without internet or NAT gateways. "+
+			"Egress traffic needs to be provided by other means.",
+	)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if args.name == "" && !interactive.Enabled() {
+		interactive.Enable()
+	}
+	if interactive.Enabled() {
+		var err error
+		args.name, err = interactive.GetString(interactive.Input{
+			Question: "Network name",
+			Flag:     "name",
+			Help:     cmd.Flags().Lookup("name").Usage,
+			Default:  args.name,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid network name: %s", err)
+			os.Exit(1)
+		}
+		args.cidr, err = interactive.GetString(interactive.Input{
+			Question: "VPC CIDR",
+			Flag:     "cidr",
+			Help:     cmd.Flags().Lookup("cidr").Usage,
+			Default:  args.cidr,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid CIDR: %s", err)
+			os.Exit(1)
+		}
+		args.multiAZ, err = interactive.GetBool(interactive.Input{
+			Question: "Multiple availability zones",
+			Flag:     "multi-az",
+			Help:     cmd.Flags().Lookup("multi-az").Usage,
+			Default:  args.multiAZ,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid multi-AZ value: %s", err)
+			os.Exit(1)
+		}
+		args.privateOnly, err = interactive.GetBool(interactive.Input{
+			Question: "Private subnets only",
+			Flag:     "private-only",
+			Help:     cmd.Flags().Lookup("private-only").Usage,
+			Default:  args.privateOnly,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid private-only value: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	params, err := buildStackParameters(args.name, args.cidr, args.multiAZ, args.privateOnly)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Creating network '%s' in region '%s', this can take several minutes",
+		args.name, r.AWSClient.GetRegion())
+	if r.Reporter.IsTerminal() {
+		r.Spinner.Start()
+	}
+	outputs, err := r.AWSClient.CreateNetworkStack(args.name, params, map[string]string{
+		tags.NetworkName:   args.name,
+		tags.RedHatManaged: tags.True,
+	})
+	r.Spinner.Stop()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create network '%s': %v", args.name, err)
+		os.Exit(1)
+	}
+
+	privateSubnets, publicSubnets := subnetIDs(outputs)
+	r.Reporter.Infof("Network '%s' created with VPC '%s'", args.name, outputs["VpcId"])
+	r.Reporter.Infof("Private subnets: %s", strings.Join(privateSubnets, ", "))
+	if len(publicSubnets) > 0 {
+		r.Reporter.Infof("Public subnets: %s", strings.Join(publicSubnets, ", "))
+	}
+	subnets := append(privateSubnets, publicSubnets...)
+	command := fmt.Sprintf("rosa create cluster --cluster-name <cluster_name> --subnet-ids %s",
+		strings.Join(subnets, ","))
+	if args.multiAZ {
+		command += " --multi-az"
+	}
+	if args.privateOnly {
+		command += " --private-link"
+	}
+	r.Reporter.Infof("To create a cluster using this network, run:\n\n  %s\n", command)
+}
+
+// buildStackParameters validates the options of the network and converts them to the parameters
+// of the CloudFormation template
+func buildStackParameters(name string, cidr string, multiAZ bool, privateOnly bool) (map[string]string, error) {
+	if !nameRE.MatchString(name) {
+		return nil, fmt.Errorf("Expected a valid network name matching %s", nameRE.String())
+	}
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("Expected a valid IPv4 CIDR, got '%s'", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("CIDR '%s' has host bits set, did you mean '%s'?", cidr, network.String())
+	}
+	prefix, _ := network.Mask.Size()
+	if prefix < minCidrPrefix || prefix > maxCidrPrefix {
+		return nil, fmt.Errorf("Expected a CIDR with a prefix between /%d and /%d, got '%s'",
+			minCidrPrefix, maxCidrPrefix, cidr)
+	}
+
+	availabilityZones := "1"
+	if multiAZ {
+		availabilityZones = "3"
+	}
+	return map[string]string{
+		"Name":                  name,
+		"VpcCidr":               network.String(),
+		"SubnetCidrBits":        strconv.Itoa(32 - (prefix + 3)),
+		"AvailabilityZoneCount": availabilityZones,
+		"PrivateOnly":           strconv.FormatBool(privateOnly),
+	}, nil
+}
+
+// subnetIDs extracts the private and public subnet IDs, in availability zone order, from the
+// outputs of the stack
+func subnetIDs(outputs map[string]string) ([]string, []string) {
+	privateSubnets := []string{}
+	publicSubnets := []string{}
+	for i := 1; i <= 3; i++ {
+		if id, ok := outputs[fmt.Sprintf("PrivateSubnet%d", i)]; ok {
+			privateSubnets = append(privateSubnets, id)
+		}
+		if id, ok := outputs[fmt.Sprintf("PublicSubnet%d", i)]; ok {
+			publicSubnets = append(publicSubnets, id)
+		}
+	}
+	return privateSubnets, publicSubnets
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create network", func() {
+	Context("buildStackParameters", func() {
+		It("Builds the parameters of a single AZ network", func() {
+			params, err := buildStackParameters("mynetwork", "10.0.0.0/16", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(map[string]string{
+				"Name":                  "mynetwork",
+				"VpcCidr":               "10.0.0.0/16",
+				"SubnetCidrBits":        "13",
+				"AvailabilityZoneCount": "1",
+				"PrivateOnly":           "false",
+			}))
+		})
+
+		It("Builds the parameters of a private multi AZ network", func() {
+			params, err := buildStackParameters("mynetwork", "192.168.0.0/20", true, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params["SubnetCidrBits"]).To(Equal("9"))
+			Expect(params["AvailabilityZoneCount"]).To(Equal("3"))
+			Expect(params["PrivateOnly"]).To(Equal("true"))
+		})
+
+		DescribeTable("Fails with invalid options",
+			func(name string, cidr string, message string) {
+				_, err := buildStackParameters(name, cidr, false, false)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid name", "1network", "10.0.0.0/16", "Expected a valid network name"),
+			Entry("invalid CIDR", "mynetwork", "10.0.0.0", "Expected a valid IPv4 CIDR"),
+			Entry("IPv6 CIDR", "mynetwork", "fd00::/48", "Expected a valid IPv4 CIDR"),
+			Entry("host bits set", "mynetwork", "10.0.1.0/16", "did you mean '10.0.0.0/16'"),
+			Entry("too large", "mynetwork", "10.0.0.0/8", "prefix between /16 and /24"),
+			Entry("too small", "mynetwork", "10.0.0.0/26", "prefix between /16 and /24"),
+		)
+	})
+
+	Context("subnetIDs", func() {
+		It("Returns the subnets in availability zone order", func() {
+			private, public := subnetIDs(map[string]string{
+				"VpcId":          "vpc-1",
+				"PrivateSubnet2": "subnet-p2",
+				"PrivateSubnet1": "subnet-p1",
+				"PublicSubnet1":  "subnet-u1",
+			})
+			Expect(private).To(Equal([]string{"subnet-p1", "subnet-p2"}))
+			Expect(public).To(Equal([]string{"subnet-u1"}))
+		})
+	})
+})
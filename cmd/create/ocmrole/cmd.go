@@ -145,6 +145,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
+			Flag:     "prefix",
 			Help:     cmd.Flags().Lookup("prefix").Usage,
 			Default:  prefix,
 			Required: true,
@@ -172,6 +173,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !isAdmin {
 		isAdmin, err = interactive.GetBool(interactive.Input{
 			Question: "Enable admin capabilities for the OCM role",
+			Flag:     "admin",
 			Help:     cmd.Flags().Lookup("admin").Usage,
 			Default:  isAdmin,
 			Required: false,
@@ -186,6 +188,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
+			Flag:     "permissions-boundary",
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
@@ -210,6 +213,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Role Path",
+			Flag:     "path",
 			Help:     cmd.Flags().Lookup("path").Usage,
 			Default:  path,
 			Validators: []interactive.Validator{
@@ -231,6 +235,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -182,6 +182,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: question,
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -227,6 +228,7 @@ func run(cmd *cobra.Command, _ []string) {
 			if interactive.Enabled() {
 				prefix, err := interactive.GetString(interactive.Input{
 					Question:   "Prefix for OIDC",
+					Flag:       userPrefixFlag,
 					Help:       cmd.Flags().Lookup(userPrefixFlag).Usage,
 					Default:    args.userPrefix,
 					Validators: []interactive.Validator{interactive.MaxLength(maxLengthUserPrefix)},
@@ -127,6 +127,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if !cmd.Flags().Changed("mode") && interactive.Enabled() && !isProgmaticallyCalled {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "OIDC provider creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
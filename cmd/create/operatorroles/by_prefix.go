@@ -27,6 +27,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 	operatorRolesPrefix := args.prefix
 	operatorRolesPrefix, err := interactive.GetString(interactive.Input{
 		Question: "Operator roles prefix",
+		Flag:     PrefixFlag,
 		Help:     cmd.Flags().Lookup(PrefixFlag).Usage,
 		Required: true,
 		Default:  operatorRolesPrefix,
@@ -56,6 +57,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 	} else {
 		isHostedCP, err = interactive.GetBool(interactive.Input{
 			Question: "Create hosted control plane operator roles",
+			Flag:     "hosted-cp",
 			Help:     cmd.Flags().Lookup("hosted-cp").Usage,
 			Default:  isHostedCP,
 			Required: false,
@@ -192,6 +192,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && !isProgmaticallyCalled {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -211,6 +212,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && !isProgmaticallyCalled {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
+			Flag:     "permissions-boundary",
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
@@ -0,0 +1,268 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedvpcresources
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var baseDomainRE = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+var args struct {
+	clusterName            string
+	baseDomain             string
+	vpcID                  string
+	installerRoleARN       string
+	ingressOperatorRoleARN string
+	roleName               string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "shared-vpc-resources",
+	Aliases: []string{"sharedvpcresources"},
+	Short:   "Create the resources that allow a cluster to be installed in a shared VPC",
+	Long: "Create, in the account that owns a shared VPC, the private Route 53 hosted zone of a cluster " +
+		"and the cross-account role that the installer and ingress operator roles of the cluster account " +
+		"assume to manage its DNS records. It has to run with the credentials of the VPC owner account. " +
+		"The ingress operator role can be added by running the command again once it exists.",
+	Example: `  # Create the resources of cluster "mycluster" in the account that owns the VPC
+  rosa create shared-vpc-resources --profile vpc-owner --cluster-name mycluster \
+    --base-domain 1vo8.p1.openshiftapps.com --vpc-id vpc-0123456789abcdef0 \
+    --installer-role-arn arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role
+
+  # Trust the ingress operator role once the operator roles of the cluster are created
+  rosa create shared-vpc-resources --profile vpc-owner --cluster-name mycluster \
+    --base-domain 1vo8.p1.openshiftapps.com --vpc-id vpc-0123456789abcdef0 \
+    --installer-role-arn arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role \
+    --ingress-operator-role-arn \
+    arn:aws:iam::123456789012:role/mycluster-a1b2-openshift-ingress-operator-cloud-credentials`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterName,
+		"cluster-name",
+		"c",
+		"",
+		"Name of the cluster that will be installed in the shared VPC.",
+	)
+	flags.StringVar(
+		&args.baseDomain,
+		"base-domain",
+		"",
+		"Base DNS domain previously reserved with 'rosa create dns-domain', e.g., '1vo8.p1.openshiftapps.com'. "+
+			"The private hosted zone is named after the cluster name and the base domain.",
+	)
+	flags.StringVar(
+		&args.vpcID,
+		"vpc-id",
+		"",
+		"ID of the shared VPC, the private hosted zone is associated with it.",
+	)
+	flags.StringVar(
+		&args.installerRoleARN,
+		"installer-role-arn",
+		"",
+		"ARN of the installer account role of the cluster account.",
+	)
+	flags.StringVar(
+		&args.ingressOperatorRoleARN,
+		"ingress-operator-role-arn",
+		"",
+		"ARN of the ingress operator role of the cluster account. "+
+			"It can only be trusted once it exists, so it is optional the first time.",
+	)
+	flags.StringVar(
+		&args.roleName,
+		"role-name",
+		"",
+		"Name of the cross-account role. Defaults to '<cluster-name>-shared-vpc-role'.",
+	)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if (args.clusterName == "" || args.baseDomain == "" || args.vpcID == "" || args.installerRoleARN == "") &&
+		!interactive.Enabled() {
+		interactive.Enable()
+	}
+	if interactive.Enabled() {
+		var err error
+		args.clusterName, err = interactive.GetString(interactive.Input{
+			Question: "Cluster name",
+			Flag:     "cluster-name",
+			Help:     cmd.Flags().Lookup("cluster-name").Usage,
+			Default:  args.clusterName,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid cluster name: %s", err)
+			os.Exit(1)
+		}
+		args.baseDomain, err = interactive.GetString(interactive.Input{
+			Question: "Base domain",
+			Flag:     "base-domain",
+			Help:     cmd.Flags().Lookup("base-domain").Usage,
+			Default:  args.baseDomain,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid base domain: %s", err)
+			os.Exit(1)
+		}
+		args.vpcID, err = interactive.GetString(interactive.Input{
+			Question: "Shared VPC ID",
+			Flag:     "vpc-id",
+			Help:     cmd.Flags().Lookup("vpc-id").Usage,
+			Default:  args.vpcID,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid VPC ID: %s", err)
+			os.Exit(1)
+		}
+		args.installerRoleARN, err = interactive.GetString(interactive.Input{
+			Question: "Installer role ARN",
+			Flag:     "installer-role-arn",
+			Help:     cmd.Flags().Lookup("installer-role-arn").Usage,
+			Default:  args.installerRoleARN,
+			Required: true,
+			Validators: []interactive.Validator{
+				aws.ARNValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid installer role ARN: %s", err)
+			os.Exit(1)
+		}
+		args.ingressOperatorRoleARN, err = interactive.GetString(interactive.Input{
+			Question: "Ingress operator role ARN",
+			Flag:     "ingress-operator-role-arn",
+			Help:     cmd.Flags().Lookup("ingress-operator-role-arn").Usage,
+			Default:  args.ingressOperatorRoleARN,
+			Validators: []interactive.Validator{
+				aws.ARNValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid ingress operator role ARN: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	roleName := args.roleName
+	if roleName == "" {
+		roleName = awsCommonUtils.TruncateRoleName(fmt.Sprintf("%s-shared-vpc-role", args.clusterName))
+	}
+	trustedRoleARNs := []string{args.installerRoleARN}
+	if args.ingressOperatorRoleARN != "" {
+		trustedRoleARNs = append(trustedRoleARNs, args.ingressOperatorRoleARN)
+	}
+	params, err := buildStackParameters(args.clusterName, args.baseDomain, args.vpcID, roleName,
+		trustedRoleARNs, r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Creating the shared VPC resources of cluster '%s' in account '%s'",
+		args.clusterName, r.Creator.AccountID)
+	if r.Reporter.IsTerminal() {
+		r.Spinner.Start()
+	}
+	outputs, err := r.AWSClient.EnsureSharedVPCStack(fmt.Sprintf("rosa-shared-vpc-%s", args.clusterName), params,
+		map[string]string{
+			tags.ClusterName:   args.clusterName,
+			tags.RedHatManaged: tags.True,
+		})
+	r.Spinner.Stop()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create the shared VPC resources of cluster '%s': %v", args.clusterName, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Private hosted zone '%s' is associated with VPC '%s'", outputs["HostedZoneId"], args.vpcID)
+	r.Reporter.Infof("Role '%s' trusts %s", outputs["RoleArn"], strings.Join(trustedRoleARNs, ", "))
+	if args.ingressOperatorRoleARN == "" {
+		r.Reporter.Warnf("The role doesn't trust the ingress operator role of the cluster yet. Create the "+
+			"operator roles with '--shared-vpc-role-arn %s' and run this command again with "+
+			"'--ingress-operator-role-arn'", outputs["RoleArn"])
+	}
+	r.Reporter.Infof("To create the cluster, run with the credentials of the cluster account:\n\n"+
+		"  rosa create cluster --cluster-name %s --subnet-ids <shared_subnet_ids> --base-domain %s "+
+		"--private-hosted-zone-id %s --shared-vpc-role-arn %s\n",
+		args.clusterName, args.baseDomain, outputs["HostedZoneId"], outputs["RoleArn"])
+}
+
+// buildStackParameters validates the options and converts them to the parameters of the
+// CloudFormation template. The trusted roles are expected to belong to another account.
+func buildStackParameters(clusterName string, baseDomain string, vpcID string, roleName string,
+	trustedRoleARNs []string, ownerAccountID string) (map[string]string, error) {
+	if !ocm.IsValidClusterName(clusterName) {
+		return nil, fmt.Errorf("Cluster name must consist of no more than 54 lowercase alphanumeric " +
+			"characters or '-', start with a letter, and end with an alphanumeric character.")
+	}
+	baseDomain = strings.TrimSuffix(baseDomain, ".")
+	if !baseDomainRE.MatchString(baseDomain) {
+		return nil, fmt.Errorf("Expected a valid base domain, got '%s'", baseDomain)
+	}
+	if !strings.HasPrefix(vpcID, "vpc-") {
+		return nil, fmt.Errorf("Expected a valid VPC ID, got '%s'", vpcID)
+	}
+	if !aws.RoleNameRE.MatchString(roleName) || len(roleName) > 64 {
+		return nil, fmt.Errorf("Expected a valid role name matching %s with no more than 64 characters",
+			aws.RoleNameRE.String())
+	}
+	for _, roleARN := range trustedRoleARNs {
+		parsed, err := arn.Parse(roleARN)
+		if err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+			return nil, fmt.Errorf("Expected a valid role ARN, got '%s'", roleARN)
+		}
+		if parsed.AccountID == ownerAccountID {
+			return nil, fmt.Errorf("Role '%s' belongs to the account that owns the VPC, the roles of the "+
+				"cluster account are expected", roleARN)
+		}
+	}
+	return map[string]string{
+		"ClusterName":     clusterName,
+		"BaseDomain":      baseDomain,
+		"VpcId":           vpcID,
+		"RoleName":        roleName,
+		"TrustedRoleArns": strings.Join(trustedRoleARNs, ","),
+	}, nil
+}
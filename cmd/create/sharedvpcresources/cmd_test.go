@@ -0,0 +1,46 @@
+package sharedvpcresources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create shared VPC resources", func() {
+	const (
+		ownerAccountID = "111111111111"
+		installerARN   = "arn:aws:iam::222222222222:role/ManagedOpenShift-Installer-Role"
+		ingressARN     = "arn:aws:iam::222222222222:role/mycluster-openshift-ingress-operator-cloud-credentials"
+	)
+
+	Context("buildStackParameters", func() {
+		It("Builds the parameters of the template", func() {
+			params, err := buildStackParameters("mycluster", "1vo8.p1.openshiftapps.com.", "vpc-0123456789abcdef0",
+				"mycluster-shared-vpc-role", []string{installerARN, ingressARN}, ownerAccountID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(map[string]string{
+				"ClusterName":     "mycluster",
+				"BaseDomain":      "1vo8.p1.openshiftapps.com",
+				"VpcId":           "vpc-0123456789abcdef0",
+				"RoleName":        "mycluster-shared-vpc-role",
+				"TrustedRoleArns": installerARN + "," + ingressARN,
+			}))
+		})
+
+		DescribeTable("Fails with invalid options",
+			func(baseDomain string, vpcID string, roleARN string, message string) {
+				_, err := buildStackParameters("mycluster", baseDomain, vpcID, "mycluster-shared-vpc-role",
+					[]string{roleARN}, ownerAccountID)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid base domain", "openshiftapps", "vpc-0123456789abcdef0", installerARN,
+				"Expected a valid base domain"),
+			Entry("invalid VPC ID", "1vo8.p1.openshiftapps.com", "subnet-0123456789abcdef0", installerARN,
+				"Expected a valid VPC ID"),
+			Entry("not a role ARN", "1vo8.p1.openshiftapps.com", "vpc-0123456789abcdef0",
+				"arn:aws:iam::222222222222:user/admin", "Expected a valid role ARN"),
+			Entry("role of the owner account", "1vo8.p1.openshiftapps.com", "vpc-0123456789abcdef0",
+				"arn:aws:iam::111111111111:role/ManagedOpenShift-Installer-Role", "belongs to the account that owns"),
+		)
+	})
+})
@@ -85,6 +85,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		name, err = interactive.GetString(interactive.Input{
 			Question: "Name of the tuning config",
+			Flag:     "name",
 			Help:     cmd.Flags().Lookup("name").Usage,
 			Default:  name,
 			Required: true,
@@ -103,6 +104,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		specPath, err = interactive.GetString(interactive.Input{
 			Question: "Path of the file containing the spec of the tuning config",
+			Flag:     "spec-path",
 			Help:     cmd.Flags().Lookup("spec-path").Usage,
 			Default:  specPath,
 			Required: true,
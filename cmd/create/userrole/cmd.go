@@ -112,6 +112,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
+			Flag:     "prefix",
 			Help:     cmd.Flags().Lookup("prefix").Usage,
 			Default:  prefix,
 			Required: true,
@@ -137,6 +138,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
+			Flag:     "permissions-boundary",
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
@@ -161,6 +163,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Role Path",
+			Flag:     "path",
 			Help:     cmd.Flags().Lookup("path").Usage,
 			Default:  path,
 			Validators: []interactive.Validator{
@@ -182,6 +185,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
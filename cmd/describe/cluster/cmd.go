@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ocmConsts "github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -338,6 +340,13 @@ func run(cmd *cobra.Command, argv []string) {
 		isPrivate,
 		cluster.CreationTimestamp().Format("Jan _2 2006 15:04:05 MST"))
 
+	if expiration, ok := cluster.GetExpirationTimestamp(); ok && !expiration.IsZero() {
+		str = fmt.Sprintf("%s"+
+			"Expires:                    %s (%s)\n", str,
+			expiration.Format("Jan _2 2006 15:04:05 MST"),
+			helper.FormatTimeUntil(expiration, time.Now()))
+	}
+
 	str = fmt.Sprintf("%s"+
 		"User Workload Monitoring:   %s\n",
 		str,
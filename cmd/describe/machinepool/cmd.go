@@ -23,6 +23,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -35,8 +36,9 @@ var Cmd = &cobra.Command{
 	Long:    "Show details of a machine pool on a cluster.",
 	Example: `  # Show details of a machine pool named "mymachinepool"" on a cluster named "mycluster"
   rosa describe machinepool --cluster=mycluster --machinepool=mymachinepool`,
-	Run:  run,
-	Args: cobra.MaximumNArgs(1),
+	Run:               run,
+	ValidArgsFunction: completion.MachinePoolArgs,
+	Args:              cobra.MaximumNArgs(1),
 }
 
 var args struct {
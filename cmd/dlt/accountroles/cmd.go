@@ -27,6 +27,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -77,6 +78,7 @@ func init() {
 
 	aws.AddModeFlag(Cmd)
 	confirm.AddFlag(flags)
+	Cmd.RegisterFlagCompletionFunc("prefix", completion.AccountRolePrefixes)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -118,6 +120,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && prefix == "" {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
+			Flag:     "prefix",
 			Help:     cmd.Flags().Lookup("prefix").Usage,
 			Default:  "ManagedOpenShift",
 			Required: true,
@@ -143,6 +146,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Account role deletion mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -167,6 +171,7 @@ func run(cmd *cobra.Command, _ []string) {
 	} else if interactive.Enabled() && !cmd.Flags().Changed("hosted-cp") && !cmd.Flags().Changed("classic") {
 		deleteHostedCP, err = interactive.GetBool(interactive.Input{
 			Question: "Delete hosted CP account roles",
+			Flag:     "hosted-cp",
 			Help:     cmd.Flags().Lookup("hosted-cp").Usage,
 			Default:  true,
 			Required: false,
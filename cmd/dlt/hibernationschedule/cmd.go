@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "hibernation-schedule",
+	Aliases: []string{"hibernationschedule", "hibernation-schedules"},
+	Short:   "Delete a hibernation schedule",
+	Long:    "Delete the locally stored hibernation schedule of a cluster.",
+	Example: `  # Delete the hibernation schedule of the cluster named "dev"
+  rosa delete hibernation-schedule --cluster=dev`,
+	Run:    run,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	path, err := hibernation.Location()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	spec, err := hibernation.Load(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if spec.Find(clusterKey) == nil {
+		r.Reporter.Errorf("There is no hibernation schedule for cluster '%s'", clusterKey)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("delete the hibernation schedule of cluster '%s'", clusterKey) {
+		os.Exit(0)
+	}
+
+	err = hibernation.Update(path, func(spec *hibernation.Spec) error {
+		spec.Remove(clusterKey)
+		return nil
+	})
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Successfully deleted the hibernation schedule of cluster '%s'", clusterKey)
+}
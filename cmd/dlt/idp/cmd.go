@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	cadmin "github.com/openshift/rosa/cmd/create/admin"
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -36,7 +37,8 @@ var Cmd = &cobra.Command{
 	Long:    "Delete a specific identity provider for a cluster.",
 	Example: `  # Delete an identity provider named github-1
   rosa delete idp github-1 --cluster=mycluster`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.IdentityProviders,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
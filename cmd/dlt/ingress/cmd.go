@@ -24,6 +24,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -43,7 +44,8 @@ var Cmd = &cobra.Command{
 
   # Delete secondary ingress using the sub-domain name
   rosa delete ingress --cluster=mycluster apps2`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.Ingresses,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -33,7 +34,8 @@ var Cmd = &cobra.Command{
 	Long:    "Delete the additional machine pool from a cluster.",
 	Example: `  # Delete machine pool with ID mp-1 from a cluster named 'mycluster'
   rosa delete machinepool --cluster=mycluster mp-1`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.MachinePoolArgs,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	name string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Delete a network created with 'rosa create network'",
+	Long: "Delete the CloudFormation stack of a network created with 'rosa create network', " +
+		"including its VPC, subnets and gateways. The network must not be used by any cluster.",
+	Example: `  # Delete the network named "mynetwork"
+  rosa delete network --name mynetwork`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.name,
+		"name",
+		"n",
+		"",
+		"Name of the network to delete.",
+	)
+	Cmd.MarkFlagRequired("name")
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if !confirm.Confirm("delete network '%s' and all its resources", args.name) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Infof("Deleting network '%s', this can take several minutes", args.name)
+	if r.Reporter.IsTerminal() {
+		r.Spinner.Start()
+	}
+	err := r.AWSClient.DeleteNetworkStack(args.name)
+	r.Spinner.Stop()
+	if err != nil {
+		r.Reporter.Errorf("Failed to delete network '%s': %v", args.name, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Network '%s' deleted", args.name)
+}
@@ -100,6 +100,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		roleARN, err = interactive.GetString(interactive.Input{
 			Question: "OCM Role ARN",
+			Flag:     "role-arn",
 			Help:     cmd.Flags().Lookup("role-arn").Usage,
 			Default:  roleARN,
 			Required: true,
@@ -145,6 +146,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && !cmd.Flags().Changed("mode") {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "OCM role deletion mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -93,6 +93,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		roleARN, err = interactive.GetString(interactive.Input{
 			Question: "User Role ARN",
+			Flag:     "role-arn",
 			Help:     cmd.Flags().Lookup("role-arn").Usage,
 			Default:  roleARN,
 			Required: true,
@@ -138,6 +139,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && !cmd.Flags().Changed("mode") {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "User role deletion mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
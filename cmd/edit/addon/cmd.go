@@ -28,17 +28,27 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+const paramsFileFlag = "params-file"
+
+var args struct {
+	paramsFile string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "addon ID",
 	Aliases: []string{"addons", "add-on", "add-ons"},
 	Short:   "Edit add-on installation parameters on cluster",
 	Long:    "Edit the parameters on installed Red Hat managed add-ons on a cluster",
 	Example: `  # Edit the parameters of the Red Hat OpenShift logging operator add-on installation
-  rosa edit addon --cluster=mycluster cluster-logging-operator`,
+  rosa edit addon --cluster=mycluster cluster-logging-operator
+
+  # Review and apply parameter changes from a file
+  rosa edit addon --cluster=mycluster cluster-logging-operator --params-file=params.yaml`,
 	Run:                run,
 	DisableFlagParsing: true,
 	Args: func(cmd *cobra.Command, argv []string) error {
@@ -59,6 +69,18 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.paramsFile,
+		paramsFileFlag,
+		"",
+		"Path to a YAML or JSON file mapping add-on parameter IDs to values. "+
+			"Parameters passed as flags take precedence over the file. "+
+			"The parameter changes are shown for confirmation before they are applied.",
+	)
+
+	confirm.AddFlag(flags)
 	ocm.AddClusterFlag(Cmd)
 }
 
@@ -96,6 +118,19 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	if args.paramsFile != "" {
+		params, err := ocm.ParseAddOnParamsFile(args.paramsFile, addonParameters)
+		if err != nil {
+			r.Reporter.Errorf("Failed to load add-on '%s' parameters: %v", addOnID, err)
+			os.Exit(1)
+		}
+		err = arguments.SetUnknownFlags(cmd, params)
+		if err != nil {
+			r.Reporter.Errorf("Failed to load add-on '%s' parameters: %v", addOnID, err)
+			os.Exit(1)
+		}
+	}
+
 	// Determine if all required parameters have already been set as flags and ensure
 	// that interactive mode is enabled if they have not. If there are no parameters
 	// set as flags, then we also ensure that interactive mode is enabled so that the
@@ -152,6 +187,7 @@ func run(cmd *cobra.Command, argv []string) {
 		flag := cmd.Flags().Lookup(param.ID())
 		if flag != nil {
 			val = flag.Value.String()
+			dflt = val
 		}
 		if interactive.Enabled() {
 			val, err = interactive.GetAddonArgument(*param, dflt)
@@ -178,6 +214,18 @@ func run(cmd *cobra.Command, argv []string) {
 		return true
 	})
 
+	changes := ocm.DiffAddOnParams(addOnInstallation.Parameters(), addonArguments)
+	if len(changes) == 0 {
+		r.Reporter.Infof("No changes to the parameters of add-on '%s' on cluster '%s'", addOnID, clusterKey)
+		os.Exit(0)
+	}
+	r.Reporter.Infof("The following parameters of add-on '%s' will be changed:\n%s",
+		addOnID, formatParamChanges(changes))
+	if args.paramsFile != "" && !confirm.Confirm("apply these changes to add-on '%s' on cluster '%s'",
+		addOnID, clusterKey) {
+		os.Exit(0)
+	}
+
 	r.Reporter.Debugf("Updating add-on parameters for '%s' on cluster '%s'", addOnID, clusterKey)
 	err = r.OCMClient.UpdateAddOnInstallation(cluster.ID(), addOnID, addonArguments)
 	if err != nil {
@@ -186,3 +234,19 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	r.Reporter.Infof("Add-on '%s' is now updating. To check the status run 'rosa list addons -c %s'", addOnID, clusterKey)
 }
+
+func formatParamChanges(changes []ocm.AddOnParamChange) string {
+	var lines []string
+	for _, change := range changes {
+		if change.From == "" {
+			lines = append(lines, fmt.Sprintf("  + %s: '%s'", change.Key, change.To))
+			continue
+		}
+		if change.To == "" {
+			lines = append(lines, fmt.Sprintf("  - %s: '%s'", change.Key, change.From))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  ~ %s: '%s' -> '%s'", change.Key, change.From, change.To))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -40,6 +40,8 @@ var args struct {
 	// Basic options
 	expirationTime     string
 	expirationDuration time.Duration
+	extendExpiration   time.Duration
+	expiringWithin     time.Duration
 
 	// Networking options
 	private                   bool
@@ -61,7 +63,10 @@ var Cmd = &cobra.Command{
   rosa edit cluster -c mycluster --private
 
   # Edit all options interactively
-  rosa edit cluster -c mycluster --interactive`,
+  rosa edit cluster -c mycluster --interactive
+
+  # Keep all clusters that expire within the next 8 hours alive for another day
+  rosa edit cluster --expiring-within 8h --extend-expiration 24h`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -70,7 +75,8 @@ func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
 
-	ocm.AddClusterFlag(Cmd)
+	// The cluster is optional when extending the expiration of the clusters selected with --expiring-within
+	ocm.AddOptionalClusterFlag(Cmd)
 	confirm.AddFlag(Cmd.Flags())
 
 	// Basic options
@@ -86,9 +92,25 @@ func init() {
 		0,
 		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used.",
 	)
+	flags.DurationVar(
+		&args.extendExpiration,
+		"extend-expiration",
+		0,
+		"Postpone the current expiration of the cluster by a relative duration like 2h, 8h, 24h. "+
+			"Can be applied to all clusters selected with '--expiring-within' instead of a single cluster.",
+	)
+	flags.DurationVar(
+		&args.expiringWithin,
+		"expiring-within",
+		0,
+		"Select all clusters that expire within the given duration to extend their expiration. "+
+			"Only valid with '--extend-expiration'.",
+	)
 	// Cluster expiration is not supported in production
 	flags.MarkHidden("expiration-time")
 	flags.MarkHidden("expiration")
+	flags.MarkHidden("extend-expiration")
+	flags.MarkHidden("expiring-within")
 
 	// Networking options
 	flags.BoolVar(
@@ -145,6 +167,19 @@ func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
+	if cmd.Flags().Changed("extend-expiration") || cmd.Flags().Changed("expiring-within") {
+		err := runExtendExpiration(r, cmd)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !cmd.Flags().Changed("cluster") {
+		r.Reporter.Errorf("required flag(s) \"cluster\" not set")
+		os.Exit(1)
+	}
 	clusterKey := r.GetClusterKey()
 
 	// Enable interactive mode if no flags have been set
@@ -242,6 +277,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		privateValue, err = interactive.GetBool(interactive.Input{
 			Question: "Private cluster, check this command's help for possible impacts",
+			Flag:     "private",
 			Help:     fmt.Sprintf("%s %s", cmd.Flags().Lookup("private").Usage, privateWarning),
 			Default:  privateValue,
 		})
@@ -270,6 +306,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() {
 		disableWorkloadMonitoringValue, err = interactive.GetBool(interactive.Input{
 			Question: "Disable Workload monitoring",
+			Flag:     "disable-workload-monitoring",
 			Help:     cmd.Flags().Lookup("disable-workload-monitoring").Usage,
 			Default:  disableWorkloadMonitoringValue,
 		})
@@ -325,6 +362,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		httpProxyValue, err = interactive.GetString(interactive.Input{
 			Question: "HTTP proxy",
+			Flag:     "http-proxy",
 			Help:     cmd.Flags().Lookup("http-proxy").Usage,
 			Default:  def,
 		})
@@ -367,6 +405,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		httpsProxyValue, err = interactive.GetString(interactive.Input{
 			Question: "HTTPS proxy",
+			Flag:     "https-proxy",
 			Help:     cmd.Flags().Lookup("https-proxy").Usage,
 			Default:  def,
 		})
@@ -397,6 +436,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if enableProxy && interactive.Enabled() {
 		noProxyInput, err := interactive.GetString(interactive.Input{
 			Question: "No proxy",
+			Flag:     "no-proxy",
 			Help:     cmd.Flags().Lookup("no-proxy").Usage,
 			Default:  cluster.Proxy().NoProxy(),
 			Validators: []interactive.Validator{
@@ -465,6 +505,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		additionalTrustBundleFileValue, err = interactive.GetCert(interactive.Input{
 			Question: "Additional trust bundle file path",
+			Flag:     "additional-trust-bundle-file",
 			Help:     cmd.Flags().Lookup("additional-trust-bundle-file").Usage,
 			Default:  def,
 		})
@@ -585,6 +626,90 @@ func warnUserForOAuthHCPVisibility(r *rosa.Runtime, clusterKey string, cluster *
 
 }
 
+// runExtendExpiration postpones the expiration of either the cluster given with --cluster or of all
+// the clusters expiring within the duration given with --expiring-within
+func runExtendExpiration(r *rosa.Runtime, cmd *cobra.Command) error {
+	if args.extendExpiration <= 0 {
+		return errors.New("Option 'extend-expiration' must be a positive duration")
+	}
+	for _, flag := range []string{"expiration-time", "expiration", "private",
+		"disable-workload-monitoring", "http-proxy", "https-proxy", "no-proxy",
+		"additional-trust-bundle-file", "audit-log-arn"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("Option '%s' can't be used together with 'extend-expiration'", flag)
+		}
+	}
+
+	var clusters []*cmv1.Cluster
+	switch {
+	case cmd.Flags().Changed("cluster") && cmd.Flags().Changed("expiring-within"):
+		return errors.New("Only one of 'cluster' or 'expiring-within' may be specified")
+	case cmd.Flags().Changed("cluster"):
+		r.GetClusterKey()
+		clusters = []*cmv1.Cluster{r.FetchCluster()}
+	case cmd.Flags().Changed("expiring-within"):
+		r.Reporter.Debugf("Loading clusters expiring within %s", args.expiringWithin)
+		all, err := r.OCMClient.GetClusters(r.Creator, 1000)
+		if err != nil {
+			return fmt.Errorf("Failed to get clusters: %v", err)
+		}
+		clusters = ocm.FilterClustersExpiringBefore(all, time.Now().Add(args.expiringWithin))
+	default:
+		return errors.New("Either 'cluster' or 'expiring-within' must be specified to extend the expiration")
+	}
+
+	return extendClustersExpiration(r, clusters, args.extendExpiration)
+}
+
+func extendClustersExpiration(r *rosa.Runtime, clusters []*cmv1.Cluster, extension time.Duration) error {
+	now := time.Now()
+	expirations := map[string]time.Time{}
+	var selected []*cmv1.Cluster
+	for _, cluster := range clusters {
+		expiration, ok := cluster.GetExpirationTimestamp()
+		if !ok || expiration.IsZero() {
+			r.Reporter.Warnf("Cluster '%s' has no expiration, skipping it", cluster.Name())
+			continue
+		}
+		// Extending an already expired cluster starts from now, not from the past expiration
+		if expiration.Before(now) {
+			expiration = now
+		}
+		expirations[cluster.ID()] = expiration.Add(extension).Round(time.Second)
+		selected = append(selected, cluster)
+	}
+	if len(selected) == 0 {
+		r.Reporter.Infof("There are no clusters to extend the expiration of")
+		return nil
+	}
+
+	for _, cluster := range selected {
+		r.Reporter.Infof("Cluster '%s' will expire at %s (%s)", cluster.Name(),
+			expirations[cluster.ID()].Format(time.RFC3339), helper.FormatTimeUntil(expirations[cluster.ID()], now))
+	}
+	if !confirm.Confirm("extend the expiration of %d cluster(s) by %s", len(selected), extension) {
+		return nil
+	}
+
+	failures := 0
+	for _, cluster := range selected {
+		r.Reporter.Debugf("Updating expiration of cluster '%s'", cluster.Name())
+		err := r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, ocm.Spec{
+			Expiration: expirations[cluster.ID()],
+		})
+		if err != nil {
+			r.Reporter.Errorf("Failed to update expiration of cluster '%s': %v", cluster.Name(), err)
+			failures++
+			continue
+		}
+		r.Reporter.Infof("Updated expiration of cluster '%s'", cluster.Name())
+	}
+	if failures > 0 {
+		return fmt.Errorf("Failed to extend the expiration of %d cluster(s)", failures)
+	}
+	return nil
+}
+
 func validateExpiration() (expiration time.Time, err error) {
 	// Validate options
 	if len(args.expirationTime) > 0 && args.expirationDuration != 0 {
@@ -687,6 +812,7 @@ func auditLogInteractivePrompt(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 
 		auditLogRoleValue, err := interactive.GetString(interactive.Input{
 			Question: "Audit log forwarding role ARN",
+			Flag:     "audit-log-arn",
 			Help:     cmd.Flags().Lookup("audit-log-arn").Usage,
 			Default:  "",
 			Required: true,
@@ -19,12 +19,16 @@ package cluster
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -114,3 +118,41 @@ func buildTestIngresses(total int, public int) []*cmv1.Ingress {
 	}
 	return ingresses
 }
+
+var _ = Describe("Extend cluster expiration", func() {
+	var testRuntime test.TestingRuntime
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		Expect(Cmd.Flags().Set("yes", "true")).To(Succeed())
+	})
+
+	It("Skips clusters without expiration", func() {
+		cluster := test.MockCluster(nil)
+		stdout, stderr, err := test.RunWithOutputCapture(func(r *rosa.Runtime, _ *cobra.Command) error {
+			return extendClustersExpiration(r, []*cmv1.Cluster{cluster}, 24*time.Hour)
+		}, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stderr).To(ContainSubstring("Cluster 'cluster' has no expiration, skipping it"))
+		Expect(stdout).To(ContainSubstring("There are no clusters to extend the expiration of"))
+	})
+
+	It("Postpones the current expiration", func() {
+		expiration := time.Now().Add(time.Hour).Round(time.Second)
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ExpirationTimestamp(expiration)
+		})
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{cluster})))
+		testRuntime.ApiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/"+test.MockClusterID),
+				VerifyJQ(".expiration_timestamp", expiration.Add(24*time.Hour).UTC().Format(time.RFC3339)),
+				RespondWithJSON(http.StatusOK, "{}"),
+			))
+		_, _, err := test.RunWithOutputCapture(func(r *rosa.Runtime, _ *cobra.Command) error {
+			return extendClustersExpiration(r, []*cmv1.Cluster{cluster}, 24*time.Hour)
+		}, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/externalauthprovider"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var externalAuthProvidersArgs *externalauthprovider.ExternalAuthProvidersArgs
+
+const argsPrefix string = ""
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-provider",
+	Aliases: []string{"externalauthproviders", "externalauthprovider", "external-auth-providers"},
+	Short:   "Edit an external authentication provider of a cluster.",
+	Long: "Edit an external authentication provider of a cluster in place. Attributes that are not " +
+		"specified keep their current value.",
+	Example: `  # Replace the audiences of an external authentication provider named "exauth" on a cluster named "mycluster"
+  rosa edit external-auth-provider exauth --cluster=mycluster --issuer-audiences=abc,def
+
+  # Rotate the CA bundle used to reach the issuer
+  rosa edit external-auth-provider exauth --cluster=mycluster --issuer-ca-file=ca.pem
+
+  # Interactively edit an external authentication provider
+  rosa edit external-auth-provider exauth --cluster=mycluster --interactive`,
+	Run:    run,
+	Hidden: true,
+	Args:   cobra.MaximumNArgs(1),
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	ocm.AddClusterFlag(Cmd)
+	interactive.AddFlag(flags)
+	externalAuthProvidersArgs = externalauthprovider.AddExternalAuthProvidersFlags(Cmd, argsPrefix)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd, argv)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
+	externalAuthId, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	// Allow the use also directly set the external authentication id as positional parameter
+	if len(argv) == 1 && !cmd.Flag("name").Changed {
+		externalAuthId = argv[0]
+	}
+	if externalAuthId == "" {
+		return fmt.Errorf("you need to specify an external authentication provider name with '--name' parameter")
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	externalAuthService := externalauthprovider.NewExternalAuthService(r.OCMClient)
+	err = externalAuthService.IsExternalAuthProviderSupported(cluster, clusterKey)
+	if err != nil {
+		return err
+	}
+
+	r.Reporter.Debugf("Fetching the external authentication provider '%s' for cluster '%s'", externalAuthId, clusterKey)
+	externalAuth, exists, err := r.OCMClient.GetExternalAuth(cluster.ID(), externalAuthId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("external authentication provider '%s' not found", externalAuthId)
+	}
+
+	if !externalauthprovider.IsExternalAuthProviderEditedViaCLI(cmd.Flags(), argsPrefix) && !interactive.Enabled() {
+		interactive.Enable()
+		r.Reporter.Infof("Enabling interactive mode")
+	}
+
+	mergedArgs := externalauthprovider.MergeExternalAuthArgs(cmd.Flags(), argsPrefix, externalAuth,
+		externalAuthProvidersArgs)
+	mergedArgs, err = externalauthprovider.GetExternalAuthOptions(cmd.Flags(), argsPrefix, false, mergedArgs)
+	if err != nil {
+		return fmt.Errorf("failed to update external authentication provider '%s' for cluster '%s': %s",
+			externalAuthId, clusterKey, err)
+	}
+
+	if !confirm.Confirm("update external authentication provider %s on cluster %s", externalAuthId, clusterKey) {
+		return nil
+	}
+
+	r.Reporter.Debugf("Updating external authentication provider '%s' for cluster '%s'", externalAuthId, clusterKey)
+	err = externalAuthService.UpdateExternalAuthProvider(cluster, clusterKey, mergedArgs, r)
+	if err != nil {
+		return err
+	}
+
+	r.Reporter.Infof("Successfully updated external authentication provider '%s' for cluster '%s'",
+		externalAuthId, clusterKey)
+
+	return nil
+}
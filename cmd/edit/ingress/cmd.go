@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift/rosa/pkg/completion"
 	utils "github.com/openshift/rosa/pkg/helper"
 	helper "github.com/openshift/rosa/pkg/ingress"
 	"github.com/openshift/rosa/pkg/interactive"
@@ -58,7 +59,8 @@ var Cmd = &cobra.Command{
 
   # Update the load balancer type of the apps2 ingress 
   rosa edit ingress --lb-type=nlb --cluster=mycluster apps2`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.Ingresses,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
@@ -213,6 +215,7 @@ func run(cmd *cobra.Command, argv []string) {
 	} else if interactive.Enabled() {
 		privArg, err := interactive.GetBool(interactive.Input{
 			Question: "Private ingress",
+			Flag:     privateFlag,
 			Help:     cmd.Flags().Lookup(privateFlag).Usage,
 			Default:  args.private,
 		})
@@ -277,6 +280,7 @@ func run(cmd *cobra.Command, argv []string) {
 		(ingress.Default() && !hasLegacyIngressSupport || !ingress.Default()) {
 		routeSelectorArg, err := interactive.GetString(interactive.Input{
 			Question: "Route Selector for ingress",
+			Flag:     routeSelectorFlag,
 			Help:     cmd.Flags().Lookup(routeSelectorFlag).Usage,
 			Default:  args.routeSelector,
 			Validators: []interactive.Validator{
@@ -340,6 +344,7 @@ func run(cmd *cobra.Command, argv []string) {
 		} else if isInteractiveEnabledAndNotHcp {
 			excludedNamespacesArg, err := interactive.GetString(interactive.Input{
 				Question: "Excluded namespaces for ingress",
+				Flag:     excludedNamespacesFlag,
 				Help:     cmd.Flags().Lookup(excludedNamespacesFlag).Usage,
 				Default:  args.excludedNamespaces,
 			})
@@ -358,6 +363,7 @@ func run(cmd *cobra.Command, argv []string) {
 		} else if isInteractiveEnabledAndNotHcp {
 			wildcardPolicyArg, err := interactive.GetOption(interactive.Input{
 				Question: "Wildcard Policy",
+				Flag:     wildcardPolicyFlag,
 				Options:  helper.ValidWildcardPolicies,
 				Help:     cmd.Flags().Lookup(wildcardPolicyFlag).Usage,
 				Default:  args.wildcardPolicy,
@@ -379,6 +385,7 @@ func run(cmd *cobra.Command, argv []string) {
 		} else if isInteractiveEnabledAndNotHcp {
 			namespaceOwnershipPolicyArg, err := interactive.GetOption(interactive.Input{
 				Question: "Namespace Ownership Policy",
+				Flag:     namespaceOwnershipPolicyFlag,
 				Options:  helper.ValidNamespaceOwnershipPolicies,
 				Help:     cmd.Flags().Lookup(namespaceOwnershipPolicyFlag).Usage,
 				Default:  args.namespaceOwnershipPolicy,
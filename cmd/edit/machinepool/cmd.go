@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -51,7 +52,8 @@ var Cmd = &cobra.Command{
   rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1
   # Set the node drain grace period to 1 hour on machine pool 'mp1' on cluster 'mycluster'
   rosa edit machinepool --node-drain-grace-period="1 hour" --cluster=mycluster mp1`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.MachinePoolArgs,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
@@ -172,6 +172,7 @@ func getMachinePoolReplicas(cmd *cobra.Command,
 		if interactive.Enabled() {
 			autoscaling, err = interactive.GetBool(interactive.Input{
 				Question: "Enable autoscaling",
+				Flag:     "enable-autoscaling",
 				Help:     cmd.Flags().Lookup("enable-autoscaling").Usage,
 				Default:  autoscaling,
 				Required: false,
@@ -189,6 +190,7 @@ func getMachinePoolReplicas(cmd *cobra.Command,
 			minReplicaUpdated = true
 			minReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Min replicas",
+				Flag:     "min-replicas",
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  existingAutoscaling.MinReplicas(),
 				Required: replicasRequired,
@@ -204,6 +206,7 @@ func getMachinePoolReplicas(cmd *cobra.Command,
 			maxReplicaUpdated = true
 			maxReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Max replicas",
+				Flag:     "max-replicas",
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  existingAutoscaling.MaxReplicas(),
 				Required: replicasRequired,
@@ -219,6 +222,7 @@ func getMachinePoolReplicas(cmd *cobra.Command,
 		}
 		replicas, err = interactive.GetInt(interactive.Input{
 			Question: "Replicas",
+			Flag:     "replicas",
 			Help:     cmd.Flags().Lookup("replicas").Usage,
 			Default:  replicas,
 			Required: true,
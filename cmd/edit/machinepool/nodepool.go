@@ -107,6 +107,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 		if interactive.Enabled() {
 			autorepair, err = interactive.GetBool(interactive.Input{
 				Question: "Autorepair",
+				Flag:     "autorepair",
 				Help:     cmd.Flags().Lookup("autorepair").Usage,
 				Default:  autorepair,
 				Required: false,
@@ -149,6 +150,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 			if len(availableTuningConfigs) > 0 {
 				inputTuningConfig, err = interactive.GetMultipleOptions(interactive.Input{
 					Question: "Tuning configs",
+					Flag:     "tuning-configs",
 					Help:     cmd.Flags().Lookup("tuning-configs").Usage,
 					Options:  availableTuningConfigs,
 					Default:  inputTuningConfig,
@@ -174,6 +176,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 		if interactive.Enabled() {
 			nodeDrainGracePeriod, err = interactive.GetString(interactive.Input{
 				Question: "Node drain grace period",
+				Flag:     "node-drain-grace-period",
 				Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
 				Default:  nodeDrainGracePeriod,
 				Required: false,
@@ -241,6 +244,7 @@ func getNodePoolReplicas(cmd *cobra.Command,
 		if interactive.Enabled() {
 			autoscaling, err = interactive.GetBool(interactive.Input{
 				Question: "Enable autoscaling",
+				Flag:     "enable-autoscaling",
 				Help:     cmd.Flags().Lookup("enable-autoscaling").Usage,
 				Default:  autoscaling,
 				Required: false,
@@ -257,6 +261,7 @@ func getNodePoolReplicas(cmd *cobra.Command,
 		if !isMinReplicasSet && (interactive.Enabled() || !isMaxReplicasSet) {
 			minReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Min replicas",
+				Flag:     "min-replicas",
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  existingAutoscaling.MinReplica(),
 				Required: replicasRequired,
@@ -274,6 +279,7 @@ func getNodePoolReplicas(cmd *cobra.Command,
 		if !isMaxReplicasSet && (interactive.Enabled() || !isMinReplicasSet) {
 			maxReplicas, err = interactive.GetInt(interactive.Input{
 				Question: "Max replicas",
+				Flag:     "max-replicas",
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  existingAutoscaling.MaxReplica(),
 				Required: replicasRequired,
@@ -296,6 +302,7 @@ func getNodePoolReplicas(cmd *cobra.Command,
 		}
 		replicas, err = interactive.GetInt(interactive.Input{
 			Question: "Replicas",
+			Flag:     "replicas",
 			Help:     cmd.Flags().Lookup("replicas").Usage,
 			Default:  replicas,
 			Required: true,
@@ -92,6 +92,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		specPath, err = interactive.GetString(interactive.Input{
 			Question: "Path of the file containing the spec of the tuning config",
+			Flag:     "spec-path",
 			Help:     cmd.Flags().Lookup("spec-path").Usage,
 			Default:  specPath,
 			Required: true,
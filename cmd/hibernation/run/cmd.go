@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	interval time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "run",
+	Short: "Execute due hibernation schedules",
+	Long: "Hibernate or resume the clusters whose hibernation schedule has an action due since the last run. " +
+		"By default the schedules are checked once, which is meant to be invoked from a cron job. With " +
+		"'--interval' the command keeps running and checks the schedules periodically, for example in a " +
+		"container. The schedules file can be set with the " + hibernation.LocationEnvKey + " environment variable.",
+	Example: `  # Execute the due actions once, e.g. from a cron job running every few minutes
+  rosa hibernation run
+
+  # Keep running and check the schedules every minute
+  rosa hibernation run --interval 1m`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.DurationVar(
+		&args.interval,
+		"interval",
+		0,
+		"Keep running and check the schedules at this interval. By default the schedules are checked once.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	path, err := hibernation.Location()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	for {
+		err = runOnce(r, path, time.Now())
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			if args.interval == 0 {
+				os.Exit(1)
+			}
+		}
+		if args.interval == 0 {
+			return
+		}
+		time.Sleep(args.interval)
+	}
+}
+
+// runOnce executes the actions that are due at the given time and records the time of the run in
+// the schedules whose action succeeded, so that failed actions are retried on the next run. The
+// times are merged into the schedules file by cluster ID, so that schedules created or deleted
+// while the actions were executed aren't lost or restored.
+func runOnce(r *rosa.Runtime, path string, now time.Time) error {
+	// Load the schedules on every run, so that changes made while running as a daemon are picked up
+	spec, err := hibernation.Load(path)
+	if err != nil {
+		return err
+	}
+
+	runs := map[string]time.Time{}
+	failures := 0
+	for _, schedule := range spec.Schedules {
+		action, at, err := schedule.Due(now)
+		if err != nil {
+			r.Reporter.Errorf("%s cluster '%s': %v", timestamp(now), schedule.ClusterName, err)
+			failures++
+			continue
+		}
+		if action == hibernation.ActionNone {
+			runs[schedule.ClusterID] = now
+			continue
+		}
+		r.Reporter.Debugf("%s cluster '%s': %s scheduled at %s is due", timestamp(now), schedule.ClusterName,
+			action, timestamp(at))
+		message, err := execute(r, schedule, action)
+		if err != nil {
+			r.Reporter.Errorf("%s cluster '%s': failed to %s: %v", timestamp(now), schedule.ClusterName, action, err)
+			failures++
+			continue
+		}
+		r.Reporter.Infof("%s cluster '%s': %s", timestamp(now), schedule.ClusterName, message)
+		runs[schedule.ClusterID] = now
+	}
+
+	err = hibernation.RecordRuns(path, runs)
+	if err != nil {
+		return err
+	}
+	if failures > 0 {
+		return fmt.Errorf("Failed to execute %d hibernation schedule(s)", failures)
+	}
+	return nil
+}
+
+func execute(r *rosa.Runtime, schedule *hibernation.Schedule, action hibernation.Action) (string, error) {
+	cluster, err := r.OCMClient.GetClusterByID(schedule.ClusterID, nil)
+	if err != nil {
+		return "", err
+	}
+	if cluster.Hypershift().Enabled() {
+		return "", fmt.Errorf("Hosted Control Plane clusters do not support hibernation")
+	}
+
+	switch action {
+	case hibernation.ActionHibernate:
+		switch cluster.State() {
+		case cmv1.ClusterStateHibernating, cmv1.ClusterStatePoweringDown:
+			return "already hibernating", nil
+		case cmv1.ClusterStateReady:
+			err = r.OCMClient.HibernateCluster(cluster.ID())
+			if err != nil {
+				return "", err
+			}
+			return "hibernating", nil
+		}
+	case hibernation.ActionResume:
+		switch cluster.State() {
+		case cmv1.ClusterStateReady, cmv1.ClusterStateResuming:
+			return "already running", nil
+		case cmv1.ClusterStateHibernating:
+			err = r.OCMClient.ResumeCluster(cluster.ID())
+			if err != nil {
+				return "", err
+			}
+			return "resuming", nil
+		}
+	}
+	return "", fmt.Errorf("cluster is in '%s' state", cluster.State())
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
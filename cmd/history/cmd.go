@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/audit"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	cluster string
+	limit   int
+	verbose bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the changes made with rosa",
+	Long: "Show the mutating commands executed with rosa from this machine, as recorded in the " +
+		"local audit journal, including who ran them and whether they succeeded.\n\n" +
+		"Recording is disabled by default. Enable it with 'rosa config set audit true', and optionally " +
+		"send every entry also to a webhook with 'rosa config set audit_webhook URL'. Disable it again " +
+		"with 'rosa config set audit false'.",
+	Example: `  # Show the changes made to cluster "mycluster"
+  rosa history --cluster mycluster
+
+  # Show the last 10 changes made to any cluster, including the full command lines
+  rosa history --limit 10 --verbose`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.cluster,
+		"cluster",
+		"c",
+		"",
+		"Name or ID of the cluster to show the changes of.",
+	)
+	flags.IntVar(
+		&args.limit,
+		"limit",
+		0,
+		"Maximum number of changes to show, starting from the most recent one.",
+	)
+	flags.BoolVarP(
+		&args.verbose,
+		"verbose",
+		"v",
+		false,
+		"Show the complete command line of each change.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	if args.limit < 0 {
+		r.Reporter.Errorf("Expected a positive value for the 'limit' flag")
+		os.Exit(1)
+	}
+
+	path, err := audit.Location()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	entries, err := audit.Read(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	entries = filterEntries(entries, args.cluster, args.limit)
+	if len(entries) == 0 {
+		if cfg, err := config.Load(); err == nil && !audit.Enabled(cfg) {
+			r.Reporter.Infof("Recording of the changes is disabled, enable it with 'rosa config set audit true'")
+		}
+		if args.cluster != "" {
+			r.Reporter.Infof("There are no recorded changes for cluster '%s'", args.cluster)
+		} else {
+			r.Reporter.Infof("There are no recorded changes")
+		}
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TIME\tCOMMAND\tCLUSTER\tIDENTITY\tOCM ACCOUNT\tRESULT\n")
+	for _, entry := range entries {
+		command := entry.Command
+		if args.verbose {
+			command = "rosa " + strings.Join(entry.Args, " ")
+		}
+		result := entry.Result
+		if entry.Error != "" {
+			result = fmt.Sprintf("%s: %s", result, entry.Error)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Timestamp.Local().Format(time.RFC3339),
+			command, entry.Cluster, entry.ARN, entry.OCMAccount, result)
+	}
+	writer.Flush()
+}
+
+// filterEntries returns the entries of the given cluster, or all of them if no cluster is given,
+// keeping only the most recent ones when a limit is given
+func filterEntries(entries []*audit.Record, clusterKey string, limit int) []*audit.Record {
+	var result []*audit.Record
+	for _, entry := range entries {
+		if clusterKey == "" || entry.Matches(clusterKey) {
+			result = append(result, entry)
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result
+}
@@ -42,11 +42,13 @@ import (
 const (
 	billingModelFlag          = "billing-model"
 	billingModelAccountIDFlag = "billing-model-account-id"
+	paramsFileFlag            = "params-file"
 )
 
 var args struct {
 	billingModel          string
 	billingModelAccountID string
+	paramsFile            string
 }
 
 var Cmd = &cobra.Command{
@@ -55,7 +57,10 @@ var Cmd = &cobra.Command{
 	Short:   "Install add-ons on cluster",
 	Long:    "Install Red Hat managed add-ons on a cluster",
 	Example: `  # Add the CodeReady Workspaces add-on installation to the cluster
-  rosa install addon --cluster=mycluster codeready-workspaces`,
+  rosa install addon --cluster=mycluster codeready-workspaces
+
+  # Add the Red Hat OpenShift logging operator add-on installation with parameters from a file
+  rosa install addon --cluster=mycluster cluster-logging-operator --params-file=params.yaml`,
 	Run:                run,
 	DisableFlagParsing: true,
 	Args: func(cmd *cobra.Command, argv []string) error {
@@ -88,6 +93,14 @@ func init() {
 		"Account ID of associated billing model for the addon installation resource",
 	)
 
+	flags.StringVar(
+		&args.paramsFile,
+		paramsFileFlag,
+		"",
+		"Path to a YAML or JSON file mapping add-on parameter IDs to values. "+
+			"Parameters passed as flags take precedence over the file.",
+	)
+
 	confirm.AddFlag(flags)
 	ocm.AddClusterFlag(Cmd)
 }
@@ -173,6 +186,19 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	if args.paramsFile != "" {
+		params, err := ocm.ParseAddOnParamsFile(args.paramsFile, addonParameters)
+		if err != nil {
+			r.Reporter.Errorf("Failed to load add-on '%s' parameters: %v", addOnID, err)
+			os.Exit(1)
+		}
+		err = arguments.SetUnknownFlags(cmd, params)
+		if err != nil {
+			r.Reporter.Errorf("Failed to load add-on '%s' parameters: %v", addOnID, err)
+			os.Exit(1)
+		}
+	}
+
 	var addonArguments []ocm.AddOnParam
 	if addonParameters.Len() > 0 {
 		// Determine if all required parameters have already been set as flags and ensure
@@ -209,7 +235,11 @@ func run(cmd *cobra.Command, argv []string) {
 				val = flag.Value.String()
 			}
 			if interactive.Enabled() {
-				val, err = interactive.GetAddonArgument(*param, param.DefaultValue())
+				dflt := param.DefaultValue()
+				if val != "" {
+					dflt = val
+				}
+				val, err = interactive.GetAddonArgument(*param, dflt)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
 					os.Exit(1)
@@ -242,6 +272,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if interactive.Enabled() {
 			billingModel, err = interactive.GetOption(interactive.Input{
 				Question: "Billing Model",
+				Flag:     billingModelFlag,
 				Help:     cmd.Flags().Lookup(billingModelFlag).Usage,
 				Default:  string(amv1.BillingModelStandard),
 				Options:  ocm.BillingOptions,
@@ -257,6 +288,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if billingModel != string(amv1.BillingModelStandard) && !cmd.Flags().Changed(billingModelAccountIDFlag) {
 		billingModelAccountID, err = interactive.GetString(interactive.Input{
 			Question: "Billing Account ID",
+			Flag:     billingModelAccountIDFlag,
 			Help:     cmd.Flags().Lookup(billingModelAccountIDFlag).Usage,
 			Required: true,
 		})
@@ -97,6 +97,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() {
 		roleArn, err = interactive.GetString(interactive.Input{
 			Question: "OCM Role ARN",
+			Flag:     "role-arn",
 			Help:     cmd.Flags().Lookup("role-arn").Usage,
 			Default:  roleArn,
 			Required: true,
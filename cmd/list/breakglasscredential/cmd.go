@@ -3,7 +3,6 @@ package breakglasscredential
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -70,18 +69,5 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		return nil
 	}
 
-	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-
-	fmt.Fprintf(writer, "ID\tUSERNAME\tSTATUS\n")
-	for _, credential := range breakGlassCredentials {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n",
-			credential.ID(),
-			credential.Username(),
-			credential.Status(),
-		)
-	}
-	writer.Flush()
-
-	return nil
+	return output.PrintTable(breakGlassCredentials)
 }
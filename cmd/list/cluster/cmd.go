@@ -20,11 +20,14 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -35,7 +38,10 @@ var Cmd = &cobra.Command{
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List the clusters that expire within the next two days
+  rosa list clusters --expiring-within 48h`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -45,6 +51,7 @@ const clusterCount = 1000
 var args struct {
 	listAll        bool
 	accountRoleArn string
+	expiringWithin time.Duration
 }
 
 func init() {
@@ -56,6 +63,8 @@ func init() {
 		"accounts under the same Red Hat organization")
 	flags.StringVar(&args.accountRoleArn, "account-role-arn", "", "List all clusters "+
 		"using the account role identified by the ARN")
+	flags.DurationVar(&args.expiringWithin, "expiring-within", 0, "List only the clusters "+
+		"that expire within the given duration, like 8h or 48h. Already expired clusters are included")
 }
 
 func listClustersUsingAccountRole(creator *aws.Creator, runtime *rosa.Runtime) ([]*v1.Cluster, error) {
@@ -93,6 +102,11 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	now := time.Now()
+	if args.expiringWithin != 0 {
+		clusters = ocm.FilterClustersExpiringBefore(clusters, now.Add(args.expiringWithin))
+	}
+
 	if output.HasFlag() {
 		err = output.Print(clusters)
 		if err != nil {
@@ -109,7 +123,7 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
+	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\tEXPIRES\n")
 	for _, cluster := range clusters {
 		typeOutput := "Classic"
 		if cluster.AWS() != nil && cluster.AWS().STS() != nil && cluster.AWS().STS().Enabled() {
@@ -118,13 +132,18 @@ func run(_ *cobra.Command, _ []string) {
 		if cluster.Hypershift().Enabled() {
 			typeOutput = "Hosted CP"
 		}
+		expiresOutput := ""
+		if expiration, ok := cluster.GetExpirationTimestamp(); ok && !expiration.IsZero() {
+			expiresOutput = helper.FormatTimeUntil(expiration, now)
+		}
 		fmt.Fprintf(
 			writer,
-			"%s\t%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\t%s\n",
 			cluster.ID(),
 			cluster.Name(),
 			cluster.State(),
 			typeOutput,
+			expiresOutput,
 		)
 	}
 	writer.Flush()
@@ -19,7 +19,6 @@ package externalauthprovider
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -86,17 +85,5 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		return fmt.Errorf("there are no external authentication providers for this cluster")
 	}
 
-	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-
-	fmt.Fprintf(writer, "NAME\tISSUER URL\n")
-	for _, externalAuthProvider := range externalAuthProviders {
-		fmt.Fprintf(writer, "%s\t%s\n",
-			externalAuthProvider.ID(),
-			externalAuthProvider.Issuer().URL(),
-		)
-	}
-	writer.Flush()
-
-	return nil
+	return output.PrintTable(externalAuthProviders)
 }
@@ -27,6 +27,7 @@ import (
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -86,6 +87,7 @@ func init() {
 	Cmd.MarkFlagRequired("version")
 
 	output.AddFlag(Cmd)
+	Cmd.RegisterFlagCompletionFunc("version", completion.Versions)
 }
 
 const (
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "hibernation-schedules",
+	Aliases: []string{"hibernationschedules", "hibernation-schedule"},
+	Short:   "List hibernation schedules",
+	Long:    "List the locally stored cluster hibernation schedules.",
+	Example: `  # List all hibernation schedules
+  rosa list hibernation-schedules`,
+	Run:    run,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	path, err := hibernation.Location()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	spec, err := hibernation.Load(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if len(spec.Schedules) == 0 {
+		r.Reporter.Infof("There are no hibernation schedules")
+		os.Exit(0)
+	}
+
+	now := time.Now()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CLUSTER\tHIBERNATE\tRESUME\tTIMEZONE\tNEXT ACTION\tLAST RUN\n")
+	for _, schedule := range spec.Schedules {
+		nextAction := ""
+		action, at, err := schedule.Next(now)
+		if err != nil {
+			nextAction = "invalid schedule"
+		} else {
+			nextAction = fmt.Sprintf("%s at %s", action, at.Format(time.RFC3339))
+		}
+		lastRun := ""
+		if !schedule.LastRun.IsZero() {
+			lastRun = schedule.LastRun.Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", schedule.ClusterName, schedule.Hibernate,
+			schedule.Resume, schedule.Timezone, nextAction, lastRun)
+	}
+	writer.Flush()
+}
@@ -17,9 +17,7 @@ limitations under the License.
 package oidcconfig
 
 import (
-	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -68,17 +66,9 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
-	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-
-	fmt.Fprintf(writer, "ID\tMANAGED\tISSUER URL\tSECRET ARN\n")
-	for _, oidcConfig := range oidcConfigs {
-		fmt.Fprintf(writer, "%s\t%v\t%s\t%s\n",
-			oidcConfig.ID(),
-			oidcConfig.Managed(),
-			oidcConfig.IssuerUrl(),
-			oidcConfig.SecretArn(),
-		)
+	err = output.PrintTable(oidcConfigs)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
-	writer.Flush()
 }
@@ -135,15 +135,16 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 	if output.HasFlag() {
-		var resource interface{} = operatorsMap
 		if args.prefix != "" {
-			if _, ok := operatorsMap[args.prefix]; !ok {
+			operatorRoles, ok := operatorsMap[args.prefix]
+			if !ok {
 				r.Reporter.Infof("No operator roles available for prefix '%s'", args.prefix)
 				os.Exit(0)
 			}
-			resource = operatorsMap[args.prefix]
+			err = output.Print(operatorRoles)
+		} else {
+			err = output.Print(operatorsMap)
 		}
-		err = output.Print(resource)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
@@ -176,6 +177,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		args.prefix, err = interactive.GetOption(interactive.Input{
 			Question: "Operator Role Prefix",
+			Flag:     "prefix",
 			Help:     cmd.Flags().Lookup("prefix").Usage,
 			Options:  prefixes,
 			Default:  prefixes[0],
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedresources
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/orphan"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "orphaned-resources",
+	Aliases: []string{"orphanedresources", "orphaned-resource"},
+	Short:   "List orphaned IAM and OIDC resources",
+	Long: "List the operator roles, operator policies, OIDC configs and OIDC providers of the current " +
+		"AWS account that no live cluster references anymore, and the reason why each of them is " +
+		"considered orphaned.\n\n" +
+		"Operator roles and OIDC providers that were not created for a cluster, and OIDC configs created " +
+		"in the last 7 days, are never considered orphaned, as they may have been created ahead of time " +
+		"for Hosted Control Plane clusters.",
+	Example: `  # List all orphaned resources
+  rosa list orphaned-resources`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Looking for orphaned resources")
+		r.Spinner.Start()
+	}
+	resources, err := orphan.Find(r)
+	r.Spinner.Stop()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		outList := []map[string]interface{}{}
+		for _, resource := range resources {
+			outList = append(outList, map[string]interface{}{
+				"type": resource.Kind, "id": resource.ID, "roles": resource.RoleNames, "reason": resource.Reason})
+		}
+		err = output.Print(outList)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(resources) == 0 {
+		r.Reporter.Infof("There are no orphaned resources")
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TYPE\tRESOURCE\tREASON\n")
+	for _, resource := range resources {
+		id := resource.ID
+		if len(resource.RoleNames) > 0 {
+			id = fmt.Sprintf("%s (%s)", id, strings.Join(resource.RoleNames, ", "))
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", resource.Kind, id, resource.Reason)
+	}
+	writer.Flush()
+}
@@ -17,9 +17,7 @@ limitations under the License.
 package service
 
 import (
-	"fmt"
 	"os"
-	"text/tabwriter"
 
 	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
 	"github.com/spf13/cobra"
@@ -63,26 +61,18 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	if output.HasFlag() {
-		outList := []*msv1.ManagedService{}
-		servicesList.Each(func(srv *msv1.ManagedService) bool {
-			outList = append(outList, srv)
-			return true
-		})
-		output.Print(outList)
-		if err != nil {
-			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
-		}
-		os.Exit(0)
-	}
-
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "SERVICE_ID\tSERVICE\tSERVICE_STATE\tCLUSTER_NAME\n")
+	outList := []*msv1.ManagedService{}
 	servicesList.Each(func(srv *msv1.ManagedService) bool {
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
-			srv.ID(), srv.Service(), srv.ServiceState(), srv.Cluster().Name())
+		outList = append(outList, srv)
 		return true
 	})
-	writer.Flush()
+	if output.HasFlag() {
+		err = output.Print(outList)
+	} else {
+		err = output.PrintTable(outList)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 }
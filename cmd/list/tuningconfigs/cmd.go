@@ -17,9 +17,7 @@ limitations under the License.
 package tuningconfigs
 
 import (
-	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -76,15 +74,9 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
-	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
-
-	fmt.Fprintf(writer, "ID\tNAME\n")
-	for _, tuningConfig := range tuningConfigs {
-		fmt.Fprintf(writer, "%s\t%s\n",
-			tuningConfig.ID(),
-			tuningConfig.Name(),
-		)
+	err = output.PrintTable(tuningConfigs)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
 	}
-	writer.Flush()
 }
@@ -63,9 +63,17 @@ func init() {
 	output.AddFlag(Cmd)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 	isHostedCp := args.hostedCp
 	var product string
 	if isHostedCp {
@@ -76,8 +84,7 @@ func run(_ *cobra.Command, _ []string) {
 	r.Reporter.Debugf("Fetching versions")
 	versions, err := r.OCMClient.GetVersionsWithProduct(product, args.channelGroup, false)
 	if err != nil {
-		r.Reporter.Errorf("Failed to fetch versions: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("Failed to fetch versions: %v", err)
 	}
 
 	var availableVersions []*cmv1.Version
@@ -91,12 +98,7 @@ func run(_ *cobra.Command, _ []string) {
 	}
 
 	if output.HasFlag() {
-		err = output.Print(availableVersions)
-		if err != nil {
-			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
-		}
-		os.Exit(0)
+		return output.Print(availableVersions)
 	}
 
 	if len(availableVersions) == 0 {
@@ -135,5 +137,5 @@ func run(_ *cobra.Command, _ []string) {
 			availableUpgrades,
 		)
 	}
-	writer.Flush()
+	return writer.Flush()
 }
@@ -0,0 +1,45 @@
+package version
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/fixtures"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("list versions", func() {
+	It("Lists the versions recorded in the fixtures", func() {
+		replay, err := fixtures.Start(filepath.Join("..", "..", "..", "pkg", "fixtures", "testdata", "versions.har"))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(replay.Stop)
+
+		r := rosa.NewRuntime()
+		r.OCMClient, err = ocm.NewClient().
+			Logger(logging.NewLogger()).
+			Transport(replay).
+			Config(&config.Config{
+				URL:         "https://api.openshift.com",
+				AccessToken: MakeTokenString("Bearer", 15*time.Minute),
+			}).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Cleanup()
+
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, r, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr).To(BeEmpty())
+		Expect(stdout).To(MatchRegexp(`VERSION\s+DEFAULT\s+AVAILABLE UPGRADES\n`))
+		Expect(stdout).To(MatchRegexp(`\n4\.15\.2\s+yes\s*\n`))
+		Expect(stdout).To(MatchRegexp(`\n4\.14\.16\s+no\s*\n`))
+		Expect(replay.Unused()).To(BeEmpty())
+	})
+})
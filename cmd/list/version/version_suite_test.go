@@ -0,0 +1,13 @@
+package version
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestListVersion(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "List version Suite")
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/orphan"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete orphaned IAM and OIDC resources",
+	Long: "Delete the operator roles, operator policies, OIDC configs and OIDC providers of the current " +
+		"AWS account that no live cluster references anymore. Use 'rosa list orphaned-resources' to " +
+		"review them first.\n\n" +
+		"Operator roles and OIDC providers that were not created for a cluster, and OIDC configs created " +
+		"in the last 7 days, are never considered orphaned, as they may have been created ahead of time " +
+		"for Hosted Control Plane clusters. Each deletion needs to be confirmed, unless '--yes' is used.",
+	Example: `  # Delete all orphaned resources, confirming each of them
+  rosa prune --mode auto
+
+  # Print the commands needed to delete the orphaned resources
+  rosa prune --mode manual`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	arguments.AddProfileFlag(flags)
+	aws.AddModeFlag(Cmd)
+	confirm.AddFlag(flags)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if !cmd.Flags().Changed("mode") {
+		if !interactive.Enabled() {
+			interactive.Enable()
+		}
+		mode, err = interactive.GetOption(interactive.Input{
+			Question: "Orphaned resources deletion mode",
+			Flag:     "mode",
+			Help:     cmd.Flags().Lookup("mode").Usage,
+			Default:  aws.ModeAuto,
+			Options:  aws.Modes,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid deletion mode: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Looking for orphaned resources")
+		r.Spinner.Start()
+	}
+	resources, err := orphan.Find(r)
+	r.Spinner.Stop()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if len(resources) == 0 {
+		r.Reporter.Infof("There are no orphaned resources")
+		return
+	}
+
+	switch mode {
+	case aws.ModeAuto:
+		r.OCMClient.LogEvent("ROSAPruneModeAuto", nil)
+		failures := 0
+		for _, resource := range resources {
+			r.Reporter.Infof("%s", resource.Reason)
+			if len(resource.RoleNames) > 0 {
+				r.Reporter.Infof("Roles: %s", strings.Join(resource.RoleNames, ", "))
+			}
+			if !confirm.Prompt(false, "Delete %s '%s'?", resource.Kind, resource.ID) {
+				continue
+			}
+			err := orphan.Delete(r, resource)
+			if err != nil {
+				r.Reporter.Warnf("%s", err)
+				failures++
+				continue
+			}
+			r.Reporter.Infof("Deleted %s '%s'", resource.Kind, resource.ID)
+		}
+		if failures > 0 {
+			r.Reporter.Errorf("Failed to delete %d orphaned resource(s)", failures)
+			os.Exit(1)
+		}
+	case aws.ModeManual:
+		r.OCMClient.LogEvent("ROSAPruneModeManual", nil)
+		commands := []string{}
+		for _, resource := range resources {
+			resourceCommands, err := orphan.ManualCommands(r, resource)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			commands = append(commands, fmt.Sprintf("# %s", resource.Reason))
+			commands = append(commands, resourceCommands...)
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following commands to delete the orphaned resources:\n")
+		}
+		fmt.Println(strings.Join(commands, "\n"))
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", aws.Modes)
+		os.Exit(1)
+	}
+}
@@ -106,6 +106,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if !cmd.Flags().Changed("mode") {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "OIDC Provider creation mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -155,6 +156,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !cmd.Flags().Changed(IssuerUrlFlag) {
 		issuerUrl, err := interactive.GetString(interactive.Input{
 			Question:   "Issuer URL (please include 'https://')",
+			Flag:       IssuerUrlFlag,
 			Help:       cmd.Flags().Lookup(IssuerUrlFlag).Usage,
 			Required:   true,
 			Validators: []interactive.Validator{interactive.IsURLHttps},
@@ -173,6 +175,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !cmd.Flags().Changed(SecretArnFlag) {
 		secretArn, err := interactive.GetString(interactive.Input{
 			Question:   "Secret ARN",
+			Flag:       SecretArnFlag,
 			Help:       cmd.Flags().Lookup(SecretArnFlag).Usage,
 			Required:   true,
 			Validators: []interactive.Validator{aws.SecretManagerArnValidator},
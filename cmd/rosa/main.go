@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/cache"
 	"github.com/openshift/rosa/cmd/completion"
 	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
@@ -33,6 +34,8 @@ import (
 	"github.com/openshift/rosa/cmd/edit"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/hibernate"
+	"github.com/openshift/rosa/cmd/hibernation"
+	"github.com/openshift/rosa/cmd/history"
 	"github.com/openshift/rosa/cmd/initialize"
 	"github.com/openshift/rosa/cmd/install"
 	"github.com/openshift/rosa/cmd/link"
@@ -40,6 +43,7 @@ import (
 	"github.com/openshift/rosa/cmd/login"
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
+	"github.com/openshift/rosa/cmd/prune"
 	"github.com/openshift/rosa/cmd/register"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
@@ -51,7 +55,14 @@ import (
 	"github.com/openshift/rosa/cmd/version"
 	"github.com/openshift/rosa/cmd/whoami"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/audit"
 	"github.com/openshift/rosa/pkg/color"
+	completions "github.com/openshift/rosa/pkg/completion"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 var root = &cobra.Command{
@@ -68,6 +79,19 @@ func init() {
 	fs := root.PersistentFlags()
 	color.AddFlag(root)
 	arguments.AddDebugFlag(fs)
+	arguments.AddAssumeRoleFlags(fs)
+	arguments.AddAWSEndpointFlags(fs)
+	interactive.AddNoPromptFlag(fs)
+	logging.AddFlags(fs)
+	ocm.AddNoCacheFlag(fs)
+
+	// Record the mutating commands in the audit journal:
+	root.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		logging.SetField(logging.CommandField, cmd.CommandPath())
+		confirm.SetCommand(cmd)
+		audit.Begin(cmd, os.Args[1:])
+	}
+	reporter.OnError(audit.RecordError)
 
 	// Register the subcommands:
 	root.AddCommand(completion.Cmd)
@@ -84,6 +108,7 @@ func init() {
 	root.AddCommand(login.Cmd)
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
+	root.AddCommand(prune.Cmd)
 	root.AddCommand(register.Cmd)
 	root.AddCommand(revoke.Cmd)
 	root.AddCommand(uninstall.Cmd)
@@ -93,16 +118,23 @@ func init() {
 	root.AddCommand(whoami.Cmd)
 	root.AddCommand(hibernate.GenerateCommand())
 	root.AddCommand(resume.GenerateCommand())
+	root.AddCommand(hibernation.Cmd)
+	root.AddCommand(history.Cmd)
 	root.AddCommand(link.Cmd)
 	root.AddCommand(unlink.Cmd)
 	root.AddCommand(token.Cmd)
 	root.AddCommand(config.Cmd)
+	root.AddCommand(cache.Cmd)
+
+	// Complete the names of the resources in the flags that are the same in all the commands:
+	completions.Register(root)
 }
 
 func main() {
 	// Execute the root command:
 	root.SetArgs(os.Args[1:])
 	err := root.Execute()
+	audit.End(err)
 	if err != nil {
 		if !strings.Contains(err.Error(), "Did you mean this?") {
 			fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -9,6 +10,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+func TestMain(m *testing.M) {
+	runCommandFromEnv()
+	os.Exit(m.Run())
+}
+
 func TestCommandStructure(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "rosa command structure")
@@ -18,6 +24,17 @@ var _ = Describe("ROSA Commands", func() {
 	It("Have all basic fields defined correctly", func() {
 		assertCommand(root)
 	})
+
+	It("Accept the assume role flags in all the commands", func() {
+		for _, leaf := range leafCommands(root) {
+			for _, flag := range []string{"assume-role-arn", "assume-role-external-id", "role-session-name",
+				"mfa-serial", "mfa-token"} {
+				Expect(leaf.Flag(flag)).NotTo(BeNil(), "Command '%s' doesn't have the '--%s' flag",
+					leaf.CommandPath(), flag)
+			}
+		}
+		Expect(root.PersistentFlags().Lookup("external-id")).NotTo(BeNil())
+	})
 })
 
 func assertCommand(command *cobra.Command) {
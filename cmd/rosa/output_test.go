@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/output"
+)
+
+const outputPackage = "github.com/openshift/rosa/pkg/output"
+
+// listedPackage is the part of the output of 'go list -json' used to type check the packages
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	Export     string
+	GoFiles    []string
+	Standard   bool
+	Module     *struct {
+		Main bool
+	}
+}
+
+// printCall is a call to output.Print or output.PrintTable and the type of the printed resource
+type printCall struct {
+	position string
+	function string
+	resource string
+}
+
+// findPrintCalls type checks the packages of this module that print resources and returns the
+// calls to output.Print and output.PrintTable that they contain
+func findPrintCalls() []printCall {
+	// #nosec G204
+	list := exec.Command("go", "list", "-mod=vendor", "-export", "-deps", "-json", "../../cmd/...",
+		"../../pkg/...")
+	list.Stderr = GinkgoWriter
+	data, err := list.Output()
+	Expect(err).NotTo(HaveOccurred())
+	exports := map[string]string{}
+	packages := []listedPackage{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	for decoder.More() {
+		listed := listedPackage{}
+		Expect(decoder.Decode(&listed)).To(Succeed())
+		exports[listed.ImportPath] = listed.Export
+		if listed.Module != nil && listed.Module.Main {
+			packages = append(packages, listed)
+		}
+	}
+
+	fset := token.NewFileSet()
+	imports := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		// #nosec G304
+		return os.Open(exports[path])
+	})
+	calls := []printCall{}
+	for _, listed := range packages {
+		files := []*ast.File{}
+		prints := false
+		for _, name := range listed.GoFiles {
+			file, err := parser.ParseFile(fset, filepath.Join(listed.Dir, name), nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			files = append(files, file)
+			for _, spec := range file.Imports {
+				prints = prints || spec.Path.Value == `"`+outputPackage+`"`
+			}
+		}
+		if !prints {
+			continue
+		}
+		info := &types.Info{
+			Types: map[ast.Expr]types.TypeAndValue{},
+			Uses:  map[*ast.Ident]types.Object{},
+		}
+		config := types.Config{Importer: imports}
+		_, err := config.Check(listed.ImportPath, fset, files, info)
+		Expect(err).NotTo(HaveOccurred())
+		for _, file := range files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok || len(call.Args) != 1 {
+					return true
+				}
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || (selector.Sel.Name != "Print" && selector.Sel.Name != "PrintTable") {
+					return true
+				}
+				function, ok := info.Uses[selector.Sel].(*types.Func)
+				if !ok || function.Pkg() == nil || function.Pkg().Path() != outputPackage {
+					return true
+				}
+				calls = append(calls, printCall{
+					position: fset.Position(call.Pos()).String(),
+					function: selector.Sel.Name,
+					resource: normalizeTypeName(types.TypeString(info.Types[call.Args[0]].Type,
+						func(p *types.Package) string { return p.Path() })),
+				})
+				return true
+			})
+		}
+	}
+	return calls
+}
+
+var anyExpression = regexp.MustCompile(`\bany\b`)
+
+func normalizeTypeName(name string) string {
+	return anyExpression.ReplaceAllString(name, "interface{}")
+}
+
+// typeName returns the name of the type in the format used by the go/types package
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface{}"
+		}
+	}
+	return t.String()
+}
+
+// emptyResource returns an empty resource of the given type
+func emptyResource(t reflect.Type) interface{} {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.New(t.Elem()).Interface()
+	case reflect.Slice:
+		return reflect.MakeSlice(t, 0, 0).Interface()
+	case reflect.Map:
+		return reflect.MakeMap(t).Interface()
+	}
+	return reflect.Zero(t).Interface()
+}
+
+var _ = Describe("Output", func() {
+	var stdout *os.File
+
+	BeforeEach(func() {
+		stdout = os.Stdout
+		devNull, err := os.Open(os.DevNull)
+		Expect(err).NotTo(HaveOccurred())
+		os.Stdout = devNull
+		DeferCleanup(func() {
+			os.Stdout = stdout
+			output.SetOutput("")
+			devNull.Close()
+		})
+	})
+
+	It("Has printers for the resources printed by all the commands", func() {
+		registered := map[string][]output.Column{}
+		for resourceType, columns := range output.Registered() {
+			registered[typeName(resourceType)] = columns
+		}
+		calls := findPrintCalls()
+		Expect(calls).NotTo(BeEmpty())
+		for _, call := range calls {
+			columns, ok := registered[call.resource]
+			Expect(ok).To(BeTrue(), "No printer is registered for '%s' printed at %s", call.resource,
+				call.position)
+			if call.function == "PrintTable" {
+				Expect(columns).NotTo(BeEmpty(), "No columns are registered for '%s' printed as a table at %s",
+					call.resource, call.position)
+			}
+		}
+	})
+
+	It("Prints all the registered resources", func() {
+		for resourceType, columns := range output.Registered() {
+			resource := emptyResource(resourceType)
+			output.SetOutput(output.JSON)
+			Expect(output.Print(resource)).To(Succeed(), "%s", resourceType)
+			if len(columns) == 0 {
+				continue
+			}
+			output.SetOutput(output.CUSTOMCOLUMNS)
+			Expect(output.Print(resource)).To(Succeed(), "%s", resourceType)
+			Expect(output.PrintTable(resource)).To(Succeed(), "%s", resourceType)
+		}
+	})
+})
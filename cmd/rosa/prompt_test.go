@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/test/fakeocm"
+)
+
+// Commands that aren't run because they write files or download binaries
+var promptLeakSkipped = map[string]bool{
+	"rosa docs":     true,
+	"rosa download": true,
+}
+
+func leafCommands(command *cobra.Command) []*cobra.Command {
+	if promptLeakSkipped[command.CommandPath()] || command.Hidden {
+		return nil
+	}
+	if len(command.Commands()) == 0 {
+		return []*cobra.Command{command}
+	}
+	result := []*cobra.Command{}
+	for _, c := range command.Commands() {
+		result = append(result, leafCommands(c)...)
+	}
+	return result
+}
+
+var _ = Describe("Prompts", func() {
+	var server *fakeocm.Server
+	var aws *httptest.Server
+
+	BeforeEach(func() {
+		server = fakeocm.NewServer()
+		DeferCleanup(server.Close)
+		aws = newFakeAWS()
+		DeferCleanup(aws.Close)
+		output, err := command(commandEnv(server), "create cluster --cluster-name mycluster --non-sts "+
+			"--version 4.14.16 --yes --aws-endpoint-url "+aws.URL).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+	})
+
+	// run runs the command with its own home directory, logged in to the fake OCM server, and with
+	// the standard input connected to the given pipe
+	run := func(ctx context.Context, stdin *os.File, args string) []byte {
+		// #nosec G204
+		process := exec.CommandContext(ctx, os.Args[0])
+		process.Env = append(commandEnv(server), commandArgsEnv+"="+args+" --aws-endpoint-url "+aws.URL)
+		process.Stdin = stdin
+		output, _ := process.CombinedOutput()
+		return output
+	}
+
+	It("Fail instead of waiting for an answer when the input isn't a terminal", func() {
+		// The standard input is a pipe that is never written or closed, like in CI systems,
+		// so a prompt that isn't disabled waits forever
+		stdin, writer, err := os.Pipe()
+		Expect(err).NotTo(HaveOccurred())
+		defer stdin.Close()
+		defer writer.Close()
+
+		commands := leafCommands(root)
+		hung := make(chan string, len(commands))
+		workers := make(chan struct{}, 8)
+		var wg sync.WaitGroup
+		for _, leaf := range commands {
+			args := strings.Join(strings.Fields(leaf.CommandPath())[1:], " ")
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				workers <- struct{}{}
+				defer func() { <-workers }()
+
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				output := run(ctx, stdin, args)
+				if ctx.Err() != nil {
+					hung <- args
+				}
+				Expect(string(output)).NotTo(ContainSubstring("inappropriate ioctl"), args)
+			}()
+		}
+		wg.Wait()
+		close(hung)
+
+		leaks := []string{}
+		for args := range hung {
+			leaks = append(leaks, args)
+		}
+		Expect(leaks).To(BeEmpty(), "Commands waiting for an answer without a terminal")
+	})
+
+	DescribeTable("Tell which flag provides the missing value",
+		func(args string, flag string) {
+			stdin, writer, err := os.Pipe()
+			Expect(err).NotTo(HaveOccurred())
+			defer stdin.Close()
+			defer writer.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			output := run(ctx, stdin, args)
+			Expect(ctx.Err()).NotTo(HaveOccurred(), "Command 'rosa %s' is waiting for an answer", args)
+			Expect(string(output)).To(ContainSubstring("because prompts are disabled, use the '--%s' flag", flag))
+		},
+		Entry("Create cluster", "create cluster", "cluster-name"),
+		Entry("Create machine pool", "create machinepool --cluster mycluster", "name"),
+		Entry("Create identity provider", "create idp --cluster mycluster", "type"),
+		Entry("Edit cluster", "edit cluster --cluster mycluster", "private"),
+		Entry("Upgrade cluster", "upgrade cluster --cluster mycluster", "version"),
+		Entry("Delete cluster", "delete cluster --cluster mycluster", "yes"),
+	)
+})
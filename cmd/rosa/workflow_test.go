@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/test/fakeocm"
+)
+
+// Environment variable with the arguments of the command that the test binary runs instead of
+// the tests, used to run each command in its own process, as commands exit when they fail
+const commandArgsEnv = "ROSA_TEST_COMMAND_ARGS"
+
+// runCommandFromEnv runs the command given in the environment, if any, and exits
+func runCommandFromEnv() {
+	args, ok := os.LookupEnv(commandArgsEnv)
+	if !ok {
+		return
+	}
+	root.SetArgs(strings.Fields(args))
+	err := root.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// fakeAWSResults are the results of the AWS queries that the fake AWS endpoint answers, by action.
+// The fake account has the 'osdCcsAdmin' user that clusters without STS need.
+var fakeAWSResults = map[string]string{
+	"GetCallerIdentity": `<Arn>arn:aws:iam::123456789012:user/dev</Arn><UserId>AIDADEV</UserId>` +
+		`<Account>123456789012</Account>`,
+	"GetUser": `<User><UserName>osdCcsAdmin</UserName><UserId>AIDAOSDCCSADMIN</UserId><Path>/</Path>` +
+		`<Arn>arn:aws:iam::123456789012:user/osdCcsAdmin</Arn><CreateDate>2024-01-01T00:00:00Z</CreateDate></User>`,
+	"ListAccessKeys": `<AccessKeyMetadata></AccessKeyMetadata><IsTruncated>false</IsTruncated>`,
+	"CreateAccessKey": `<AccessKey><UserName>osdCcsAdmin</UserName><AccessKeyId>AKIAOSDCCSADMIN</AccessKeyId>` +
+		`<Status>Active</Status><SecretAccessKey>secret</SecretAccessKey></AccessKey>`,
+}
+
+// newFakeAWS starts a server that answers the AWS queries sent by the commands to check the account
+func newFakeAWS() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query, err := url.ParseQuery(string(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		action := query.Get("Action")
+		result, ok := fakeAWSResults[action]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "<ErrorResponse><Error><Code>InvalidAction</Code>"+
+				"<Message>Action '%s' isn't supported</Message></Error></ErrorResponse>", action)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintf(w, "<%sResponse><%sResult>%s</%sResult></%sResponse>", action, action, result, action, action)
+	}))
+}
+
+// commandEnv returns the environment that makes the commands use the given fake OCM server and AWS
+// endpoint, with a new home directory
+func commandEnv(server *fakeocm.Server) []string {
+	home := GinkgoT().TempDir()
+	data, err := json.Marshal(server.Config())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(home, "ocm.json"), data, 0600)).To(Succeed())
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + home,
+		"OCM_CONFIG=" + filepath.Join(home, "ocm.json"),
+		"AWS_ACCESS_KEY_ID=AKIADEV",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"AWS_REGION=us-east-1",
+		"AWS_EC2_METADATA_DISABLED=true",
+	}
+}
+
+// command returns a process that runs the given command with the given environment
+func command(env []string, args string) *exec.Cmd {
+	// #nosec G204
+	process := exec.Command(os.Args[0])
+	process.Env = append(env, commandArgsEnv+"="+args)
+	return process
+}
+
+var _ = Describe("Workflow", func() {
+	var server *fakeocm.Server
+	var run func(args string) string
+	var runStdout func(args string) string
+
+	BeforeEach(func() {
+		server = fakeocm.NewServer()
+		DeferCleanup(server.Close)
+		aws := newFakeAWS()
+		DeferCleanup(aws.Close)
+		env := commandEnv(server)
+		run = func(args string) string {
+			output, err := command(env, args+" --aws-endpoint-url "+aws.URL).CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), "Command 'rosa %s' failed:\n%s", args, output)
+			return string(output)
+		}
+		runStdout = func(args string) string {
+			process := command(env, args+" --aws-endpoint-url "+aws.URL)
+			var stderr strings.Builder
+			process.Stderr = &stderr
+			output, err := process.Output()
+			Expect(err).NotTo(HaveOccurred(), "Command 'rosa %s' failed:\n%s%s", args, output, stderr.String())
+			return string(output)
+		}
+	})
+
+	It("Creates, adds a machine pool to, upgrades and deletes a cluster", func() {
+		output := run("create cluster --cluster-name mycluster --non-sts --version 4.14.16 --yes")
+		Expect(output).To(ContainSubstring("Cluster 'mycluster' has been created"))
+		clusters := server.List("clusters")
+		Expect(clusters).To(HaveLen(1))
+		clusterID := clusters[0]["id"].(string)
+		Expect(clusters[0]["region"]).To(HaveKeyWithValue("id", "us-east-1"))
+
+		output = run("create machinepool --cluster mycluster --name mp1 --replicas 2 --instance-type m5.xlarge")
+		Expect(output).To(ContainSubstring("Machine pool 'mp1' created successfully"))
+		machinePool, ok := server.Get("clusters/" + clusterID + "/machine_pools/mp1")
+		Expect(ok).To(BeTrue())
+		Expect(machinePool["replicas"]).To(BeEquivalentTo(2))
+		Expect(run("list machinepools --cluster mycluster")).To(MatchRegexp(`mp1\s+No\s+2\s+m5.xlarge`))
+
+		output = run("upgrade cluster --cluster mycluster --version 4.15.2 --yes")
+		Expect(output).To(ContainSubstring("Upgrade successfully scheduled for cluster 'mycluster'"))
+		upgrades := server.List("clusters/" + clusterID + "/upgrade_policies")
+		Expect(upgrades).To(HaveLen(1))
+		Expect(upgrades[0]["version"]).To(Equal("4.15.2"))
+
+		output = run("delete cluster --cluster mycluster --yes")
+		Expect(output).To(ContainSubstring("Cluster 'mycluster' will start uninstalling now"))
+		Expect(server.List("clusters")).To(BeEmpty())
+		Expect(run("list clusters")).To(ContainSubstring("No clusters available"))
+	})
+
+	It("Prints the generated admin password whatever the log level and format", func() {
+		run("create cluster --cluster-name mycluster --non-sts --version 4.14.16 --yes")
+		run("create cluster --cluster-name other --non-sts --version 4.14.16 --yes")
+		password := regexp.MustCompile(`--password (\S+)`)
+
+		output := runStdout("create admin --cluster mycluster --log-level warn --log-format json")
+		Expect(output).To(MatchRegexp(`--username cluster-admin --password \S{8,}`))
+		Expect(password.FindStringSubmatch(output)[1]).NotTo(Equal("***"))
+
+		// The messages copied to the log file don't contain the password:
+		logFile := filepath.Join(GinkgoT().TempDir(), "rosa.log")
+		output = runStdout("create admin --cluster other --log-file " + logFile)
+		generated := password.FindStringSubmatch(output)
+		Expect(generated).To(HaveLen(2))
+		data, err := os.ReadFile(logFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("--password ***"))
+		Expect(string(data)).NotTo(ContainSubstring(generated[1]))
+	})
+})
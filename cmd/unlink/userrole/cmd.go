@@ -95,6 +95,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if interactive.Enabled() && roleArn == "" {
 		roleArn, err = interactive.GetString(interactive.Input{
 			Question: "User Role ARN",
+			Flag:     "role-arn",
 			Help:     cmd.Flags().Lookup("role-arn").Usage,
 			Default:  roleArn,
 			Required: true,
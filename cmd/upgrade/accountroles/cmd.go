@@ -19,6 +19,7 @@ package accountroles
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ import (
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	awscbRoles "github.com/openshift/rosa/pkg/aws/commandbuilder/helper/roles"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/completion"
+	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/helper/roles"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -44,6 +47,7 @@ var args struct {
 	version      string
 	channelGroup string
 	hostedCP     bool
+	showDiff     string
 }
 
 var Cmd = &cobra.Command{
@@ -52,7 +56,10 @@ var Cmd = &cobra.Command{
 	Short:   "Upgrade account-wide IAM roles to the latest version.",
 	Long:    "Upgrade account-wide IAM roles to the latest version before upgrading your cluster.",
 	Example: `  # Upgrade account roles for ROSA STS clusters
-  rosa upgrade account-roles`,
+  rosa upgrade account-roles
+
+  # Show the permission changes of each policy before upgrading it
+  rosa upgrade account-roles --prefix ManagedOpenShift --show-diff`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -93,8 +100,11 @@ func init() {
 		"Enable the use of Hosted Control Planes",
 	)
 
+	roles.AddShowDiffFlag(Cmd, &args.showDiff)
+
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
+	Cmd.RegisterFlagCompletionFunc("prefix", completion.AccountRolePrefixes)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -112,6 +122,11 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	prefix := args.prefix
 
+	if args.showDiff != "" && !helper.Contains(aws.DiffFormats, args.showDiff) {
+		reporter.Errorf("Invalid diff format '%s'. Allowed values are %s", args.showDiff, aws.DiffFormats)
+		os.Exit(1)
+	}
+
 	version := args.version
 	isVersionChosen := version != ""
 	channelGroup := args.channelGroup
@@ -221,6 +236,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if interactive.Enabled() && !skipInteractive {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Account role upgrade mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -238,6 +254,20 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.showDiff != "" {
+		diffs, err := getAccountRolePolicyDiffs(awsClient, prefix, creator.Partition, creator.AccountID, policies,
+			policyPath)
+		if err != nil {
+			reporter.Errorf("Failed to compare the account role policies: %v", err)
+			os.Exit(1)
+		}
+		err = roles.PrintPolicyDiffs(reporter, diffs, args.showDiff)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
 	switch mode {
 	case aws.ModeAuto:
 		if isUpgradeNeedForAccountRolePolicies {
@@ -333,6 +363,26 @@ func upgradeAccountRolePolicies(reporter *rprtr.Object, awsClient aws.Client, pr
 	return nil
 }
 
+// getAccountRolePolicyDiffs compares the current documents of the account role policies with the
+// ones that the upgrade applies
+func getAccountRolePolicyDiffs(awsClient aws.Client, prefix string, partition string, accountID string,
+	policies map[string]*cmv1.AWSSTSPolicy, policyPath string) ([]*aws.PolicyDiff, error) {
+	files := helper.MapKeys(aws.AccountRoles)
+	sort.Strings(files)
+	diffs := []*aws.PolicyDiff{}
+	for _, file := range files {
+		roleName := common.GetRoleName(prefix, aws.AccountRoles[file].Name)
+		policyARN := aws.GetPolicyARN(partition, accountID, roleName, policyPath)
+		policyDetails := aws.GetPolicyDetails(policies, fmt.Sprintf("sts_%s_permission_policy", file))
+		diff, err := roles.GetPolicyDiff(awsClient, roleName, policyARN, policyDetails)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
 func buildCommands(prefix string, partition string, accountID string, isUpgradeNeedForAccountRolePolicies bool,
 	awsClient aws.Client, defaultPolicyVersion string, policyPath string) string {
 	commands := []string{}
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "addon ID",
+	Aliases: []string{"addons", "add-on", "add-ons"},
+	Short:   "Upgrade add-on installation on cluster",
+	Long: "Upgrade a Red Hat managed add-on installation on a cluster to a new available version. " +
+		"When no version is given, the versions of the add-on are listed.",
+	Example: `  # List the versions available for the Red Hat OpenShift logging operator add-on installation
+  rosa upgrade addon --cluster=mycluster cluster-logging-operator
+
+  # Upgrade the Red Hat OpenShift logging operator add-on installation to version 5.8.1
+  rosa upgrade addon --cluster=mycluster cluster-logging-operator --version=5.8.1`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"expected exactly one command line parameter containing the id of the add-on",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of the add-on that the installation will be upgraded to",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd, argv)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
+	addOnID := argv[0]
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+
+	addOnInstallation, err := r.OCMClient.GetAddOnInstallation(cluster.ID(), addOnID)
+	if err != nil {
+		return fmt.Errorf("Failed to get add-on '%s' installation: %v", addOnID, err)
+	}
+	currentVersion := addOnInstallation.AddonVersion().ID()
+	if currentVersion == "" {
+		return fmt.Errorf("Add-on '%s' installation on cluster '%s' does not report its version",
+			addOnID, clusterKey)
+	}
+
+	r.Reporter.Debugf("Fetching versions of add-on '%s'", addOnID)
+	versions, err := r.OCMClient.GetAddOnVersions(addOnID)
+	if err != nil {
+		return fmt.Errorf("Failed to get versions of add-on '%s': %v", addOnID, err)
+	}
+	availableUpgrades := []string{}
+	for _, version := range versions {
+		if version.ID() == currentVersion {
+			availableUpgrades = version.AvailableUpgrades()
+			break
+		}
+	}
+
+	version := args.version
+	if version == "" {
+		if !interactive.Enabled() {
+			printVersions(versions, currentVersion, availableUpgrades)
+			if len(availableUpgrades) > 0 {
+				r.Reporter.Infof("To upgrade add-on '%s' run 'rosa upgrade addon %s -c %s --version <version>'",
+					addOnID, addOnID, clusterKey)
+			}
+			return nil
+		}
+		if len(availableUpgrades) == 0 {
+			r.Reporter.Infof("There are no available upgrades for add-on '%s' on cluster '%s'", addOnID, clusterKey)
+			return nil
+		}
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "Version",
+			Flag:     "version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options:  availableUpgrades,
+			Default:  availableUpgrades[len(availableUpgrades)-1],
+			Required: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid add-on version: %s", err)
+		}
+	}
+
+	if version == currentVersion {
+		r.Reporter.Infof("Add-on '%s' on cluster '%s' is already at version '%s'", addOnID, clusterKey, version)
+		return nil
+	}
+	if !helper.Contains(availableUpgrades, version) {
+		if len(availableUpgrades) == 0 {
+			return fmt.Errorf("There are no available upgrades for add-on '%s' from version '%s'",
+				addOnID, currentVersion)
+		}
+		return fmt.Errorf("Version '%s' is not an available upgrade for add-on '%s' from version '%s'. "+
+			"Available upgrades: %s", version, addOnID, currentVersion, strings.Join(availableUpgrades, ", "))
+	}
+
+	if !confirm.Confirm("upgrade add-on '%s' on cluster '%s' from version '%s' to '%s'",
+		addOnID, clusterKey, currentVersion, version) {
+		return nil
+	}
+
+	r.Reporter.Debugf("Upgrading add-on '%s' on cluster '%s' to version '%s'", addOnID, clusterKey, version)
+	err = r.OCMClient.UpgradeAddOnInstallation(cluster.ID(), addOnID, version)
+	if err != nil {
+		return fmt.Errorf("Failed to upgrade add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
+	}
+	r.Reporter.Infof("Add-on '%s' is now upgrading to version '%s'. To check the status run 'rosa list addons -c %s'",
+		addOnID, version, clusterKey)
+	return nil
+}
+
+func printVersions(versions []*cmv1.AddOnVersion, currentVersion string, availableUpgrades []string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "VERSION\tCHANNEL\tNOTES\n")
+	for _, version := range versions {
+		if !version.Enabled() {
+			continue
+		}
+		notes := ""
+		if version.ID() == currentVersion {
+			notes = "installed"
+		} else if helper.Contains(availableUpgrades, version.ID()) {
+			notes = "available upgrade"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", version.ID(), version.Channel(), notes)
+	}
+	writer.Flush()
+}
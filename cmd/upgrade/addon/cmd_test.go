@@ -0,0 +1,107 @@
+package addon
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const addOnID = "cluster-logging-operator"
+
+var _ = Describe("Upgrade add-on", func() {
+	Context("Upgrade add-on command", func() {
+		var testRuntime test.TestingRuntime
+
+		mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.AWS(cmv1.NewAWS().SubnetIDs("subnet-0b761d44d3d9a4663", "subnet-0f87f640e56934cbc"))
+			c.Region(cmv1.NewCloudRegion().ID("us-east-1"))
+			c.State(cmv1.ClusterStateReady)
+		})
+		clusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+
+		installation := formatAddOnInstallation("5.8.0")
+		versions := formatAddOnVersionList(
+			cmv1.NewAddOnVersion().ID("5.8.0").Channel("stable").Enabled(true).AvailableUpgrades("5.8.1"),
+			cmv1.NewAddOnVersion().ID("5.8.1").Channel("stable").Enabled(true),
+			cmv1.NewAddOnVersion().ID("5.9.0").Channel("stable").Enabled(true),
+		)
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			args.version = ""
+			Expect(Cmd.Flags().Set("yes", "true")).To(Succeed())
+		})
+
+		It("Lists the versions when no version is given", func() {
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, installation))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, versions))
+			stdout, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime,
+				Cmd, &[]string{addOnID})
+			Expect(err).To(BeNil())
+			Expect(stdout).To(ContainSubstring("VERSION  CHANNEL  NOTES"))
+			Expect(stdout).To(ContainSubstring("5.8.0    stable   installed"))
+			Expect(stdout).To(ContainSubstring("5.8.1    stable   available upgrade"))
+		})
+
+		It("Fails if the version is not an available upgrade", func() {
+			args.version = "5.9.0"
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, installation))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, versions))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{addOnID})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("Version '5.9.0' is not an available upgrade for add-on " +
+				"'cluster-logging-operator' from version '5.8.0'. Available upgrades: 5.8.1"))
+		})
+
+		It("Upgrades to an available version", func() {
+			args.version = "5.8.1"
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, installation))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, versions))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, formatAddOnInstallation("5.8.1")))
+			_, stderr, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime,
+				Cmd, &[]string{addOnID})
+			Expect(err).To(BeNil())
+			Expect(stderr).To(BeEmpty())
+		})
+	})
+})
+
+func formatAddOnInstallation(version string) string {
+	installation, err := cmv1.NewAddOnInstallation().ID(addOnID).
+		Addon(cmv1.NewAddOn().ID(addOnID)).
+		AddonVersion(cmv1.NewAddOnVersion().ID(version)).
+		Build()
+	Expect(err).To(BeNil())
+	var outputJson bytes.Buffer
+	Expect(cmv1.MarshalAddOnInstallation(installation, &outputJson)).To(Succeed())
+	return outputJson.String()
+}
+
+func formatAddOnVersionList(builders ...*cmv1.AddOnVersionBuilder) string {
+	var versions []*cmv1.AddOnVersion
+	for _, builder := range builders {
+		version, err := builder.Build()
+		Expect(err).To(BeNil())
+		versions = append(versions, version)
+	}
+	var outputJson bytes.Buffer
+	Expect(cmv1.MarshalAddOnVersionList(versions, &outputJson)).To(Succeed())
+	return fmt.Sprintf(`
+	{
+		"kind": "AddOnVersionList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(versions), len(versions), outputJson.String())
+}
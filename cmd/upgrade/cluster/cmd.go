@@ -28,6 +28,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -134,6 +135,7 @@ func init() {
 	)
 
 	confirm.AddFlag(flags)
+	Cmd.RegisterFlagCompletionFunc("version", completion.Versions)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -266,6 +268,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			if currentUpgradeScheduling.AutomaticUpgrades {
 				currentUpgradeScheduling.AllowMinorVersionUpdates, err = interactive.GetBool(interactive.Input{
 					Question: "Allow minor upgrades",
+					Flag:     "allow-minor-version-updates",
 					Help:     cmd.Flags().Lookup("allow-minor-version-updates").Usage,
 					Default:  currentUpgradeScheduling.AllowMinorVersionUpdates,
 					Required: false,
@@ -441,6 +444,7 @@ func buildVersion(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster,
 		}
 		version, err = interactive.GetOption(interactive.Input{
 			Question: "Version",
+			Flag:     "version",
 			Help:     cmd.Flags().Lookup("version").Usage,
 			Options:  availableUpgrades,
 			Default:  version,
@@ -456,6 +460,7 @@ func buildVersion(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster,
 func setMode(r *rosa.Runtime, cmd *cobra.Command) string {
 	mode, err := interactive.GetOption(interactive.Input{
 		Question: "IAM Roles/Policies upgrade mode",
+		Flag:     "mode",
 		Help:     cmd.Flags().Lookup("mode").Usage,
 		Default:  aws.ModeAuto,
 		Options:  aws.Modes,
@@ -524,6 +529,7 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 		var err error
 		nodeDrainGracePeriod, err = interactive.GetOption(interactive.Input{
 			Question: "Node draining",
+			Flag:     "node-drain-grace-period",
 			Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
 			Options:  nodeDrainOptions,
 			Default:  nodeDrainGracePeriod,
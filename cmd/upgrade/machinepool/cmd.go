@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/completion"
 	"github.com/openshift/rosa/pkg/input"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -49,7 +50,8 @@ var Cmd = &cobra.Command{
 
   # Schedule a machinepool upgrade within the hour
   rosa upgrade machinepool np1 -c mycluster --version 4.12.20`,
-	Run: run,
+	Run:               run,
+	ValidArgsFunction: completion.MachinePoolArgs,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
 			return fmt.Errorf(
@@ -108,6 +110,7 @@ func init() {
 
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
+	Cmd.RegisterFlagCompletionFunc("version", completion.Versions)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -185,6 +188,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		if currentUpgradeScheduling.AutomaticUpgrades {
 			currentUpgradeScheduling.AllowMinorVersionUpdates, err = interactive.GetBool(interactive.Input{
 				Question: "Allow minor upgrades",
+				Flag:     "allow-minor-version-updates",
 				Help:     cmd.Flags().Lookup("allow-minor-version-updates").Usage,
 				Default:  currentUpgradeScheduling.AllowMinorVersionUpdates,
 				Required: false,
@@ -349,6 +353,7 @@ func ComputeNodePoolVersion(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.C
 	if interactive.Enabled() {
 		version, err = interactive.GetOption(interactive.Input{
 			Question: "Machine pool version",
+			Flag:     "version",
 			Help:     cmd.Flags().Lookup("version").Usage,
 			Options:  filteredVersionList,
 			Default:  version,
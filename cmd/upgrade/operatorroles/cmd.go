@@ -298,6 +298,7 @@ func handleModeFlag(cmd *cobra.Command, mode string) (string, error) {
 		var err error
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Operator IAM role/policy upgrade mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -19,6 +19,7 @@ package roles
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ var args struct {
 	clusterUpgradeVersion       string
 	policyUpgradeversion        string
 	channelGroup                string
+	showDiff                    string
 }
 
 var Cmd = &cobra.Command{
@@ -56,7 +58,10 @@ var Cmd = &cobra.Command{
 	Short:   "Upgrade cluster-specific IAM roles to the latest version.",
 	Long:    "Upgrade cluster-specific IAM roles to the latest version before upgrading your cluster.",
 	Example: `  # Upgrade cluster roles for ROSA STS clusters
-		rosa upgrade roles -c <cluster_key>`,
+		rosa upgrade roles -c <cluster_key>
+
+  # Show the permission changes of each policy before upgrading it
+  rosa upgrade roles -c <cluster_key> --cluster-version <version> --show-diff=json`,
 	Args: cobra.MaximumNArgs(2),
 	Run:  run,
 }
@@ -98,6 +103,8 @@ func init() {
 	)
 	flags.MarkHidden(channelGroupFlag)
 
+	roles.AddShowDiffFlag(Cmd, &args.showDiff)
+
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -131,6 +138,11 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	if args.showDiff != "" && !helper.Contains(aws.DiffFormats, args.showDiff) {
+		reporter.Errorf("Invalid diff format '%s'. Allowed values are %s", args.showDiff, aws.DiffFormats)
+		os.Exit(1)
+	}
+
 	clusterUpgradeVersion := args.clusterUpgradeVersion
 
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
@@ -177,6 +189,7 @@ func run(cmd *cobra.Command, argv []string) {
 		var err error
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Roles upgrade mode",
+			Flag:     "mode",
 			Help:     cmd.Flags().Lookup("mode").Usage,
 			Default:  aws.ModeAuto,
 			Options:  aws.Modes,
@@ -280,6 +293,20 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 
+		if args.showDiff != "" {
+			diffs, err := getAccountRolePolicyDiffs(awsClient, cluster, creator.Partition, creator.AccountID,
+				accountRolePolicies)
+			if err != nil {
+				reporter.Errorf("Failed to compare the account role policies: %v", err)
+				os.Exit(1)
+			}
+			err = roles.PrintPolicyDiffs(reporter, diffs, args.showDiff)
+			if err != nil {
+				reporter.Errorf("%v", err)
+				os.Exit(1)
+			}
+		}
+
 		switch mode {
 		case aws.ModeAuto:
 			if isUpgradeNeedForAccountRolePolicies {
@@ -403,6 +430,20 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	if isOperatorPolicyUpgradeNeeded && args.showDiff != "" {
+		diffs, err := getOperatorRolePolicyDiffs(r.AWSClient, r.Creator.Partition, r.Creator.AccountID,
+			operatorRolePolicies, credRequests, cluster, operatorRolePolicyPrefix)
+		if err != nil {
+			r.Reporter.Errorf("Failed to compare the operator role policies: %v", err)
+			os.Exit(1)
+		}
+		err = roles.PrintPolicyDiffs(r.Reporter, diffs, args.showDiff)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
 	if isOperatorPolicyUpgradeNeeded {
 		err = upgradeOperatorPolicies(
 			mode,
@@ -629,6 +670,120 @@ func upgradeAccountRolePoliciesFromCluster(
 	return nil
 }
 
+// getAccountRolePolicyDiffs compares the current documents of the account role policies of the
+// cluster with the ones that the upgrade applies
+func getAccountRolePolicyDiffs(
+	awsClient aws.Client,
+	cluster *v1.Cluster,
+	partition string,
+	accountID string,
+	policies map[string]*v1.AWSSTSPolicy,
+) ([]*aws.PolicyDiff, error) {
+	files := helper.MapKeys(aws.AccountRoles)
+	sort.Strings(files)
+	diffs := []*aws.PolicyDiff{}
+	for _, file := range files {
+		role := aws.AccountRoles[file]
+		roleName, err := aws.GetAccountRoleName(cluster, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		if roleName == "" {
+			continue
+		}
+		rolePath, err := aws.GetPathFromAccountRole(cluster, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		policyARN, err := getAttachedPolicyARN(awsClient, roleName,
+			aws.GetPolicyARN(partition, accountID, roleName, rolePath))
+		if err != nil {
+			return nil, err
+		}
+		policyDetails := aws.GetPolicyDetails(policies, fmt.Sprintf("sts_%s_permission_policy", file))
+		diff, err := roles.GetPolicyDiff(awsClient, roleName, policyARN, policyDetails)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// getOperatorRolePolicyDiffs compares the current documents of the operator role policies of the
+// cluster with the ones that the upgrade applies
+func getOperatorRolePolicyDiffs(
+	awsClient aws.Client,
+	partition string,
+	accountID string,
+	policies map[string]*v1.AWSSTSPolicy,
+	credRequests map[string]*v1.STSOperator,
+	cluster *v1.Cluster,
+	operatorRolePolicyPrefix string,
+) ([]*aws.PolicyDiff, error) {
+	operatorRoles := cluster.AWS().STS().OperatorIAMRoles()
+	isSharedVpc := cluster.AWS().PrivateHostedZoneRoleARN() != ""
+	generalPath, err := aws.GetPathFromARN(operatorRoles[0].RoleARN())
+	if err != nil {
+		return nil, err
+	}
+	keys := helper.MapKeys(credRequests)
+	sort.Strings(keys)
+	diffs := []*aws.PolicyDiff{}
+	for _, credrequest := range keys {
+		operator := credRequests[credrequest]
+		name := fmt.Sprintf("%s-%s-%s", operatorRolePolicyPrefix, operator.Namespace(), operator.Name())
+		policyARN := aws.GetOperatorPolicyARN(
+			partition,
+			accountID,
+			operatorRolePolicyPrefix,
+			operator.Namespace(),
+			operator.Name(),
+			generalPath,
+		)
+		operatorRoleARN := aws.FindOperatorRoleBySTSOperator(operatorRoles, operator)
+		if operatorRoleARN != "" {
+			operatorRoleName, err := aws.GetResourceIdFromARN(operatorRoleARN)
+			if err != nil {
+				return nil, err
+			}
+			name = operatorRoleName
+			policyARN, err = getAttachedPolicyARN(awsClient, operatorRoleName, policyARN)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		filename := aws.GetOperatorPolicyKey(credrequest, cluster.Hypershift().Enabled(), isSharedVpc)
+		policyDetails := aws.GetPolicyDetails(policies, filename)
+		if isSharedVpc {
+			policyDetails = aws.InterpolatePolicyDocument(partition, policyDetails, map[string]string{
+				"shared_vpc_role_arn": cluster.AWS().PrivateHostedZoneRoleARN(),
+			})
+		}
+		diff, err := roles.GetPolicyDiff(awsClient, name, policyARN, policyDetails)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// getAttachedPolicyARN returns the ARN of the policy that the upgrade will modify, which is the
+// one attached to the role when there is a single one, without asking which one to use
+func getAttachedPolicyARN(awsClient aws.Client, roleName string, generatedPolicyARN string) (string, error) {
+	policiesDetails, err := awsClient.GetAttachedPolicy(&roleName)
+	if err != nil {
+		return "", err
+	}
+	attachedPoliciesDetails := aws.FindAllAttachedPolicyDetails(policiesDetails)
+	if len(attachedPoliciesDetails) == 1 {
+		return attachedPoliciesDetails[0].PolicyArn, nil
+	}
+	return generatedPolicyARN, nil
+}
+
 func buildAccountRoleCommandsFromCluster(
 	mode string,
 	cluster *v1.Cluster,
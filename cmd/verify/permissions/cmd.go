@@ -19,25 +19,45 @@ package permissions
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	prefix   string
+	hostedCP bool
+}
+
 var Cmd = &cobra.Command{
 	Use:     "permissions",
 	Aliases: []string{"scp"},
-	Short:   "Verify AWS permissions are ok for non-STS cluster install",
-	Long:    "Verify AWS permissions needed to create a non-STS cluster are configured as expected",
+	Short:   "Verify AWS permissions are ok for cluster install",
+	Long: "Verify AWS permissions needed to create a non-STS cluster are configured as expected. " +
+		"With '--cluster' or '--prefix' the IAM policy simulator is run instead for each account and " +
+		"operator role against the actions of its policy, taking into account permissions boundaries " +
+		"and service control policies, and the denied actions are listed. Actions with wildcards or " +
+		"that depend on conditions the simulator can't evaluate are listed as not verified.",
 	Example: `  # Verify AWS permissions are configured correctly
   rosa verify permissions
 
   # Verify AWS permissions in a different region
-  rosa verify permissions --region=us-west-2`,
+  rosa verify permissions --region=us-west-2
+
+  # Verify that the account and operator roles of cluster "mycluster" are allowed every action they need
+  rosa verify permissions --cluster mycluster
+
+  # Verify the account roles with prefix "ManagedOpenShift" before creating a cluster
+  rosa verify permissions --prefix ManagedOpenShift`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -45,11 +65,31 @@ var Cmd = &cobra.Command{
 func init() {
 	flags := Cmd.Flags()
 
+	ocm.AddOptionalClusterFlag(Cmd)
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		"",
+		"Prefix of the account roles to verify.",
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Verify the account roles used by hosted control plane clusters. Only used with '--prefix'.",
+	)
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 }
 
-func run(_ *cobra.Command, _ []string) {
+// roleTarget is a role whose permissions are simulated against the actions of its policies
+type roleTarget struct {
+	Description string
+	ARN         string
+	PolicyKeys  []string
+}
+
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
@@ -74,6 +114,16 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	isClusterSet := cmd.Flags().Changed("cluster")
+	if isClusterSet && args.prefix != "" {
+		r.Reporter.Errorf("Options '--cluster' and '--prefix' are mutually exclusive")
+		os.Exit(1)
+	}
+	if isClusterSet || args.prefix != "" {
+		verifyRoles(r, isClusterSet)
+		return
+	}
+
 	r.Reporter.Infof("Verifying permissions for non-STS clusters")
 	r.Reporter.Infof("Validating SCP policies...")
 	policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
@@ -99,3 +149,219 @@ func run(_ *cobra.Command, _ []string) {
 	}
 	r.Reporter.Infof("AWS SCP policies ok")
 }
+
+// verifyRoles simulates the permissions of the roles of a cluster, or of the account roles with a
+// prefix, and lists the actions that each of them is denied
+func verifyRoles(r *rosa.Runtime, isClusterSet bool) {
+	var err error
+	r.Creator, err = r.AWSClient.GetCreator()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get AWS creator: %v", err)
+		os.Exit(1)
+	}
+
+	policies, err := r.OCMClient.GetPolicies("")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the policies of the roles: %v", err)
+		os.Exit(1)
+	}
+
+	var targets []roleTarget
+	if isClusterSet {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			r.Reporter.Errorf("Cluster '%s' doesn't use STS, run without '--cluster' to verify the "+
+				"permissions of non-STS clusters", r.ClusterKey)
+			os.Exit(1)
+		}
+		credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the operator roles of cluster '%s': %v", r.ClusterKey, err)
+			os.Exit(1)
+		}
+		targets = clusterRoleTargets(cluster, credRequests)
+	} else {
+		targets, err = accountRoleTargets(r.AWSClient, args.prefix, args.hostedCP)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	failed := false
+	for _, target := range targets {
+		actions, unverified, err := targetActions(r.AWSClient, policies, target.PolicyKeys)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the policy of role '%s' (%s): %v", target.ARN, target.Description, err)
+			os.Exit(1)
+		}
+		if len(actions) == 0 && len(unverified) == 0 {
+			r.Reporter.Warnf("No policy found for role '%s' (%s), skipping it", target.ARN, target.Description)
+			continue
+		}
+		denied := []aws.SimulatedAction{}
+		verified := len(actions)
+		if len(actions) > 0 {
+			r.Reporter.Debugf("Simulating %d actions for role '%s' (%s)", len(actions), target.ARN,
+				target.Description)
+			var conditional []aws.SimulatedAction
+			denied, conditional, err = r.AWSClient.SimulateRolePermissions(target.ARN, actions)
+			if err != nil {
+				r.Reporter.Errorf("%v", err)
+				os.Exit(1)
+			}
+			unverified = append(unverified, conditional...)
+			verified -= len(conditional)
+		}
+		if len(unverified) > 0 {
+			r.Reporter.Warnf("Role '%s' (%s) has %d actions that were not verified:\n  %s", target.ARN,
+				target.Description, len(unverified), formatActions(unverified))
+		}
+		if len(denied) == 0 {
+			r.Reporter.Infof("Role '%s' (%s) is allowed all of its %d verified actions", target.ARN,
+				target.Description, verified)
+			continue
+		}
+		failed = true
+		r.Reporter.Errorf("Role '%s' (%s) is denied %d of its %d verified actions:\n  %s", target.ARN,
+			target.Description, len(denied), verified, formatActions(denied))
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// formatActions returns a line for each of the given actions, with the reason why it was denied or
+// not verified
+func formatActions(actions []aws.SimulatedAction) string {
+	lines := make([]string, len(actions))
+	for i, action := range actions {
+		lines[i] = fmt.Sprintf("%s: %s", action.Action, action.Reason)
+	}
+	return strings.Join(lines, "\n  ")
+}
+
+// clusterRoleTargets returns the account and operator roles of an STS cluster, with the keys of
+// the OCM policies that define the actions each of them needs
+func clusterRoleTargets(cluster *cmv1.Cluster, credRequests map[string]*cmv1.STSOperator) []roleTarget {
+	hostedCP := cluster.Hypershift().Enabled()
+	sts := cluster.AWS().STS()
+	accountRoleARNs := map[string]string{
+		aws.InstallerAccountRole:    sts.RoleARN(),
+		aws.SupportAccountRole:      sts.SupportRoleARN(),
+		aws.ControlPlaneAccountRole: sts.InstanceIAMRoles().MasterRoleARN(),
+		aws.WorkerAccountRole:       sts.InstanceIAMRoles().WorkerRoleARN(),
+	}
+	roleTypes := helper.MapKeys(accountRoleARNs)
+	sort.Strings(roleTypes)
+	targets := []roleTarget{}
+	for _, roleType := range roleTypes {
+		if accountRoleARNs[roleType] == "" {
+			continue
+		}
+		targets = append(targets, roleTarget{
+			Description: accountRoleDescription(roleType, hostedCP),
+			ARN:         accountRoleARNs[roleType],
+			PolicyKeys:  []string{accountRolePolicyKey(roleType, hostedCP)},
+		})
+	}
+
+	isSharedVPC := cluster.AWS().PrivateHostedZoneRoleARN() != ""
+	operatorTargets := []roleTarget{}
+	for _, operatorRole := range sts.OperatorIAMRoles() {
+		for key, operator := range credRequests {
+			if operator.Namespace() != operatorRole.Namespace() || operator.Name() != operatorRole.Name() {
+				continue
+			}
+			operatorTargets = append(operatorTargets, roleTarget{
+				Description: fmt.Sprintf("%s/%s operator role", operator.Namespace(), operator.Name()),
+				ARN:         operatorRole.RoleARN(),
+				PolicyKeys:  []string{aws.GetOperatorPolicyKey(key, hostedCP, isSharedVPC)},
+			})
+		}
+	}
+	sort.Slice(operatorTargets, func(i, j int) bool {
+		return operatorTargets[i].Description < operatorTargets[j].Description
+	})
+	return append(targets, operatorTargets...)
+}
+
+// accountRoleTargets returns the account roles with the given prefix. They have to exist, as
+// the policy simulator can only evaluate existing roles.
+func accountRoleTargets(awsClient aws.Client, prefix string, hostedCP bool) ([]roleTarget, error) {
+	accountRoles := aws.AccountRoles
+	if hostedCP {
+		accountRoles = aws.HCPAccountRoles
+	}
+	roleTypes := helper.MapKeys(accountRoles)
+	sort.Strings(roleTypes)
+	targets := []roleTarget{}
+	for _, roleType := range roleTypes {
+		roleName := common.GetRoleName(prefix, accountRoles[roleType].Name)
+		exists, roleARN, err := awsClient.CheckRoleExists(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get account role '%s': %v", roleName, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("Account role '%s' doesn't exist", roleName)
+		}
+		targets = append(targets, roleTarget{
+			Description: accountRoleDescription(roleType, hostedCP),
+			ARN:         roleARN,
+			PolicyKeys:  []string{accountRolePolicyKey(roleType, hostedCP)},
+		})
+	}
+	return targets, nil
+}
+
+// accountRoleDescription returns the description of the account role of the given type, using the
+// names of the hosted control plane roles if needed
+func accountRoleDescription(roleType string, hostedCP bool) string {
+	if hostedCP {
+		return fmt.Sprintf("%s account role", aws.HCPAccountRoles[roleType].Name)
+	}
+	return fmt.Sprintf("%s account role", aws.AccountRoles[roleType].Name)
+}
+
+func accountRolePolicyKey(roleType string, hostedCP bool) string {
+	if hostedCP {
+		return fmt.Sprintf("sts_hcp_%s_permission_policy", roleType)
+	}
+	return fmt.Sprintf("sts_%s_permission_policy", roleType)
+}
+
+// targetActions returns the actions allowed by the given OCM policies, and the ones that can't be
+// verified. Policies managed by AWS don't include their document, so it is read from IAM.
+func targetActions(awsClient aws.Client, policies map[string]*cmv1.AWSSTSPolicy,
+	policyKeys []string) ([]string, []aws.SimulatedAction, error) {
+	actions := map[string]bool{}
+	unverified := []aws.SimulatedAction{}
+	for _, key := range policyKeys {
+		policy, ok := policies[key]
+		if !ok {
+			continue
+		}
+		document := policy.Details()
+		if document == "" && policy.ARN() != "" {
+			var err error
+			document, err = awsClient.GetDefaultPolicyDocument(policy.ARN())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if document == "" {
+			continue
+		}
+		policyActions, policyUnverified, err := aws.PolicyActions(document)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, action := range policyActions {
+			actions[action] = true
+		}
+		unverified = append(unverified, policyUnverified...)
+	}
+	result := helper.MapKeys(actions)
+	sort.Strings(result)
+	return result, unverified, nil
+}
@@ -0,0 +1,88 @@
+package permissions
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Verify permissions", func() {
+	Context("clusterRoleTargets", func() {
+		It("Returns the account and operator roles of a classic cluster with their policies", func() {
+			cluster, err := cmv1.NewCluster().AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role").
+				SupportRoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role").
+				InstanceIAMRoles(cmv1.NewInstanceIAMRoles().
+					MasterRoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role").
+					WorkerRoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role")).
+				OperatorIAMRoles(cmv1.NewOperatorIAMRole().
+					Namespace("openshift-ingress-operator").
+					Name("cloud-credentials").
+					RoleARN("arn:aws:iam::123456789012:role/mycluster-openshift-ingress-operator-cloud-credentials")),
+			)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			ingress, err := cmv1.NewSTSOperator().Namespace("openshift-ingress-operator").
+				Name("cloud-credentials").Build()
+			Expect(err).NotTo(HaveOccurred())
+			storage, err := cmv1.NewSTSOperator().Namespace("openshift-cluster-csi-drivers").
+				Name("ebs-cloud-credentials").Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			targets := clusterRoleTargets(cluster, map[string]*cmv1.STSOperator{
+				"ingress_operator_cloud_credentials":        ingress,
+				"cluster_csi_drivers_ebs_cloud_credentials": storage,
+			})
+			Expect(targets).To(HaveLen(5))
+			Expect(targets[0].Description).To(Equal("Installer account role"))
+			Expect(targets[0].PolicyKeys).To(Equal([]string{"sts_installer_permission_policy"}))
+			Expect(targets[1].Description).To(Equal("ControlPlane account role"))
+			Expect(targets[1].PolicyKeys).To(Equal([]string{"sts_instance_controlplane_permission_policy"}))
+			Expect(targets[2].Description).To(Equal("Worker account role"))
+			Expect(targets[3].Description).To(Equal("Support account role"))
+			Expect(targets[4].Description).To(Equal("openshift-ingress-operator/cloud-credentials operator role"))
+			Expect(targets[4].PolicyKeys).To(Equal([]string{"openshift_ingress_operator_cloud_credentials_policy"}))
+		})
+
+		It("Uses the hosted control plane policies and skips missing roles", func() {
+			cluster, err := cmv1.NewCluster().
+				Hypershift(cmv1.NewHypershift().Enabled(true)).
+				AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+					RoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role"))).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			targets := clusterRoleTargets(cluster, map[string]*cmv1.STSOperator{})
+			Expect(targets).To(HaveLen(1))
+			Expect(targets[0].Description).To(Equal("HCP-ROSA-Installer account role"))
+			Expect(targets[0].PolicyKeys).To(Equal([]string{"sts_hcp_installer_permission_policy"}))
+		})
+	})
+
+	Context("targetActions", func() {
+		It("Reads the document of AWS managed policies from IAM", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			awsClient := aws.NewMockClient(mockCtrl)
+			managedARN := "arn:aws:iam::aws:policy/service-role/ROSAInstallerPolicy"
+			awsClient.EXPECT().GetDefaultPolicyDocument(managedARN).Return(
+				`{"Statement": [{"Effect": "Allow", "Action": ["ec2:RunInstances", "s3:Get*"], "Resource": "*"}]}`, nil)
+			managed, err := cmv1.NewAWSSTSPolicy().ARN(managedARN).Build()
+			Expect(err).NotTo(HaveOccurred())
+			inline, err := cmv1.NewAWSSTSPolicy().
+				Details(`{"Statement": [{"Effect": "Allow", "Action": "ec2:CreateTags", "Resource": "*"}]}`).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			actions, unverified, err := targetActions(awsClient, map[string]*cmv1.AWSSTSPolicy{
+				"managed": managed,
+				"inline":  inline,
+			}, []string{"managed", "inline", "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(Equal([]string{"ec2:CreateTags", "ec2:RunInstances"}))
+			Expect(unverified).To(Equal([]aws.SimulatedAction{{Action: "s3:Get*", Reason: "contains a wildcard"}}))
+		})
+	})
+})
@@ -18,16 +18,47 @@ package quota
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+const defaultComputeMachineType = "m5.xlarge"
+
+// topologyFlags are the flags that describe a planned cluster. When none of them is used the
+// command verifies the generic quotas needed by any cluster. Requesting increases also plans a
+// cluster, with the default topology, because the generic check doesn't compute the missing quota.
+var topologyFlags = []string{
+	"compute-machine-type",
+	"replicas",
+	"max-replicas",
+	"multi-az",
+	"hosted-cp",
+	"existing-vpc",
+	"machine-pool",
+	"request-increase",
+}
+
+var args struct {
+	computeMachineType string
+	replicas           int
+	maxReplicas        int
+	multiAZ            bool
+	hostedCP           bool
+	existingVPC        bool
+	machinePools       []string
+	requestIncrease    bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "quota",
 	Short: "Verify AWS quota is ok for cluster install",
@@ -36,7 +67,13 @@ var Cmd = &cobra.Command{
   rosa verify quota
 
   # Verify AWS quotas in a different region
-  rosa verify quota --region=us-west-2`,
+  rosa verify quota --region=us-west-2
+
+  # Verify AWS quotas for a multi-AZ cluster that autoscales up to 12 compute nodes
+  rosa verify quota --multi-az --compute-machine-type m5.2xlarge --max-replicas 12
+
+  # Verify AWS quotas for a cluster with an additional GPU machine pool and request the missing quota
+  rosa verify quota --replicas 3 --machine-pool g4dn.xlarge:2 --request-increase`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -44,11 +81,63 @@ var Cmd = &cobra.Command{
 func init() {
 	flags := Cmd.Flags()
 
+	flags.StringVar(
+		&args.computeMachineType,
+		"compute-machine-type",
+		defaultComputeMachineType,
+		"Instance type for the compute nodes of the planned cluster.",
+	)
+	flags.IntVar(
+		&args.replicas,
+		"replicas",
+		0,
+		"Number of compute nodes of the planned cluster. Defaults to 2 for single-AZ clusters "+
+			"and 3 for multi-AZ clusters.",
+	)
+	flags.IntVar(
+		&args.maxReplicas,
+		"max-replicas",
+		0,
+		"Maximum number of compute nodes of the planned cluster when autoscaling is enabled.",
+	)
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"The planned cluster is deployed to multiple availability zones.",
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"The planned cluster uses Hosted Control Planes, so there are no control plane nodes in the account.",
+	)
+	flags.BoolVar(
+		&args.existingVPC,
+		"existing-vpc",
+		false,
+		"The planned cluster is installed into existing subnets, so no NAT gateways or elastic IPs are created.",
+	)
+	flags.StringArrayVar(
+		&args.machinePools,
+		"machine-pool",
+		nil,
+		"Additional machine pool of the planned cluster, in the format '<instance type>:<nodes>'. "+
+			"Can be used multiple times.",
+	)
+	flags.BoolVar(
+		&args.requestIncrease,
+		"request-increase",
+		false,
+		"Open Service Quotas increase requests for the quotas that are insufficient for the planned cluster.",
+	)
+
 	arguments.AddRegionFlag(flags)
 	arguments.AddProfileFlag(flags)
+	confirm.AddFlag(flags)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
@@ -73,6 +162,16 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if hasTopology(cmd) {
+		topology, err := buildTopology()
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		verifyCapacity(r, topology)
+		return
+	}
+
 	if r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Validating AWS quota...")
 	}
@@ -89,3 +188,127 @@ func run(_ *cobra.Command, _ []string) {
 			"https://docs.openshift.com/rosa/rosa_getting_started/rosa-required-aws-service-quotas.html")
 	}
 }
+
+func hasTopology(cmd *cobra.Command) bool {
+	for _, flag := range topologyFlags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildTopology converts the flags to the description of the planned cluster
+func buildTopology() (*aws.ClusterTopology, error) {
+	nodes := args.replicas
+	if nodes < 0 || args.maxReplicas < 0 {
+		return nil, fmt.Errorf("The number of replicas can't be negative")
+	}
+	if nodes == 0 {
+		nodes = 2
+		if args.multiAZ {
+			nodes = 3
+		}
+	}
+	if args.maxReplicas > 0 {
+		if args.maxReplicas < nodes && args.replicas > 0 {
+			return nil, fmt.Errorf("Max replicas must not be less than replicas")
+		}
+		nodes = args.maxReplicas
+	}
+	machinePools, err := parseMachinePools(args.machinePools)
+	if err != nil {
+		return nil, err
+	}
+	return &aws.ClusterTopology{
+		ComputeInstanceType: args.computeMachineType,
+		ComputeNodes:        nodes,
+		MultiAZ:             args.multiAZ,
+		HostedCP:            args.hostedCP,
+		ExistingVPC:         args.existingVPC || args.hostedCP,
+		MachinePools:        machinePools,
+	}, nil
+}
+
+func parseMachinePools(values []string) ([]aws.MachinePoolTopology, error) {
+	pools := []aws.MachinePoolTopology{}
+	for _, value := range values {
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Expected a machine pool in the format '<instance type>:<nodes>', got '%s'", value)
+		}
+		nodes, err := strconv.Atoi(parts[1])
+		if err != nil || nodes < 0 {
+			return nil, fmt.Errorf("Expected a valid number of nodes for machine pool '%s'", value)
+		}
+		pools = append(pools, aws.MachinePoolTopology{
+			InstanceType: parts[0],
+			Nodes:        nodes,
+		})
+	}
+	return pools, nil
+}
+
+func verifyCapacity(r *rosa.Runtime, topology *aws.ClusterTopology) {
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Validating AWS quota for the planned cluster...")
+	}
+	checks, err := r.AWSClient.VerifyCapacity(topology)
+	if err != nil {
+		r.Reporter.Errorf("Failed to verify AWS quota: %v", err)
+		os.Exit(1)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SERVICE\tQUOTA CODE\tQUOTA\tREQUIRED\tIN USE\tLIMIT\tSTATUS\n")
+	insufficient := []aws.CapacityCheck{}
+	for _, check := range checks {
+		status := "ok"
+		if !check.Sufficient() {
+			status = "insufficient"
+			insufficient = append(insufficient, check)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			check.ServiceCode, check.QuotaCode, check.QuotaName,
+			formatQuantity(check.Required), formatQuantity(check.Usage), formatQuantity(check.Quota), status)
+	}
+	writer.Flush()
+
+	if len(insufficient) == 0 {
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("AWS quota ok for the planned cluster")
+		}
+		return
+	}
+	r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
+
+	if !args.requestIncrease {
+		r.Reporter.Errorf("Insufficient AWS quotas for the planned cluster. " +
+			"Use '--request-increase' to request the missing quota")
+		os.Exit(1)
+	}
+	failed := false
+	for _, check := range insufficient {
+		if !confirm.Prompt(true, "Request an increase of quota '%s' to %s?",
+			check.QuotaName, formatQuantity(check.DesiredQuota())) {
+			continue
+		}
+		id, err := r.AWSClient.RequestQuotaIncrease(check.ServiceCode, check.QuotaCode, check.DesiredQuota())
+		if err != nil {
+			r.Reporter.Errorf("Failed to request an increase of quota '%s': %v", check.QuotaName, err)
+			failed = true
+			continue
+		}
+		r.Reporter.Infof("Requested an increase of quota '%s' to %s with request ID '%s'",
+			check.QuotaName, formatQuantity(check.DesiredQuota()), id)
+	}
+	if failed {
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Quota increase requests can take some time to be approved. " +
+		"Run this command again to check that the quota is sufficient before creating the cluster")
+}
+
+func formatQuantity(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,88 @@
+package quota
+
+import (
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Verify quota", func() {
+	BeforeEach(func() {
+		args.computeMachineType = defaultComputeMachineType
+		args.replicas = 0
+		args.maxReplicas = 0
+		args.multiAZ = false
+		args.hostedCP = false
+		args.existingVPC = false
+		args.machinePools = nil
+	})
+
+	DescribeTable("Selects the planned cluster mode",
+		func(changed []string, expected bool) {
+			cmd := &cobra.Command{}
+			for _, name := range append(topologyFlags, "region") {
+				cmd.Flags().String(name, "", "")
+			}
+			for _, name := range changed {
+				Expect(cmd.Flags().Set(name, "true")).To(Succeed())
+			}
+			Expect(hasTopology(cmd)).To(Equal(expected))
+		},
+		Entry("without flags", []string{}, false),
+		Entry("with the region only", []string{"region"}, false),
+		Entry("with replicas", []string{"replicas"}, true),
+		Entry("when requesting increases", []string{"request-increase"}, true),
+	)
+
+	It("Defaults to three compute nodes for multi-AZ clusters", func() {
+		args.multiAZ = true
+		topology, err := buildTopology()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(topology.ComputeNodes).To(Equal(3))
+	})
+
+	It("Uses the autoscaling maximum as the number of compute nodes", func() {
+		args.replicas = 2
+		args.maxReplicas = 10
+		topology, err := buildTopology()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(topology.ComputeNodes).To(Equal(10))
+	})
+
+	It("Fails when the maximum is lower than the replicas", func() {
+		args.replicas = 6
+		args.maxReplicas = 4
+		_, err := buildTopology()
+		Expect(err).To(MatchError("Max replicas must not be less than replicas"))
+	})
+
+	It("Treats hosted clusters as using an existing VPC", func() {
+		args.hostedCP = true
+		topology, err := buildTopology()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(topology.ExistingVPC).To(BeTrue())
+	})
+
+	It("Parses additional machine pools", func() {
+		pools, err := parseMachinePools([]string{"g4dn.xlarge:2", "r5.2xlarge:0"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pools).To(Equal([]aws.MachinePoolTopology{
+			{InstanceType: "g4dn.xlarge", Nodes: 2},
+			{InstanceType: "r5.2xlarge", Nodes: 0},
+		}))
+	})
+
+	DescribeTable("Fails with invalid machine pools",
+		func(value string) {
+			_, err := parseMachinePools([]string{value})
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing nodes", "g4dn.xlarge"),
+		Entry("missing type", ":2"),
+		Entry("negative nodes", "g4dn.xlarge:-1"),
+		Entry("non numeric nodes", "g4dn.xlarge:two"),
+	)
+})
@@ -75,7 +75,7 @@ func run(_ *cobra.Command, _ []string) {
 
 func retrievePossibleVersionsFromMirror() ([]string, error) {
 	logger := logging.NewLogger()
-	transport := http.DefaultTransport
+	transport := logging.TraceTransport(http.DefaultTransport)
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
 		dumper, err := logging.NewRoundTripper().Logger(logger).Next(transport).Build()
 		if err != nil {
@@ -25,6 +25,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift/rosa/pkg/aws/assumerole"
+	"github.com/openshift/rosa/pkg/aws/endpoints"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/debug"
@@ -105,6 +107,28 @@ func ParseUnknownFlags(cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// SetUnknownFlags registers the given values as unknown flags of the command, the same way
+// ParseUnknownFlags does for the command line. Values already given on the command line win.
+func SetUnknownFlags(cmd *cobra.Command, values map[string]string) error {
+	flags := cmd.Flags()
+	for name, value := range values {
+		if flags.Lookup(name) != nil {
+			if flags.Changed(name) {
+				continue
+			}
+		} else {
+			var strVal string
+			flags.StringVar(&strVal, name, "", "")
+		}
+		err := flags.Set(name, value)
+		if err != nil {
+			return err
+		}
+		hasUnknownFlags = true
+	}
+	return nil
+}
+
 // Parse known flags will take the command line arguments and map the ones that fit with known flags.
 func ParseKnownFlags(cmd *cobra.Command, argv []string, failOnUnknown bool) error {
 	flags := cmd.Flags()
@@ -296,6 +320,18 @@ func GetProfile() string {
 	return profile.Profile()
 }
 
+// AddAssumeRoleFlags adds the flags used to assume an AWS role on top of the credentials of the
+// default chain or the profile to the given set of command line flags.
+func AddAssumeRoleFlags(fs *pflag.FlagSet) {
+	assumerole.AddFlags(fs)
+}
+
+// AddAWSEndpointFlags adds the flags used to override the endpoints of the AWS services to the
+// given set of command line flags.
+func AddAWSEndpointFlags(fs *pflag.FlagSet) {
+	endpoints.AddFlags(fs)
+}
+
 // AddRegionFlag adds the '--region' flag to the given set of command line flags.
 func AddRegionFlag(fs *pflag.FlagSet) {
 	region.AddFlag(fs)
@@ -52,4 +52,21 @@ var _ = Describe("Client", func() {
 			Expect(fmt.Sprint(err)).To(Equal("No value given for flag '-c'"))
 		})
 	})
+
+	Context("Test SetUnknownFlags func", func() {
+		BeforeEach(func() {
+			cmd = &cobra.Command{Use: "test"}
+			cmd.Flags().BoolP("help", "h", false, "")
+		})
+		It("Registers values as unknown flags without overriding the command line", func() {
+			err := ParseUnknownFlags(cmd, []string{"--param-a", "from-cli"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = SetUnknownFlags(cmd, map[string]string{"param-a": "from-file", "param-b": "from-file"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(HasUnknownFlags()).To(BeTrue())
+			Expect(cmd.Flags().Lookup("param-a").Value.String()).To(Equal("from-cli"))
+			Expect(cmd.Flags().Lookup("param-b").Value.String()).To(Equal("from-file"))
+		})
+	})
 })
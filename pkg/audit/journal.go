@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to write and read the local journal of the
+// mutating commands executed with rosa. Recording is disabled by default, it is enabled with the
+// 'rosa config set audit true' command and disabled again with 'rosa config set audit false'.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocationEnvKey is the environment variable that overrides the location of the journal file
+const LocationEnvKey = "ROSA_AUDIT_JOURNAL"
+
+// WebhookEnvKey is the environment variable that, when set, overrides the 'audit_webhook'
+// configuration setting that makes rosa also send every journal entry to the given URL
+const WebhookEnvKey = "ROSA_AUDIT_WEBHOOK"
+
+const (
+	ResultStarted    = "started"
+	ResultSucceeded  = "succeeded"
+	ResultFailed     = "failed"
+	ResultIncomplete = "incomplete"
+)
+
+// Record is a single entry of the journal. Every mutating command writes an entry with the
+// 'started' result when it begins and another one with the same ID when it finishes.
+type Record struct {
+	ID           string    `json:"id"`
+	Timestamp    time.Time `json:"timestamp"`
+	Command      string    `json:"command"`
+	Args         []string  `json:"args"`
+	Cluster      string    `json:"cluster,omitempty"`
+	ClusterID    string    `json:"cluster_id,omitempty"`
+	ARN          string    `json:"arn,omitempty"`
+	AWSAccountID string    `json:"aws_account_id,omitempty"`
+	OCMAccount   string    `json:"ocm_account,omitempty"`
+	Result       string    `json:"result"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// Matches returns true if the entry refers to the cluster with the given name or ID
+func (r *Record) Matches(clusterKey string) bool {
+	return r.Cluster == clusterKey || r.ClusterID == clusterKey
+}
+
+// Sink receives the entries of the journal. The local journal file is always used, additional
+// sinks can be registered with the AddSink function.
+type Sink interface {
+	Write(entry *Record) error
+}
+
+// Location returns the location of the journal file. It can be overridden with the
+// ROSA_AUDIT_JOURNAL environment variable.
+func Location() (string, error) {
+	if path := os.Getenv(LocationEnvKey); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "rosa", "audit.jsonl"), nil
+}
+
+// FileSink appends the entries, one JSON document per line, to a local file
+type FileSink struct {
+	Path string
+}
+
+var _ Sink = &FileSink{}
+
+func (s *FileSink) Write(entry *Record) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("can't marshal journal entry: %v", err)
+	}
+	dir := filepath.Dir(s.Path)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("Failed to create directory %s: %v", dir, err)
+	}
+	// #nosec G304
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to open journal file '%s': %v", s.Path, err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("Failed to write journal file '%s': %v", s.Path, err)
+	}
+	return nil
+}
+
+// WebhookSink sends each entry as a JSON document in the body of a POST request to the given URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+var _ Sink = &WebhookSink{}
+
+func (s *WebhookSink) Write(entry *Record) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("can't marshal journal entry: %v", err)
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	response, err := client.Post(s.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("Failed to send journal entry to '%s': %v", s.URL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("Failed to send journal entry to '%s': %s", s.URL, response.Status)
+	}
+	return nil
+}
+
+// Read returns the entries of the journal file, merging the start and end records of each
+// command. Commands that never recorded their end, for example because the process exited early,
+// are reported with the 'incomplete' result. If the file doesn't exist it returns no entries.
+func Read(path string) ([]*Record, error) {
+	// #nosec G304
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read journal file '%s': %v", path, err)
+	}
+	defer file.Close()
+
+	var entries []*Record
+	index := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		entry := &Record{}
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse line %d of journal file '%s': %v", line, path, err)
+		}
+		if i, ok := index[entry.ID]; ok && entry.ID != "" {
+			// Keep the start time of the command, but everything else from the final record
+			entry.Timestamp = entries[i].Timestamp
+			entries[i] = entry
+			continue
+		}
+		index[entry.ID] = len(entries)
+		entries = append(entries, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read journal file '%s': %v", path, err)
+	}
+	for _, entry := range entries {
+		if entry.Result == ResultStarted {
+			entry.Result = ResultIncomplete
+		}
+	}
+	return entries, nil
+}
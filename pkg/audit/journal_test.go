@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Audit journal", func() {
+	var path string
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		path = filepath.Join(dir, "audit.jsonl")
+		GinkgoT().Setenv(LocationEnvKey, path)
+		GinkgoT().Setenv("OCM_CONFIG", filepath.Join(dir, "ocm.json"))
+		GinkgoT().Setenv(WebhookEnvKey, "")
+		Expect(config.Save(&config.Config{Audit: true})).To(Succeed())
+	})
+
+	buildCommand := func(verb string, name string) *cobra.Command {
+		root := &cobra.Command{Use: "rosa"}
+		parent := &cobra.Command{Use: verb}
+		cmd := &cobra.Command{Use: name}
+		root.AddCommand(parent)
+		parent.AddCommand(cmd)
+		return cmd
+	}
+
+	It("Only records mutating commands", func() {
+		Expect(IsMutating(buildCommand("delete", "cluster"))).To(BeTrue())
+		Expect(IsMutating(buildCommand("describe", "cluster"))).To(BeFalse())
+
+		Begin(buildCommand("list", "clusters"), []string{"list", "clusters"})
+		End(nil)
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("Records nothing unless enabled in the configuration", func() {
+		Expect(config.Save(&config.Config{})).To(Succeed())
+		Begin(buildCommand("delete", "cluster"), []string{"delete", "cluster"})
+		End(nil)
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("Sends the entries to the webhook of the configuration", func() {
+		var received []*Record
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entry := &Record{}
+			Expect(json.NewDecoder(r.Body).Decode(entry)).To(Succeed())
+			received = append(received, entry)
+		}))
+		defer server.Close()
+		Expect(config.Save(&config.Config{Audit: true, AuditWebhook: server.URL})).To(Succeed())
+
+		Begin(buildCommand("delete", "cluster"), []string{"delete", "cluster"})
+		End(nil)
+		Expect(received).To(HaveLen(2))
+		Expect(received[0].Result).To(Equal(ResultStarted))
+		Expect(received[1].Result).To(Equal(ResultSucceeded))
+	})
+
+	It("Records the result, identity and cluster with the secrets redacted", func() {
+		Begin(buildCommand("create", "idp"), []string{"create", "idp", "-c", "mycluster",
+			"--type", "htpasswd", "--users", "admin:secret", "--client-secret=abc",
+			"--bind-password", "def", "--mfa-token", "123456"})
+		SetCreator(&aws.Creator{ARN: "arn:aws:iam::123:user/dev", AccountID: "123"})
+		SetCluster("mycluster", "24vf9iitg3p6tlml88iml6j6mu095mh8")
+		End(nil)
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("admin:secret"))
+		Expect(string(data)).NotTo(ContainSubstring("abc"))
+		Expect(string(data)).NotTo(ContainSubstring("123456"))
+
+		entries, err := Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Command).To(Equal("rosa create idp"))
+		Expect(entries[0].Args).To(Equal([]string{"create", "idp", "-c", "mycluster",
+			"--type", "htpasswd", "--users", "***", "--client-secret=***", "--bind-password", "***",
+			"--mfa-token", "***"}))
+		Expect(entries[0].ARN).To(Equal("arn:aws:iam::123:user/dev"))
+		Expect(entries[0].AWSAccountID).To(Equal("123"))
+		Expect(entries[0].Matches("mycluster")).To(BeTrue())
+		Expect(entries[0].Matches("24vf9iitg3p6tlml88iml6j6mu095mh8")).To(BeTrue())
+		Expect(entries[0].Result).To(Equal(ResultSucceeded))
+	})
+
+	It("Reports failed and incomplete commands", func() {
+		Begin(buildCommand("edit", "cluster"), []string{"edit", "cluster"})
+		End(errors.New("boom"))
+		Begin(buildCommand("upgrade", "cluster"), []string{"upgrade", "cluster"})
+
+		entries, err := Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Result).To(Equal(ResultFailed))
+		Expect(entries[0].Error).To(Equal("boom"))
+		Expect(entries[1].Result).To(Equal(ResultIncomplete))
+	})
+
+	It("Records the failures reported before exiting", func() {
+		Begin(buildCommand("create", "cluster"), []string{"create", "cluster"})
+		SetCreator(&aws.Creator{ARN: "arn:aws:iam::123:user/dev", AccountID: "123"})
+		RecordError("Failed to create cluster")
+
+		entries, err := Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Result).To(Equal(ResultFailed))
+		Expect(entries[0].Error).To(Equal("Failed to create cluster"))
+		Expect(entries[0].ARN).To(Equal("arn:aws:iam::123:user/dev"))
+	})
+
+	It("Keeps the result of commands that succeed after reporting an error", func() {
+		Begin(buildCommand("delete", "cluster"), []string{"delete", "cluster"})
+		RecordError("Failed to delete the operator roles")
+		End(nil)
+
+		entries, err := Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Result).To(Equal(ResultSucceeded))
+	})
+
+	It("Writes the identity of incomplete commands", func() {
+		Begin(buildCommand("upgrade", "cluster"), []string{"upgrade", "cluster"})
+		SetCreator(&aws.Creator{ARN: "arn:aws:iam::123:user/dev", AccountID: "123"})
+
+		entries, err := Read(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Result).To(Equal(ResultIncomplete))
+		Expect(entries[0].AWSAccountID).To(Equal("123"))
+	})
+
+	It("Sends the entries to the additional sinks", func() {
+		sink := &memorySink{}
+		AddSink(sink)
+		DeferCleanup(func() {
+			sinks = nil
+		})
+		Begin(buildCommand("delete", "cluster"), []string{"delete", "cluster"})
+		End(nil)
+		Expect(sink.entries).To(HaveLen(2))
+		Expect(sink.entries[0].Result).To(Equal(ResultStarted))
+		Expect(sink.entries[1].Result).To(Equal(ResultSucceeded))
+	})
+})
+
+type memorySink struct {
+	entries []*Record
+}
+
+func (s *memorySink) Write(entry *Record) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
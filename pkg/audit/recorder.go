@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to record the command being executed, enriching it with
+// the details that are only known once the runtime has been initialized.
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// mutatingCommands are the top level commands whose subcommands change resources
+var mutatingCommands = map[string]bool{
+	"create":      true,
+	"delete":      true,
+	"edit":        true,
+	"grant":       true,
+	"hibernate":   true,
+	"hibernation": true,
+	"init":        true,
+	"install":     true,
+	"link":        true,
+	"prune":       true,
+	"register":    true,
+	"resume":      true,
+	"revoke":      true,
+	"uninstall":   true,
+	"unlink":      true,
+	"upgrade":     true,
+}
+
+var sinks []Sink
+var current *Record
+var webhook string
+
+// AddSink registers an additional sink that will receive the entries written to the journal
+func AddSink(sink Sink) {
+	sinks = append(sinks, sink)
+}
+
+// Enabled returns true if recording has been turned on in the given configuration, with the
+// 'rosa config set audit true' command
+func Enabled(cfg *config.Config) bool {
+	return cfg != nil && cfg.Audit
+}
+
+// IsMutating returns true if the given command changes resources and should be recorded
+func IsMutating(cmd *cobra.Command) bool {
+	path := strings.Fields(cmd.CommandPath())
+	if len(path) < 2 {
+		return false
+	}
+	return mutatingCommands[path[1]]
+}
+
+// Begin starts recording the given command if it is a mutating one. The arguments are the
+// complete command line, and the values of the sensitive flags are redacted before recording it.
+func Begin(cmd *cobra.Command, args []string) {
+	current = nil
+	if !IsMutating(cmd) {
+		return
+	}
+	cfg, err := config.Load()
+	if err != nil || !Enabled(cfg) {
+		return
+	}
+	webhook = cfg.AuditWebhook
+	if url := os.Getenv(WebhookEnvKey); url != "" {
+		webhook = url
+	}
+	current = &Record{
+		ID:        uuid.NewString(),
+		Timestamp: time.Now().UTC(),
+		Command:   cmd.CommandPath(),
+		Args:      logging.RedactArgs(args),
+		Result:    ResultStarted,
+	}
+	if clusterKey, err := ocm.GetClusterKey(); err == nil {
+		current.Cluster = clusterKey
+	}
+	if username, err := cfg.GetData("username"); err == nil {
+		current.OCMAccount = username
+	}
+	write(current)
+}
+
+// SetCreator records the AWS identity used by the command being recorded. The record is written
+// again, as the identity is only known after the command started.
+func SetCreator(creator *aws.Creator) {
+	if current == nil || creator == nil {
+		return
+	}
+	current.ARN = creator.ARN
+	current.AWSAccountID = creator.AccountID
+	entry := *current
+	write(&entry)
+}
+
+// SetCluster records the cluster that the command being recorded acts on. The identifier is
+// optional, as it is only known once the cluster has been fetched.
+func SetCluster(key string, id string) {
+	if current == nil {
+		return
+	}
+	if key != "" {
+		current.Cluster = key
+	}
+	if id != "" {
+		current.ClusterID = id
+	}
+}
+
+// End records the result of the command being recorded
+func End(err error) {
+	if current == nil {
+		return
+	}
+	entry := *current
+	entry.Timestamp = time.Now().UTC()
+	entry.Result = ResultSucceeded
+	if err != nil {
+		entry.Result = ResultFailed
+		entry.Error = err.Error()
+	}
+	write(&entry)
+	current = nil
+}
+
+// RecordError records the failure of the command being recorded. Most commands exit as soon as
+// they report an error, without returning to the code that calls End, so this is called for every
+// reported error. The journal keeps the last record written, so if the command later succeeds the
+// call to End replaces this result.
+func RecordError(message string) {
+	if current == nil {
+		return
+	}
+	entry := *current
+	entry.Timestamp = time.Now().UTC()
+	entry.Result = ResultFailed
+	entry.Error = message
+	write(&entry)
+}
+
+// write sends the entry to the journal and to the additional sinks. Failures never stop the
+// command, they are only reported in debug mode.
+func write(entry *Record) {
+	var targets []Sink
+	if path, err := Location(); err == nil {
+		targets = append(targets, &FileSink{Path: path})
+	}
+	targets = append(targets, sinks...)
+	if webhook != "" {
+		targets = append(targets, &WebhookSink{URL: webhook})
+	}
+	for _, sink := range targets {
+		err := sink.Write(entry)
+		if err != nil && debug.Enabled() {
+			fmt.Fprintf(os.Stderr, "Failed to record command in the audit journal: %v\n", err)
+		}
+	}
+}
@@ -20,6 +20,9 @@ import (
 //
 
 type Ec2ApiClient interface {
+	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options),
+	) (*ec2.CreateTagsOutput, error)
+
 	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options),
 	) (*ec2.DescribeSecurityGroupsOutput, error)
 
@@ -39,6 +42,26 @@ type Ec2ApiClient interface {
 	DescribeInstanceTypeOfferings(ctx context.Context,
 		params *ec2.DescribeInstanceTypeOfferingsInput, optFns ...func(*ec2.Options),
 	) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+
+	DescribeInstanceTypes(ctx context.Context,
+		params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeInstanceTypesOutput, error)
+
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeInstancesOutput, error)
+
+	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeVolumesOutput, error)
+
+	DescribeAddresses(ctx context.Context, params *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeAddressesOutput, error)
+
+	DescribeNatGateways(ctx context.Context, params *ec2.DescribeNatGatewaysInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeNatGatewaysOutput, error)
+
+	DescribeNetworkInterfaces(ctx context.Context,
+		params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeNetworkInterfacesOutput, error)
 }
 
 // interface guard to ensure that all methods defined in the Ec2ApiClient
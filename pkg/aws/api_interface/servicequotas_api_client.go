@@ -27,6 +27,14 @@ type ServiceQuotasApiClient interface {
 	ListServiceQuotas(ctx context.Context,
 		params *servicequotas.ListServiceQuotasInput, optFns ...func(*servicequotas.Options),
 	) (*servicequotas.ListServiceQuotasOutput, error)
+
+	GetAWSDefaultServiceQuota(ctx context.Context,
+		params *servicequotas.GetAWSDefaultServiceQuotaInput, optFns ...func(*servicequotas.Options),
+	) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error)
+
+	RequestServiceQuotaIncrease(ctx context.Context,
+		params *servicequotas.RequestServiceQuotaIncreaseInput, optFns ...func(*servicequotas.Options),
+	) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
 }
 
 var _ ServiceQuotasApiClient = (*servicequotas.Client)(nil)
@@ -0,0 +1,98 @@
+package aws
+
+import (
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/aws/assumerole"
+)
+
+var _ = Describe("Assume role", func() {
+	parseFlags := func(args ...string) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		assumerole.AddFlags(flags)
+		Expect(flags.Parse(args)).To(Succeed())
+	}
+
+	AfterEach(func() {
+		parseFlags(
+			"--assume-role-arn=",
+			"--external-id=",
+			"--role-session-name=",
+			"--mfa-serial=",
+			"--mfa-token=",
+		)
+	})
+
+	Context("withAssumedRole", func() {
+		It("Keeps the credentials when no role is assumed", func() {
+			parseFlags()
+			cfg, err := (&ClientBuilder{logger: logrus.New()}).withAssumedRole(awsSdk.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Credentials).To(BeNil())
+		})
+
+		It("Wraps the credentials with the assumed role", func() {
+			parseFlags("--assume-role-arn", "arn:aws:iam::123456789012:role/admins/OrgAccess",
+				"--external-id", "secret")
+			cfg, err := (&ClientBuilder{logger: logrus.New()}).withAssumedRole(awsSdk.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Credentials).To(BeAssignableToTypeOf(&awsSdk.CredentialsCache{}))
+		})
+
+		It("Fails with an invalid role ARN", func() {
+			parseFlags("--assume-role-arn", "OrgAccess")
+			_, err := (&ClientBuilder{logger: logrus.New()}).withAssumedRole(awsSdk.Config{})
+			Expect(err).To(MatchError(ContainSubstring("Invalid role ARN 'OrgAccess'")))
+		})
+
+		It("Fails when the assume role options are used without a role", func() {
+			parseFlags("--external-id", "secret")
+			_, err := (&ClientBuilder{logger: logrus.New()}).withAssumedRole(awsSdk.Config{})
+			Expect(err).To(MatchError(
+				"'--external-id' can only be used together with '--assume-role-arn'"))
+		})
+
+		It("Accepts the external ID with the alias of the flag", func() {
+			parseFlags("--assume-role-external-id", "secret")
+			Expect(assumerole.ExternalID()).To(Equal("secret"))
+		})
+
+		It("Fails when the MFA token is given without the device", func() {
+			parseFlags("--assume-role-arn", "arn:aws:iam::123456789012:role/OrgAccess", "--mfa-token", "123456")
+			_, err := (&ClientBuilder{logger: logrus.New()}).withAssumedRole(awsSdk.Config{})
+			Expect(err).To(MatchError("'--mfa-token' requires '--mfa-serial'"))
+		})
+	})
+
+	Context("creatorForAssumedRole", func() {
+		var creator *Creator
+
+		BeforeEach(func() {
+			creator = &Creator{
+				ARN:       "arn:aws:iam::123456789012:role/OrgAccess",
+				AccountID: "123456789012",
+				IsSTS:     true,
+				Partition: "aws",
+			}
+		})
+
+		It("Uses the ARN of the assumed role, including its path", func() {
+			creator = creatorForAssumedRole(creator, "arn:aws:iam::123456789012:role/admins/OrgAccess")
+			Expect(creator.ARN).To(Equal("arn:aws:iam::123456789012:role/admins/OrgAccess"))
+		})
+
+		It("Keeps the identity returned by STS when it is a different role", func() {
+			creator = creatorForAssumedRole(creator, "arn:aws:iam::210987654321:role/admins/OrgAccess")
+			Expect(creator.ARN).To(Equal("arn:aws:iam::123456789012:role/OrgAccess"))
+		})
+
+		It("Keeps the identity when no role is assumed", func() {
+			creator = creatorForAssumedRole(creator, "")
+			Expect(creator.ARN).To(Equal("arn:aws:iam::123456789012:role/OrgAccess"))
+		})
+	})
+})
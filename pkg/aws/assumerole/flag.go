@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the command line options that make rosa assume
+// an AWS role before calling AWS, on top of the credentials of the default chain or the profile.
+
+package assumerole
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	RoleARNFlag     = "assume-role-arn"
+	ExternalIDFlag  = "external-id"
+	SessionNameFlag = "role-session-name"
+	MFASerialFlag   = "mfa-serial"
+	MFATokenFlag    = "mfa-token"
+
+	// ExternalIDAliasFlag is the name of the external ID flag for the commands that have their own
+	// '--external-id' flag, which hides the global one
+	ExternalIDAliasFlag = "assume-role-external-id"
+
+	// RoleARNEnvKey is the environment variable used when the '--assume-role-arn' flag isn't given
+	RoleARNEnvKey = "ROSA_ASSUME_ROLE_ARN"
+)
+
+// AddFlags adds the assume role flags to the given set of command line flags. They are meant to
+// be added as persistent flags of the root command, so that all the commands accept them.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&roleARN,
+		RoleARNFlag,
+		"",
+		"ARN of an AWS role to assume with the current credentials before calling AWS. "+
+			"Useful to operate on other accounts from a central identity account.",
+	)
+	flags.StringVar(
+		&externalID,
+		ExternalIDFlag,
+		"",
+		"External ID required by the trust policy of the role given with '--assume-role-arn'.",
+	)
+	flags.StringVar(
+		&externalID,
+		ExternalIDAliasFlag,
+		"",
+		"Same as '--external-id', for the commands that have their own '--external-id' flag.",
+	)
+	flags.StringVar(
+		&sessionName,
+		SessionNameFlag,
+		"",
+		"Session name used when assuming the role given with '--assume-role-arn'. "+
+			"Defaults to a name generated by rosa.",
+	)
+	flags.StringVar(
+		&mfaSerial,
+		MFASerialFlag,
+		"",
+		"Serial number or ARN of the MFA device required to assume the role given with '--assume-role-arn'.",
+	)
+	flags.StringVar(
+		&mfaToken,
+		MFATokenFlag,
+		"",
+		"Current code of the MFA device. If it isn't given it is read from the standard input.",
+	)
+}
+
+// RoleARN returns the ARN of the role to assume, or an empty string when no role is assumed.
+func RoleARN() string {
+	if roleARN != "" {
+		return roleARN
+	}
+	return os.Getenv(RoleARNEnvKey)
+}
+
+// ExternalID returns the external ID used to assume the role.
+func ExternalID() string {
+	return externalID
+}
+
+// SessionName returns the name of the session of the assumed role.
+func SessionName() string {
+	if sessionName != "" {
+		return sessionName
+	}
+	return fmt.Sprintf("rosa-%d", time.Now().Unix())
+}
+
+// MFASerial returns the serial number of the MFA device used to assume the role.
+func MFASerial() string {
+	return mfaSerial
+}
+
+// MFAToken returns the MFA code given in the command line.
+func MFAToken() string {
+	return mfaToken
+}
+
+// Validate checks that the assume role options are only used together with a role to assume.
+func Validate() error {
+	if RoleARN() != "" {
+		if mfaToken != "" && mfaSerial == "" {
+			return fmt.Errorf("'--%s' requires '--%s'", MFATokenFlag, MFASerialFlag)
+		}
+		return nil
+	}
+	for flag, value := range map[string]string{
+		ExternalIDFlag:  externalID,
+		SessionNameFlag: sessionName,
+		MFASerialFlag:   mfaSerial,
+		MFATokenFlag:    mfaToken,
+	} {
+		if value != "" {
+			return fmt.Errorf("'--%s' can only be used together with '--%s'", flag, RoleARNFlag)
+		}
+	}
+	return nil
+}
+
+var (
+	roleARN     string
+	externalID  string
+	sessionName string
+	mfaSerial   string
+	mfaToken    string
+)
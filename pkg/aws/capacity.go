@@ -0,0 +1,460 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to calculate the AWS quota that a planned cluster
+// consumes and to compare it with the current usage and quotas of the account.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+)
+
+const (
+	// Instances created in the account for classic clusters, in addition to the compute nodes
+	controlPlaneInstanceType = "m5.2xlarge"
+	infraInstanceType        = "r5.xlarge"
+	bootstrapInstanceType    = "m5.xlarge"
+	controlPlaneNodes        = 3
+	bootstrapNodes           = 1
+
+	// Size in GiB of the gp3 root volumes of each kind of node
+	controlPlaneVolumeSize = 350
+	infraVolumeSize        = 300
+	computeVolumeSize      = 300
+	bootstrapVolumeSize    = 120
+
+	// Network interfaces of the load balancers created in each availability zone
+	classicLoadBalancerInterfaces = 3
+	hostedLoadBalancerInterfaces  = 1
+)
+
+var (
+	quotaEIPs = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-0263D0A3",
+		QuotaName:   "EC2-VPC Elastic IPs",
+	}
+	quotaNATGateways = quota{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-FE5A380F",
+		QuotaName:   "NAT gateways per Availability Zone",
+	}
+	quotaNetworkInterfaces = quota{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-DF5E4CA3",
+		QuotaName:   "Network interfaces per Region",
+	}
+	quotaGP3Storage = quota{
+		ServiceCode: "ebs",
+		QuotaCode:   "L-7A658B76",
+		QuotaName:   "Storage for General Purpose SSD (gp3) volumes, in TiB",
+	}
+	quotaStandardInstances = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1216C47A",
+		QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+	}
+)
+
+// instanceQuotas are the vCPU quotas of the instance families that are not part of the standard
+// quota. The prefixes are checked in order, so longer ones must come first.
+var instanceQuotas = []struct {
+	Prefix string
+	Quota  quota
+}{
+	{"inf", quota{ServiceCode: "ec2", QuotaCode: "L-1945791B", QuotaName: "Running On-Demand Inf instances"}},
+	{"trn", quota{ServiceCode: "ec2", QuotaCode: "L-2C3B7624", QuotaName: "Running On-Demand Trn instances"}},
+	{"hpc", quota{ServiceCode: "ec2", QuotaCode: "L-F7808C92", QuotaName: "Running On-Demand HPC instances"}},
+	{"dl", quota{ServiceCode: "ec2", QuotaCode: "L-6E869C2A", QuotaName: "Running On-Demand DL instances"}},
+	{"vt", quota{ServiceCode: "ec2", QuotaCode: "L-DB2E81BA", QuotaName: "Running On-Demand G and VT instances"}},
+	{"g", quota{ServiceCode: "ec2", QuotaCode: "L-DB2E81BA", QuotaName: "Running On-Demand G and VT instances"}},
+	{"p", quota{ServiceCode: "ec2", QuotaCode: "L-417A185B", QuotaName: "Running On-Demand P instances"}},
+	{"x", quota{ServiceCode: "ec2", QuotaCode: "L-7295265B", QuotaName: "Running On-Demand X instances"}},
+	{"f", quota{ServiceCode: "ec2", QuotaCode: "L-74FC7D96", QuotaName: "Running On-Demand F instances"}},
+}
+
+// ClusterTopology describes the resources of a planned cluster that consume AWS quota
+type ClusterTopology struct {
+	ComputeInstanceType string
+	// Number of compute nodes, or the maximum number of nodes when autoscaling is enabled
+	ComputeNodes int
+	MultiAZ      bool
+	HostedCP     bool
+	// The cluster uses existing subnets, so no NAT gateways or elastic IPs are created
+	ExistingVPC  bool
+	MachinePools []MachinePoolTopology
+}
+
+// MachinePoolTopology describes an additional machine pool of a planned cluster
+type MachinePoolTopology struct {
+	InstanceType string
+	Nodes        int
+}
+
+// CapacityCheck compares the capacity that a planned cluster requires with the current usage and
+// the quota of the account
+type CapacityCheck struct {
+	ServiceCode string
+	QuotaCode   string
+	QuotaName   string
+	Required    float64
+	Usage       float64
+	Quota       float64
+}
+
+// Sufficient returns true if the quota can accommodate the current usage plus the capacity
+// required by the cluster
+func (c *CapacityCheck) Sufficient() bool {
+	return c.Usage+c.Required <= c.Quota
+}
+
+// DesiredQuota returns the value to request when increasing the quota so that the cluster fits
+func (c *CapacityCheck) DesiredQuota() float64 {
+	return math.Ceil(c.Usage + c.Required)
+}
+
+// InstanceTypes returns the instance types used by the nodes of the cluster
+func (t *ClusterTopology) InstanceTypes() []string {
+	types := map[string]bool{t.ComputeInstanceType: true}
+	if !t.HostedCP {
+		types[controlPlaneInstanceType] = true
+		types[infraInstanceType] = true
+		types[bootstrapInstanceType] = true
+	}
+	for _, pool := range t.MachinePools {
+		types[pool.InstanceType] = true
+	}
+	result := make([]string, 0, len(types))
+	for instanceType := range types {
+		result = append(result, instanceType)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (t *ClusterTopology) availabilityZones() int {
+	if t.MultiAZ {
+		return 3
+	}
+	return 1
+}
+
+// nodes returns the number of nodes of each instance type and their total root volume size
+func (t *ClusterTopology) nodes() (map[string]int, int) {
+	nodes := map[string]int{}
+	storage := 0
+	add := func(instanceType string, count int, volumeSize int) {
+		nodes[instanceType] += count
+		storage += count * volumeSize
+	}
+	if !t.HostedCP {
+		infraNodes := 2
+		if t.MultiAZ {
+			infraNodes = 3
+		}
+		add(controlPlaneInstanceType, controlPlaneNodes, controlPlaneVolumeSize)
+		add(infraInstanceType, infraNodes, infraVolumeSize)
+		add(bootstrapInstanceType, bootstrapNodes, bootstrapVolumeSize)
+	}
+	add(t.ComputeInstanceType, t.ComputeNodes, computeVolumeSize)
+	for _, pool := range t.MachinePools {
+		add(pool.InstanceType, pool.Nodes, computeVolumeSize)
+	}
+	return nodes, storage
+}
+
+// RequiredCapacity calculates the capacity that the cluster requires for each quota, given the
+// number of vCPUs of each of the instance types that it uses
+func RequiredCapacity(topology *ClusterTopology, vcpus map[string]int32) ([]CapacityCheck, error) {
+	nodes, storage := topology.nodes()
+	totalNodes := 0
+	instanceChecks := map[string]*CapacityCheck{}
+	for instanceType, count := range nodes {
+		totalNodes += count
+		cpus, ok := vcpus[instanceType]
+		if !ok {
+			return nil, fmt.Errorf("Unknown number of vCPUs for instance type '%s'", instanceType)
+		}
+		q := instanceQuota(instanceType)
+		check, ok := instanceChecks[q.QuotaCode]
+		if !ok {
+			check = newCapacityCheck(q, 0)
+			instanceChecks[q.QuotaCode] = check
+		}
+		check.Required += float64(int(cpus) * count)
+	}
+
+	checks := []CapacityCheck{}
+	for _, check := range instanceChecks {
+		checks = append(checks, *check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].QuotaCode < checks[j].QuotaCode
+	})
+
+	zones := topology.availabilityZones()
+	natGateways := 0
+	if !topology.ExistingVPC && !topology.HostedCP {
+		natGateways = 1
+	}
+	loadBalancerInterfaces := classicLoadBalancerInterfaces
+	if topology.HostedCP {
+		loadBalancerInterfaces = hostedLoadBalancerInterfaces
+	}
+	checks = append(checks,
+		*newCapacityCheck(quotaGP3Storage, float64(storage)/1024),
+		*newCapacityCheck(quotaEIPs, float64(natGateways*zones)),
+		*newCapacityCheck(quotaNATGateways, float64(natGateways)),
+		*newCapacityCheck(quotaNetworkInterfaces, float64(totalNodes+zones*(natGateways+loadBalancerInterfaces))),
+	)
+	return checks, nil
+}
+
+// instanceQuota returns the vCPU quota that applies to the given instance type
+func instanceQuota(instanceType string) quota {
+	family := strings.ToLower(strings.Split(instanceType, ".")[0])
+	for _, q := range instanceQuotas {
+		if strings.HasPrefix(family, q.Prefix) {
+			return q.Quota
+		}
+	}
+	return quotaStandardInstances
+}
+
+func newCapacityCheck(q quota, required float64) *CapacityCheck {
+	return &CapacityCheck{
+		ServiceCode: q.ServiceCode,
+		QuotaCode:   q.QuotaCode,
+		QuotaName:   q.QuotaName,
+		Required:    required,
+	}
+}
+
+// VerifyCapacity calculates the capacity required by the planned cluster and fills in the current
+// usage and the quota of the account for each of the affected quotas
+func (c *awsClient) VerifyCapacity(topology *ClusterTopology) ([]CapacityCheck, error) {
+	vcpus, err := c.getInstanceTypeVCPUs(topology.InstanceTypes())
+	if err != nil {
+		return nil, err
+	}
+	checks, err := RequiredCapacity(topology, vcpus)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := c.getCapacityUsage()
+	if err != nil {
+		return nil, err
+	}
+	for i := range checks {
+		checks[i].Usage = usage[checks[i].QuotaCode]
+		checks[i].Quota, err = c.getQuotaValue(checks[i].ServiceCode, checks[i].QuotaCode)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get quota '%s': %v", checks[i].QuotaName, err)
+		}
+	}
+	return checks, nil
+}
+
+// RequestQuotaIncrease opens a Service Quotas request to increase the given quota and returns its
+// identifier
+func (c *awsClient) RequestQuotaIncrease(serviceCode string, quotaCode string, value float64) (string, error) {
+	output, err := c.serviceQuotasClient.RequestServiceQuotaIncrease(context.Background(),
+		&servicequotas.RequestServiceQuotaIncreaseInput{
+			ServiceCode:  aws.String(serviceCode),
+			QuotaCode:    aws.String(quotaCode),
+			DesiredValue: aws.Float64(value),
+		})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.RequestedQuota.Id), nil
+}
+
+func (c *awsClient) getInstanceTypeVCPUs(instanceTypes []string) (map[string]int32, error) {
+	types := make([]ec2types.InstanceType, len(instanceTypes))
+	for i, instanceType := range instanceTypes {
+		types[i] = ec2types.InstanceType(instanceType)
+	}
+	output, err := c.ec2Client.DescribeInstanceTypes(context.Background(), &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: types,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe instance types %s: %v", instanceTypes, err)
+	}
+	vcpus := map[string]int32{}
+	for _, instanceType := range output.InstanceTypes {
+		if instanceType.VCpuInfo != nil {
+			vcpus[string(instanceType.InstanceType)] = aws.ToInt32(instanceType.VCpuInfo.DefaultVCpus)
+		}
+	}
+	return vcpus, nil
+}
+
+// getCapacityUsage returns the current usage of the account for each quota code
+func (c *awsClient) getCapacityUsage() (map[string]float64, error) {
+	usage := map[string]float64{}
+
+	instances := ec2.NewDescribeInstancesPaginator(c.ec2Client, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: []string{"pending", "running"},
+		}},
+	})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe instances: %v", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.CpuOptions == nil {
+					continue
+				}
+				cpus := aws.ToInt32(instance.CpuOptions.CoreCount) * aws.ToInt32(instance.CpuOptions.ThreadsPerCore)
+				usage[instanceQuota(string(instance.InstanceType)).QuotaCode] += float64(cpus)
+			}
+		}
+	}
+
+	volumes := ec2.NewDescribeVolumesPaginator(c.ec2Client, &ec2.DescribeVolumesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("volume-type"),
+			Values: []string{string(ec2types.VolumeTypeGp3)},
+		}},
+	})
+	for volumes.HasMorePages() {
+		page, err := volumes.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe volumes: %v", err)
+		}
+		for _, volume := range page.Volumes {
+			usage[quotaGP3Storage.QuotaCode] += float64(aws.ToInt32(volume.Size)) / 1024
+		}
+	}
+
+	addresses, err := c.ec2Client.DescribeAddresses(context.Background(), &ec2.DescribeAddressesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("domain"),
+			Values: []string{string(ec2types.DomainTypeVpc)},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe elastic IPs: %v", err)
+	}
+	usage[quotaEIPs.QuotaCode] = float64(len(addresses.Addresses))
+
+	natGateways, err := c.getMaxNATGatewaysPerZone()
+	if err != nil {
+		return nil, err
+	}
+	usage[quotaNATGateways.QuotaCode] = float64(natGateways)
+
+	interfaces := ec2.NewDescribeNetworkInterfacesPaginator(c.ec2Client, &ec2.DescribeNetworkInterfacesInput{})
+	for interfaces.HasMorePages() {
+		page, err := interfaces.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe network interfaces: %v", err)
+		}
+		usage[quotaNetworkInterfaces.QuotaCode] += float64(len(page.NetworkInterfaces))
+	}
+
+	return usage, nil
+}
+
+// getMaxNATGatewaysPerZone returns the number of NAT gateways of the availability zone that has
+// the most of them, as the quota applies to each zone and the zones of the cluster aren't known
+func (c *awsClient) getMaxNATGatewaysPerZone() (int, error) {
+	subnets := map[string]int{}
+	natGateways := ec2.NewDescribeNatGatewaysPaginator(c.ec2Client, &ec2.DescribeNatGatewaysInput{
+		Filter: []ec2types.Filter{{
+			Name:   aws.String("state"),
+			Values: []string{"pending", "available"},
+		}},
+	})
+	for natGateways.HasMorePages() {
+		page, err := natGateways.NextPage(context.Background())
+		if err != nil {
+			return 0, fmt.Errorf("Failed to describe NAT gateways: %v", err)
+		}
+		for _, natGateway := range page.NatGateways {
+			subnets[aws.ToString(natGateway.SubnetId)]++
+		}
+	}
+	if len(subnets) == 0 {
+		return 0, nil
+	}
+
+	subnetIDs := make([]string, 0, len(subnets))
+	for subnetID := range subnets {
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	output, err := c.ec2Client.DescribeSubnets(context.Background(), &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIDs,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Failed to describe subnets of NAT gateways: %v", err)
+	}
+	zones := map[string]int{}
+	max := 0
+	for _, subnet := range output.Subnets {
+		zone := aws.ToString(subnet.AvailabilityZone)
+		zones[zone] += subnets[aws.ToString(subnet.SubnetId)]
+		if zones[zone] > max {
+			max = zones[zone]
+		}
+	}
+	return max, nil
+}
+
+// getQuotaValue returns the value of the quota applied to the account, or the default value when
+// the account doesn't have a specific one
+func (c *awsClient) getQuotaValue(serviceCode string, quotaCode string) (float64, error) {
+	output, err := c.serviceQuotasClient.GetServiceQuota(context.Background(), &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err == nil && output.Quota != nil && output.Quota.Value != nil {
+		return *output.Quota.Value, nil
+	}
+	var notFound *servicequotastypes.NoSuchResourceException
+	if err != nil && !errors.As(err, &notFound) {
+		return 0, err
+	}
+	defaultOutput, err := c.serviceQuotasClient.GetAWSDefaultServiceQuota(context.Background(),
+		&servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(serviceCode),
+			QuotaCode:   aws.String(quotaCode),
+		})
+	if err != nil {
+		return 0, err
+	}
+	if defaultOutput.Quota == nil || defaultOutput.Quota.Value == nil {
+		return 0, fmt.Errorf("No value for quota '%s'", quotaCode)
+	}
+	return *defaultOutput.Quota.Value, nil
+}
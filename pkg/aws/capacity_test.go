@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"context"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Capacity", func() {
+	vcpus := map[string]int32{
+		"m5.xlarge":   4,
+		"m5.2xlarge":  8,
+		"r5.xlarge":   4,
+		"g4dn.xlarge": 4,
+	}
+
+	Context("RequiredCapacity", func() {
+		It("Includes the control plane, infra and bootstrap nodes of classic clusters", func() {
+			checks, err := RequiredCapacity(&ClusterTopology{
+				ComputeInstanceType: "m5.xlarge",
+				ComputeNodes:        2,
+			}, vcpus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(5))
+			Expect(checks[0].QuotaCode).To(Equal(quotaStandardInstances.QuotaCode))
+			Expect(checks[0].Required).To(Equal(44.0))
+			Expect(checks[1].QuotaCode).To(Equal(quotaGP3Storage.QuotaCode))
+			Expect(checks[1].Required).To(BeNumerically("~", 2370.0/1024))
+			Expect(checks[2].QuotaCode).To(Equal(quotaEIPs.QuotaCode))
+			Expect(checks[2].Required).To(Equal(1.0))
+			Expect(checks[3].QuotaCode).To(Equal(quotaNATGateways.QuotaCode))
+			Expect(checks[3].Required).To(Equal(1.0))
+			Expect(checks[4].QuotaCode).To(Equal(quotaNetworkInterfaces.QuotaCode))
+			Expect(checks[4].Required).To(Equal(12.0))
+		})
+
+		It("Splits the vCPUs of hosted clusters by instance family quota", func() {
+			checks, err := RequiredCapacity(&ClusterTopology{
+				ComputeInstanceType: "m5.xlarge",
+				ComputeNodes:        3,
+				MultiAZ:             true,
+				HostedCP:            true,
+				ExistingVPC:         true,
+				MachinePools:        []MachinePoolTopology{{InstanceType: "g4dn.xlarge", Nodes: 2}},
+			}, vcpus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(6))
+			Expect(checks[0].QuotaCode).To(Equal(quotaStandardInstances.QuotaCode))
+			Expect(checks[0].Required).To(Equal(12.0))
+			Expect(checks[1].QuotaCode).To(Equal("L-DB2E81BA"))
+			Expect(checks[1].Required).To(Equal(8.0))
+			Expect(checks[3].Required).To(Equal(0.0))
+			Expect(checks[4].Required).To(Equal(0.0))
+			Expect(checks[5].Required).To(Equal(8.0))
+		})
+
+		It("Fails with an unknown instance type", func() {
+			_, err := RequiredCapacity(&ClusterTopology{ComputeInstanceType: "m9.huge", ComputeNodes: 2}, vcpus)
+			Expect(err).To(MatchError(ContainSubstring("m9.huge")))
+		})
+	})
+
+	DescribeTable("instanceQuota",
+		func(instanceType string, quotaCode string) {
+			Expect(instanceQuota(instanceType).QuotaCode).To(Equal(quotaCode))
+		},
+		Entry("standard", "m5.xlarge", "L-1216C47A"),
+		Entry("storage optimized", "i3.large", "L-1216C47A"),
+		Entry("inferentia", "inf2.xlarge", "L-1945791B"),
+		Entry("GPU", "g5.2xlarge", "L-DB2E81BA"),
+		Entry("accelerated", "p4d.24xlarge", "L-417A185B"),
+		Entry("memory", "x2idn.16xlarge", "L-7295265B"),
+	)
+
+	Context("VerifyCapacity", func() {
+		var (
+			mockCtrl          *gomock.Controller
+			mockEC2API        *mocks.MockEc2ApiClient
+			mockServiceQuotas *mocks.MockServiceQuotasApiClient
+			client            Client
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEC2API = mocks.NewMockEc2ApiClient(mockCtrl)
+			mockServiceQuotas = mocks.NewMockServiceQuotasApiClient(mockCtrl)
+			client = New(
+				awsSdk.Config{},
+				logrus.New(),
+				mocks.NewMockIamApiClient(mockCtrl),
+				mockEC2API,
+				mocks.NewMockOrganizationsApiClient(mockCtrl),
+				mocks.NewMockS3ApiClient(mockCtrl),
+				mocks.NewMockSecretsManagerApiClient(mockCtrl),
+				mocks.NewMockStsApiClient(mockCtrl),
+				mocks.NewMockCloudFormationApiClient(mockCtrl),
+				mockServiceQuotas,
+				mocks.NewMockKmsApiClient(mockCtrl),
+				&AccessKey{},
+				false,
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Compares the required capacity with the usage and quotas of the account", func() {
+			mockEC2API.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeInstanceTypesOutput{
+					InstanceTypes: []ec2types.InstanceTypeInfo{{
+						InstanceType: "m5.xlarge",
+						VCpuInfo:     &ec2types.VCpuInfo{DefaultVCpus: awsSdk.Int32(4)},
+					}},
+				}, nil)
+			mockEC2API.EXPECT().DescribeInstances(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeInstancesOutput{
+					Reservations: []ec2types.Reservation{{
+						Instances: []ec2types.Instance{{
+							InstanceType: "m5.4xlarge",
+							CpuOptions: &ec2types.CpuOptions{
+								CoreCount:      awsSdk.Int32(8),
+								ThreadsPerCore: awsSdk.Int32(2),
+							},
+						}},
+					}},
+				}, nil)
+			mockEC2API.EXPECT().DescribeVolumes(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeVolumesOutput{
+					Volumes: []ec2types.Volume{{Size: awsSdk.Int32(512)}},
+				}, nil)
+			mockEC2API.EXPECT().DescribeAddresses(gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeAddressesOutput{}, nil)
+			mockEC2API.EXPECT().DescribeNatGateways(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []ec2types.NatGateway{
+						{SubnetId: awsSdk.String("subnet-a")},
+						{SubnetId: awsSdk.String("subnet-b")},
+						{SubnetId: awsSdk.String("subnet-c")},
+					},
+				}, nil)
+			mockEC2API.EXPECT().DescribeSubnets(gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeSubnetsOutput{
+					Subnets: []ec2types.Subnet{
+						{SubnetId: awsSdk.String("subnet-a"), AvailabilityZone: awsSdk.String("us-east-1a")},
+						{SubnetId: awsSdk.String("subnet-b"), AvailabilityZone: awsSdk.String("us-east-1a")},
+						{SubnetId: awsSdk.String("subnet-c"), AvailabilityZone: awsSdk.String("us-east-1b")},
+					},
+				}, nil)
+			mockEC2API.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				&ec2.DescribeNetworkInterfacesOutput{
+					NetworkInterfaces: make([]ec2types.NetworkInterface, 7),
+				}, nil)
+			mockServiceQuotas.EXPECT().GetServiceQuota(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *servicequotas.GetServiceQuotaInput,
+					_ ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error) {
+					if *input.QuotaCode == quotaStandardInstances.QuotaCode {
+						return &servicequotas.GetServiceQuotaOutput{
+							Quota: &servicequotastypes.ServiceQuota{Value: awsSdk.Float64(20)},
+						}, nil
+					}
+					return nil, &servicequotastypes.NoSuchResourceException{}
+				}).Times(5)
+			mockServiceQuotas.EXPECT().GetAWSDefaultServiceQuota(gomock.Any(), gomock.Any()).Return(
+				&servicequotas.GetAWSDefaultServiceQuotaOutput{
+					Quota: &servicequotastypes.ServiceQuota{Value: awsSdk.Float64(5)},
+				}, nil).Times(4)
+
+			checks, err := client.VerifyCapacity(&ClusterTopology{
+				ComputeInstanceType: "m5.xlarge",
+				ComputeNodes:        2,
+				HostedCP:            true,
+				ExistingVPC:         true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(5))
+
+			// 16 vCPUs in use plus 8 required exceed the quota of 20
+			Expect(checks[0].Usage).To(Equal(16.0))
+			Expect(checks[0].Quota).To(Equal(20.0))
+			Expect(checks[0].Sufficient()).To(BeFalse())
+			Expect(checks[0].DesiredQuota()).To(Equal(24.0))
+			Expect(checks[1].Usage).To(Equal(0.5))
+			Expect(checks[1].Sufficient()).To(BeTrue())
+			Expect(checks[3].Usage).To(Equal(2.0))
+			Expect(checks[4].Usage).To(Equal(7.0))
+			Expect(checks[4].Sufficient()).To(BeFalse())
+		})
+	})
+})
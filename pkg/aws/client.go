@@ -31,11 +31,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -51,9 +53,12 @@ import (
 	"github.com/zgalor/weberr"
 
 	client "github.com/openshift/rosa/pkg/aws/api_interface"
+	"github.com/openshift/rosa/pkg/aws/assumerole"
+	"github.com/openshift/rosa/pkg/aws/endpoints"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	regionflag "github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	rosaconfig "github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/info"
@@ -104,6 +109,9 @@ type Client interface {
 	ValidateCredentials() (isValid bool, err error)
 	EnsureOsdCcsAdminUser(stackName string, adminUserName string, awsRegion string) (bool, error)
 	DeleteOsdCcsAdminUser(stackName string) error
+	CreateNetworkStack(stackName string, params map[string]string, tagList map[string]string) (map[string]string, error)
+	EnsureSharedVPCStack(stackName string, params map[string]string, tagList map[string]string) (map[string]string, error)
+	DeleteNetworkStack(stackName string) error
 	AccessKeyGetter
 	GetCreator() (*Creator, error)
 	ValidateSCP(*string, map[string]*cmv1.AWSSTSPolicy) (bool, error)
@@ -114,6 +122,13 @@ type Client interface {
 	GetVPCPrivateSubnets(subnetID string) ([]ec2types.Subnet, error)
 	FilterVPCsPrivateSubnets(subnets []ec2types.Subnet) ([]ec2types.Subnet, error)
 	ValidateQuota() (bool, error)
+	VerifyCapacity(topology *ClusterTopology) ([]CapacityCheck, error)
+	RequestQuotaIncrease(serviceCode string, quotaCode string, value float64) (string, error)
+	FindKMSKeyByAlias(alias string) (string, error)
+	CreateKMSKey(alias string, description string, policy *PolicyDocument, tags map[string]string) (string, error)
+	GetKMSKeyPolicy(keyID string) (*PolicyDocument, error)
+	PutKMSKeyPolicy(keyID string, policy *PolicyDocument) error
+	ValidateKMSKeyPolicy(keyID string, grants []KMSKeyGrant) ([]string, []string, error)
 	TagUserRegion(username string, region string) error
 	GetClusterRegionTagForUser(username string) (string, error)
 	EnsureRole(name string, policy string, permissionsBoundary string,
@@ -137,6 +152,8 @@ type Client interface {
 	ListAccountRoles(version string) ([]Role, error)
 	ListOperatorRoles(version string, clusterID string) (map[string][]OperatorRoleDetail, error)
 	ListOidcProviders(targetClusterId string, config *cmv1.OidcConfig) ([]OidcProviderOutput, error)
+	ListUnattachedOperatorPolicies() ([]PolicyDetail, error)
+	DeletePolicy(policyArn string) error
 	GetRoleByARN(roleARN string) (iamtypes.Role, error)
 	DeleteOperatorRole(roles string, managedPolicies bool) error
 	GetOperatorRolesFromAccountByClusterID(
@@ -206,6 +223,10 @@ type Client interface {
 	GetAccountRoleByArn(roleArn string) (Role, error)
 	GetSecurityGroupIds(vpcId string) ([]ec2types.SecurityGroup, error)
 	FetchPublicSubnetMap(subnets []ec2types.Subnet) (map[string]bool, error)
+	ValidateSubnets(topology *ClusterTopology, privateLink bool, accountID string,
+		subnets []ec2types.Subnet) (*SubnetReport, error)
+	TagSubnets(tagsBySubnet map[string][]string) error
+	SimulateRolePermissions(roleARN string, actions []string) ([]SimulatedAction, []SimulatedAction, error)
 }
 
 type AccessKeyGetter interface {
@@ -219,6 +240,8 @@ type ClientBuilder struct {
 	region              *string
 	credentials         *AccessKey
 	useLocalCredentials bool
+	endpoints           map[string]string
+	transport           http.RoundTripper
 }
 
 type awsClient struct {
@@ -232,6 +255,7 @@ type awsClient struct {
 	stsClient           client.StsApiClient
 	cfClient            client.CloudFormationApiClient
 	serviceQuotasClient client.ServiceQuotasApiClient
+	kmsClient           client.KmsApiClient
 	awsAccessKeys       *AccessKey
 	useLocalCredentials bool
 }
@@ -264,6 +288,7 @@ func New(
 	stsClient client.StsApiClient,
 	cfClient client.CloudFormationApiClient,
 	serviceQuotasClient client.ServiceQuotasApiClient,
+	kmsClient client.KmsApiClient,
 	awsAccessKeys *AccessKey,
 	useLocalCredentials bool,
 
@@ -279,6 +304,7 @@ func New(
 		stsClient,
 		cfClient,
 		serviceQuotasClient,
+		kmsClient,
 		awsAccessKeys,
 		useLocalCredentials,
 	}
@@ -305,6 +331,20 @@ func (b *ClientBuilder) UseLocalCredentials(value bool) *ClientBuilder {
 	return b
 }
 
+// Transport sets the transport that the AWS clients will use to send the requests, instead of the
+// default one. This is intended for tests.
+func (b *ClientBuilder) Transport(value http.RoundTripper) *ClientBuilder {
+	b.transport = value
+	return b
+}
+
+func (b *ClientBuilder) httpTransport() http.RoundTripper {
+	if b.transport != nil {
+		return b.transport
+	}
+	return http.DefaultTransport
+}
+
 // Create AWS session with a specific set of credentials
 func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 	logLevel aws.ClientLogMode) (aws.Config, error) {
@@ -313,7 +353,7 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 			value.SecretAccessKey, "")),
 		config.WithRegion(*b.region),
 		config.WithHTTPClient(&http.Client{
-			Transport: http.DefaultTransport,
+			Transport: logging.TraceTransport(b.httpTransport()),
 		}),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
@@ -343,7 +383,7 @@ func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws
 		config.WithSharedConfigProfile(profile.Profile()),
 		config.WithRegion(*b.region),
 		config.WithHTTPClient(&http.Client{
-			Transport: http.DefaultTransport,
+			Transport: logging.TraceTransport(b.httpTransport()),
 		}),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
@@ -380,11 +420,58 @@ func (b *ClientBuilder) BuildSession() (aws.Config, error) {
 	}
 	allErrorCodes = append(throttleErrorCodes, awserr.InvalidClientTokenID)
 
+	var cfg aws.Config
+	var err error
 	if b.credentials != nil {
-		return b.BuildSessionWithOptionsCredentials(b.credentials, logLevel)
+		cfg, err = b.BuildSessionWithOptionsCredentials(b.credentials, logLevel)
+	} else {
+		cfg, err = b.BuildSessionWithOptions(logLevel)
+	}
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	return b.withAssumedRole(cfg)
+}
+
+// withAssumedRole replaces the credentials of the configuration with the ones of the role given
+// with the '--assume-role-arn' flag, obtained using the original credentials
+func (b *ClientBuilder) withAssumedRole(cfg aws.Config) (aws.Config, error) {
+	err := assumerole.Validate()
+	if err != nil {
+		return aws.Config{}, err
+	}
+	roleARN := assumerole.RoleARN()
+	if roleARN == "" {
+		return cfg, nil
 	}
+	err = ARNValidator(roleARN)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("Invalid role ARN '%s' to assume: %v", roleARN, err)
+	}
+	b.logger.Debugf("Assuming AWS role: %s", roleARN)
 
-	return b.BuildSessionWithOptions(logLevel)
+	stsClient := sts.NewFromConfig(cfg, func(options *sts.Options) {
+		options.BaseEndpoint = b.endpoint(endpoints.STS)
+	})
+	provider := stscreds.NewAssumeRoleProvider(stsClient, roleARN,
+		func(options *stscreds.AssumeRoleOptions) {
+			options.RoleSessionName = assumerole.SessionName()
+			if externalID := assumerole.ExternalID(); externalID != "" {
+				options.ExternalID = aws.String(externalID)
+			}
+			if serial := assumerole.MFASerial(); serial != "" {
+				options.SerialNumber = aws.String(serial)
+				options.TokenProvider = stscreds.StdinTokenProvider
+				if token := assumerole.MFAToken(); token != "" {
+					options.TokenProvider = func() (string, error) {
+						return token, nil
+					}
+				}
+			}
+		})
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	return cfg, nil
 }
 
 // Build uses the information stored in the builder to build a new AWS client.
@@ -408,6 +495,12 @@ func (b *ClientBuilder) Build() (Client, error) {
 		return nil, fmt.Errorf("failed to connect to AWS. Use a GovCloud region in your profile")
 	}
 
+	// Resolve the custom endpoints of the AWS services:
+	err := b.resolveEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the AWS session:
 	cfg, err := b.BuildSession()
 	if err != nil {
@@ -424,16 +517,37 @@ func (b *ClientBuilder) Build() (Client, error) {
 
 	// Create and populate the object:
 	c := &awsClient{
-		cfg:                 cfg,
-		logger:              b.logger,
-		iamClient:           iam.NewFromConfig(cfg),
-		ec2Client:           ec2.NewFromConfig(cfg),
-		orgClient:           organizations.NewFromConfig(cfg),
-		s3Client:            s3.NewFromConfig(cfg),
-		smClient:            secretsmanager.NewFromConfig(cfg),
-		stsClient:           sts.NewFromConfig(cfg),
-		cfClient:            cloudformation.NewFromConfig(cfg),
-		serviceQuotasClient: servicequotas.NewFromConfig(cfg),
+		cfg:    cfg,
+		logger: b.logger,
+		iamClient: iam.NewFromConfig(cfg, func(options *iam.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.IAM)
+		}),
+		ec2Client: ec2.NewFromConfig(cfg, func(options *ec2.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.EC2)
+		}),
+		orgClient: organizations.NewFromConfig(cfg, func(options *organizations.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.Organizations)
+		}),
+		s3Client: s3.NewFromConfig(cfg, func(options *s3.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.S3)
+			// Custom endpoints, like LocalStack, don't usually support virtual hosted buckets
+			options.UsePathStyle = options.BaseEndpoint != nil
+		}),
+		smClient: secretsmanager.NewFromConfig(cfg, func(options *secretsmanager.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.SecretsManager)
+		}),
+		stsClient: sts.NewFromConfig(cfg, func(options *sts.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.STS)
+		}),
+		cfClient: cloudformation.NewFromConfig(cfg, func(options *cloudformation.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.CloudFormation)
+		}),
+		serviceQuotasClient: servicequotas.NewFromConfig(cfg, func(options *servicequotas.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.ServiceQuotas)
+		}),
+		kmsClient: kms.NewFromConfig(cfg, func(options *kms.Options) {
+			options.BaseEndpoint = b.endpoint(endpoints.KMS)
+		}),
 		useLocalCredentials: b.useLocalCredentials,
 	}
 
@@ -449,6 +563,38 @@ func (b *ClientBuilder) Build() (Client, error) {
 	return c, err
 }
 
+// resolveEndpoints determines the custom endpoints of the AWS services, from the command line,
+// the environment or the configuration file
+func (b *ClientBuilder) resolveEndpoints() error {
+	var configured map[string]string
+	cfg, err := rosaconfig.Load()
+	if err != nil {
+		b.logger.Debugf("Failed to load the AWS endpoints from the configuration file: %v", err)
+	} else if cfg != nil {
+		configured = cfg.AWSEndpoints
+	}
+	err = endpoints.Validate(configured)
+	if err != nil {
+		return err
+	}
+	b.endpoints = map[string]string{}
+	for _, service := range endpoints.Services {
+		if value := endpoints.Resolve(service, configured); value != "" {
+			b.logger.Debugf("Using custom endpoint '%s' for AWS service '%s'", value, service)
+			b.endpoints[service] = value
+		}
+	}
+	return nil
+}
+
+// endpoint returns the custom endpoint of the given AWS service, or nil to use the default one
+func (b *ClientBuilder) endpoint(service string) *string {
+	if value, ok := b.endpoints[service]; ok {
+		return aws.String(value)
+	}
+	return nil
+}
+
 func (c *awsClient) GetIAMCredentials() (aws.Credentials, error) {
 	return c.cfg.Credentials.Retrieve(context.TODO())
 }
@@ -687,7 +833,34 @@ func (c *awsClient) GetCreator() (*Creator, error) {
 		return nil, err
 	}
 
-	return CreatorForCallerIdentity(getCallerIdentityOutput)
+	creator, err := CreatorForCallerIdentity(getCallerIdentityOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	return creatorForAssumedRole(creator, assumerole.RoleARN()), nil
+}
+
+// creatorForAssumedRole uses the ARN of the role assumed with the '--assume-role-arn' flag as the
+// ARN of the creator, as the identity returned by STS doesn't include the path of the role
+func creatorForAssumedRole(creator *Creator, roleARN string) *Creator {
+	if roleARN == "" || !creator.IsSTS {
+		return creator
+	}
+	parsedRoleARN, err := arn.Parse(roleARN)
+	if err != nil || parsedRoleARN.AccountID != creator.AccountID {
+		return creator
+	}
+	parsedCreatorARN, err := arn.Parse(creator.ARN)
+	if err != nil {
+		return creator
+	}
+	roleResource := strings.Split(parsedRoleARN.Resource, "/")
+	creatorResource := strings.Split(parsedCreatorARN.Resource, "/")
+	if roleResource[len(roleResource)-1] == creatorResource[len(creatorResource)-1] {
+		creator.ARN = roleARN
+	}
+	return creator
 }
 
 // CreatorForCallerIdentity adapts an STS CallerIdentity to the ROSA *Creator
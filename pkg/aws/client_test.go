@@ -63,6 +63,7 @@ var _ = Describe("Client", func() {
 			mockSTSApi,
 			mockCfAPI,
 			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
 			&AccessKey{},
 			false,
 		)
@@ -242,6 +243,26 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+	Context("DeleteNetworkStack", func() {
+		It("Reports a stack that doesn't exist", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{
+				Code:    "ValidationError",
+				Message: "Stack with id mynetwork does not exist",
+			})
+			err := client.DeleteNetworkStack("mynetwork")
+			Expect(err).To(MatchError("CloudFormation stack 'mynetwork' doesn't exist"))
+		})
+
+		It("Returns the other errors as they are", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{
+				Code:    "AccessDenied",
+				Message: "Role arn:aws:iam::123:role/does not exist in the allowed list",
+			})
+			err := client.DeleteNetworkStack("mynetwork")
+			Expect(err).To(MatchError(ContainSubstring("AccessDenied")))
+		})
+	})
+
 	Context("CheckAdminUserNotExisting", func() {
 		var (
 			adminUserName string
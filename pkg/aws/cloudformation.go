@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
@@ -29,6 +31,15 @@ import (
 	"github.com/aws/smithy-go"
 
 	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+const (
+	networkTemplatePath   = "templates/cloudformation/rosa_network.yaml"
+	sharedVPCTemplatePath = "templates/cloudformation/rosa_shared_vpc.yaml"
+	// NAT gateways take several minutes to become available, so the network stack needs more time
+	// than the rest of the stacks
+	networkStackMaxWaitDur = 30 * time.Minute
 )
 
 func readCloudFormationTemplate(path string) (string, error) {
@@ -228,3 +239,203 @@ func buildUpdateStackInput(cfTemplateBody, stackName string) *cloudformation.Upd
 		TemplateBody: aws.String(cfTemplateBody),
 	}
 }
+
+// CreateNetworkStack deploys the bundled network template with the given parameters, waits for the
+// stack to be created and returns its outputs
+func (c *awsClient) CreateNetworkStack(stackName string, params map[string]string,
+	tagList map[string]string) (map[string]string, error) {
+	_, err := c.describeStack(stackName)
+	if err == nil {
+		return nil, fmt.Errorf("CloudFormation stack '%s' already exists", stackName)
+	}
+	if !isStackNotFoundError(err) {
+		return nil, err
+	}
+
+	cfTemplateBody, err := readCloudFormationTemplate(networkTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.cfClient.CreateStack(context.Background(), &cloudformation.CreateStackInput{
+		StackName:    aws.String(stackName),
+		TemplateBody: aws.String(cfTemplateBody),
+		Parameters:   buildStackParameters(params),
+		Tags:         buildStackTags(tagList),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	waiter := cloudformation.NewStackCreateCompleteWaiter(c.cfClient)
+	err = waiter.Wait(context.Background(), buildDescribeStacksInput(stackName), networkStackMaxWaitDur)
+	if err != nil {
+		return nil, fmt.Errorf("CloudFormation stack '%s' wasn't created: %v", stackName, err)
+	}
+
+	return c.getStackOutputs(stackName)
+}
+
+// EnsureSharedVPCStack deploys the bundled shared VPC template with the given parameters and returns
+// its outputs. If the stack was already created for the same cluster it is updated, so that the
+// trusted roles can be added once they exist.
+func (c *awsClient) EnsureSharedVPCStack(stackName string, params map[string]string,
+	tagList map[string]string) (map[string]string, error) {
+	cfTemplateBody, err := readCloudFormationTemplate(sharedVPCTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := c.describeStack(stackName)
+	if err != nil && !isStackNotFoundError(err) {
+		return nil, err
+	}
+	if err != nil {
+		_, err = c.cfClient.CreateStack(context.Background(), &cloudformation.CreateStackInput{
+			StackName:    aws.String(stackName),
+			TemplateBody: aws.String(cfTemplateBody),
+			Parameters:   buildStackParameters(params),
+			Tags:         buildStackTags(tagList),
+			Capabilities: []cloudformationtypes.Capability{cloudformationtypes.CapabilityCapabilityNamedIam},
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = waitForStackCreateComplete(context.Background(), c.cfClient, stackName)
+		if err != nil {
+			return nil, fmt.Errorf("CloudFormation stack '%s' wasn't created: %v", stackName, err)
+		}
+		return c.getStackOutputs(stackName)
+	}
+
+	clusterName := tagList[tags.ClusterName]
+	isClusterStack := false
+	for _, tag := range stack.Tags {
+		if aws.ToString(tag.Key) == tags.ClusterName && aws.ToString(tag.Value) == clusterName {
+			isClusterStack = true
+		}
+	}
+	if !isClusterStack {
+		return nil, fmt.Errorf("CloudFormation stack '%s' already exists and wasn't created for cluster '%s'",
+			stackName, clusterName)
+	}
+	_, err = c.cfClient.UpdateStack(context.Background(), &cloudformation.UpdateStackInput{
+		StackName:    aws.String(stackName),
+		TemplateBody: aws.String(cfTemplateBody),
+		Parameters:   buildStackParameters(params),
+		Tags:         buildStackTags(tagList),
+		Capabilities: []cloudformationtypes.Capability{cloudformationtypes.CapabilityCapabilityNamedIam},
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ValidationError" ||
+			!strings.Contains(apiErr.ErrorMessage(), "No updates are to be performed") {
+			return nil, err
+		}
+	} else {
+		err = waitForStackUpdateComplete(context.Background(), c.cfClient, stackName)
+		if err != nil {
+			return nil, fmt.Errorf("CloudFormation stack '%s' wasn't updated: %v", stackName, err)
+		}
+	}
+	return c.getStackOutputs(stackName)
+}
+
+func (c *awsClient) getStackOutputs(stackName string) (map[string]string, error) {
+	stack, err := c.describeStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[string]string{}
+	for _, output := range stack.Outputs {
+		outputs[aws.ToString(output.OutputKey)] = aws.ToString(output.OutputValue)
+	}
+	return outputs, nil
+}
+
+func buildStackParameters(params map[string]string) []cloudformationtypes.Parameter {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]cloudformationtypes.Parameter, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, cloudformationtypes.Parameter{
+			ParameterKey:   aws.String(key),
+			ParameterValue: aws.String(params[key]),
+		})
+	}
+	return result
+}
+
+func buildStackTags(tagList map[string]string) []cloudformationtypes.Tag {
+	keys := make([]string, 0, len(tagList))
+	for key := range tagList {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]cloudformationtypes.Tag, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, cloudformationtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tagList[key]),
+		})
+	}
+	return result
+}
+
+// DeleteNetworkStack deletes a stack created with CreateNetworkStack and waits for the deletion
+// to complete. Stacks that weren't created by rosa are never deleted.
+func (c *awsClient) DeleteNetworkStack(stackName string) error {
+	stack, err := c.describeStack(stackName)
+	if err != nil {
+		if isStackNotFoundError(err) {
+			return fmt.Errorf("CloudFormation stack '%s' doesn't exist", stackName)
+		}
+		return err
+	}
+	isNetworkStack := false
+	for _, tag := range stack.Tags {
+		if aws.ToString(tag.Key) == tags.NetworkName {
+			isNetworkStack = true
+		}
+	}
+	if !isNetworkStack {
+		return fmt.Errorf("CloudFormation stack '%s' wasn't created with 'rosa create network'", stackName)
+	}
+
+	_, err = c.cfClient.DeleteStack(context.Background(), &cloudformation.DeleteStackInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return err
+	}
+	waiter := cloudformation.NewStackDeleteCompleteWaiter(c.cfClient)
+	err = waiter.Wait(context.Background(), buildDescribeStacksInput(stackName), networkStackMaxWaitDur)
+	if err != nil {
+		return fmt.Errorf("CloudFormation stack '%s' wasn't deleted: %v", stackName, err)
+	}
+	return nil
+}
+
+func (c *awsClient) describeStack(stackName string) (*cloudformationtypes.Stack, error) {
+	output, err := c.cfClient.DescribeStacks(context.Background(), buildDescribeStacksInput(stackName))
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Stacks) == 0 {
+		return nil, &smithy.GenericAPIError{
+			Code:    "ValidationError",
+			Message: fmt.Sprintf("Stack with id %s does not exist", stackName),
+		}
+	}
+	return &output.Stacks[0], nil
+}
+
+// isStackNotFoundError checks if the error returned when describing a stack is because it doesn't
+// exist, CloudFormation reports it as a generic validation error
+func isStackNotFoundError(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+		strings.Contains(apiErr.ErrorMessage(), "does not exist")
+}
@@ -0,0 +1,188 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the command line options that override the
+// endpoints of the AWS services, for example to use LocalStack or VPC interface endpoints.
+
+package endpoints
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	IAM            = "iam"
+	STS            = "sts"
+	EC2            = "ec2"
+	S3             = "s3"
+	SecretsManager = "secretsmanager"
+	CloudFormation = "cloudformation"
+	ServiceQuotas  = "servicequotas"
+	Organizations  = "organizations"
+	KMS            = "kms"
+
+	// AllServices is the key used in the configuration file for the endpoint of all the services
+	AllServices = "default"
+
+	URLFlag         = "aws-endpoint-url"
+	ServiceURLsFlag = "aws-service-endpoint"
+
+	// URLEnvKey is the environment variable with the endpoint of all the services, and the prefix
+	// of the variables with the endpoint of a specific service, for example
+	// ROSA_AWS_ENDPOINT_URL_IAM
+	URLEnvKey = "ROSA_AWS_ENDPOINT_URL"
+)
+
+// Services are the AWS services whose endpoint can be overridden
+var Services = []string{
+	CloudFormation,
+	EC2,
+	IAM,
+	KMS,
+	Organizations,
+	S3,
+	SecretsManager,
+	ServiceQuotas,
+	STS,
+}
+
+// AddFlags adds the endpoint flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&endpointURL,
+		URLFlag,
+		"",
+		"Use the given URL as the endpoint of all the AWS services, for example to use LocalStack.",
+	)
+	flags.StringToStringVar(
+		&serviceURLs,
+		ServiceURLsFlag,
+		nil,
+		fmt.Sprintf("Use a custom endpoint for specific AWS services, in the format "+
+			"'<service>=<url>'. Can be used multiple times. Valid services are %s.", Services),
+	)
+}
+
+// Resolve returns the endpoint of the given service, or an empty string to use the default one.
+// The flags take precedence over the environment variables, and those over the endpoints of the
+// configuration file. In each of them the endpoint of the service takes precedence over the one
+// for all the services.
+func Resolve(service string, configured map[string]string) string {
+	candidates := []string{
+		serviceURLs[service],
+		endpointURL,
+		os.Getenv(URLEnvKey + "_" + strings.ToUpper(service)),
+		os.Getenv(URLEnvKey),
+		configured[service],
+		configured[AllServices],
+	}
+	for _, candidate := range candidates {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Validate checks that the endpoints given in the flags, environment and configuration file are
+// for known services and are valid URLs.
+func Validate(configured map[string]string) error {
+	all := map[string]string{}
+	for service, value := range configured {
+		all[service] = value
+	}
+	for service, value := range serviceURLs {
+		all[service] = value
+	}
+	for _, service := range Services {
+		if value := os.Getenv(URLEnvKey + "_" + strings.ToUpper(service)); value != "" {
+			all[service] = value
+		}
+	}
+	all[AllServices] = Resolve(AllServices, configured)
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name != AllServices && !isService(name) {
+			return fmt.Errorf("Unknown AWS service '%s' for custom endpoint. Valid services are %s",
+				name, Services)
+		}
+		value := all[name]
+		if value == "" {
+			continue
+		}
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("Invalid endpoint URL '%s' for AWS service '%s'", value, name)
+		}
+	}
+	return nil
+}
+
+// Parse converts a list of endpoints in the format '<service>=<url>,...' to a map.
+func Parse(value string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Expected an endpoint in the format '<service>=<url>', got '%s'", item)
+		}
+		result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return result, nil
+}
+
+// Format converts a map of endpoints to the format accepted by Parse.
+func Format(values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := make([]string, len(names))
+	for i, name := range names {
+		items[i] = fmt.Sprintf("%s=%s", name, values[name])
+	}
+	return strings.Join(items, ",")
+}
+
+func isService(name string) bool {
+	for _, service := range Services {
+		if service == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	endpointURL string
+	serviceURLs map[string]string
+)
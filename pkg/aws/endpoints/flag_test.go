@@ -0,0 +1,77 @@
+package endpoints
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Endpoints", func() {
+	parseFlags := func(args ...string) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(flags)
+		Expect(flags.Parse(args)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		endpointURL = ""
+		serviceURLs = nil
+	})
+
+	Context("Resolve", func() {
+		configured := map[string]string{
+			IAM:         "https://iam.config.example.com",
+			AllServices: "https://default.config.example.com",
+		}
+
+		It("Uses the default endpoint when nothing is overridden", func() {
+			Expect(Resolve(EC2, nil)).To(BeEmpty())
+		})
+
+		It("Uses the configuration file", func() {
+			Expect(Resolve(IAM, configured)).To(Equal("https://iam.config.example.com"))
+			Expect(Resolve(EC2, configured)).To(Equal("https://default.config.example.com"))
+		})
+
+		It("Prefers the environment over the configuration file", func() {
+			GinkgoT().Setenv("ROSA_AWS_ENDPOINT_URL_IAM", "https://iam.env.example.com")
+			Expect(Resolve(IAM, configured)).To(Equal("https://iam.env.example.com"))
+			Expect(Resolve(EC2, configured)).To(Equal("https://default.config.example.com"))
+		})
+
+		It("Prefers the flags over the environment", func() {
+			GinkgoT().Setenv("ROSA_AWS_ENDPOINT_URL", "https://env.example.com")
+			parseFlags("--aws-endpoint-url", "http://localhost:4566",
+				"--aws-service-endpoint", "iam=https://iam.flag.example.com")
+			Expect(Resolve(IAM, configured)).To(Equal("https://iam.flag.example.com"))
+			Expect(Resolve(EC2, configured)).To(Equal("http://localhost:4566"))
+		})
+	})
+
+	Context("Validate", func() {
+		It("Accepts known services with valid URLs", func() {
+			parseFlags("--aws-service-endpoint", "sts=https://sts.example.com")
+			Expect(Validate(map[string]string{S3: "http://localhost:4566"})).To(Succeed())
+		})
+
+		It("Fails with an unknown service", func() {
+			parseFlags("--aws-service-endpoint", "lambda=https://lambda.example.com")
+			Expect(Validate(nil)).To(MatchError(ContainSubstring("Unknown AWS service 'lambda'")))
+		})
+
+		It("Fails with an invalid URL", func() {
+			GinkgoT().Setenv("ROSA_AWS_ENDPOINT_URL", "localhost")
+			Expect(Validate(nil)).To(MatchError("Invalid endpoint URL 'localhost' for AWS service 'default'"))
+		})
+	})
+
+	It("Parses and formats lists of endpoints", func() {
+		values, err := Parse("sts=http://localhost:4566, iam=http://localhost:4566,")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveLen(2))
+		Expect(Format(values)).To(Equal("iam=http://localhost:4566,sts=http://localhost:4566"))
+
+		_, err = Parse("iam")
+		Expect(err).To(HaveOccurred())
+	})
+})
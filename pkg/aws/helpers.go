@@ -426,6 +426,11 @@ func GetUserRoleName(prefix string, role string, userName string) string {
 	return awsCommonUtils.TruncateRoleName(name)
 }
 
+func GetOperatorRoleName(prefix string, namespace string, name string) string {
+	role := fmt.Sprintf("%s-%s-%s", prefix, namespace, name)
+	return awsCommonUtils.TruncateRoleName(role)
+}
+
 func GetOperatorPolicyName(prefix string, namespace string, name string) string {
 	policy := fmt.Sprintf("%s-%s-%s", prefix, namespace, name)
 	return awsCommonUtils.TruncateRoleName(policy)
@@ -0,0 +1,491 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to create customer managed KMS keys and to check that
+// their key policy grants the account and operator roles the permissions that clusters need.
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	// KMSKeyPurposeEBS is the purpose of keys used to encrypt the EBS volumes of the cluster
+	KMSKeyPurposeEBS = "ebs"
+	// KMSKeyPurposeEtcd is the purpose of keys used to encrypt etcd, only for hosted control planes
+	KMSKeyPurposeEtcd = "etcd"
+
+	// KMS only supports one key policy, and it is always named 'default'
+	kmsKeyPolicyName = "default"
+)
+
+var KMSKeyPurposes = []string{KMSKeyPurposeEBS, KMSKeyPurposeEtcd}
+
+// KMSKeyOperator identifies an operator whose role needs access to a customer managed key
+type KMSKeyOperator struct {
+	Namespace string
+	Name      string
+}
+
+func (o KMSKeyOperator) String() string {
+	return fmt.Sprintf("%s/%s", o.Namespace, o.Name)
+}
+
+var (
+	EBSCSIDriverOperator          = KMSKeyOperator{Namespace: "openshift-cluster-csi-drivers", Name: "ebs-cloud-credentials"}
+	KubeControllerManagerOperator = KMSKeyOperator{Namespace: "kube-system", Name: "kube-controller-manager"}
+	CAPAControllerManagerOperator = KMSKeyOperator{Namespace: "kube-system", Name: "capa-controller-manager"}
+	KMSProviderOperator           = KMSKeyOperator{Namespace: "kube-system", Name: "kms-provider"}
+)
+
+// KMSKeyRoles contains the ARNs of the roles that use a customer managed key. Roles with an empty
+// ARN are left out of the key policy.
+type KMSKeyRoles struct {
+	InstallerRoleARN    string
+	SupportRoleARN      string
+	ControlPlaneRoleARN string
+	WorkerRoleARN       string
+	OperatorRoleARNs    map[KMSKeyOperator]string
+}
+
+// KMSKeyGrant is a statement of the key policy, allowing a set of roles to perform some actions
+type KMSKeyGrant struct {
+	Sid       string
+	RoleARNs  []string
+	Actions   []string
+	Condition interface{}
+}
+
+var (
+	kmsUseActions = []string{
+		"kms:Encrypt",
+		"kms:Decrypt",
+		"kms:ReEncrypt*",
+		"kms:GenerateDataKey*",
+		"kms:DescribeKey",
+	}
+	kmsGrantActions = []string{
+		"kms:CreateGrant",
+		"kms:ListGrants",
+		"kms:RevokeGrant",
+	}
+	// Grants can only be created for the AWS services that attach the volumes to the instances
+	kmsGrantCondition = map[string]interface{}{
+		"Bool": map[string]interface{}{
+			"kms:GrantIsForAWSResource": "true",
+		},
+	}
+)
+
+// KMSKeyOperators returns the operators whose roles need access to a key with the given purpose
+func KMSKeyOperators(purpose string, hostedCP bool) []KMSKeyOperator {
+	switch {
+	case purpose == KMSKeyPurposeEtcd:
+		return []KMSKeyOperator{KMSProviderOperator}
+	case hostedCP:
+		return []KMSKeyOperator{KubeControllerManagerOperator, CAPAControllerManagerOperator, EBSCSIDriverOperator}
+	default:
+		return []KMSKeyOperator{EBSCSIDriverOperator}
+	}
+}
+
+// KMSKeyGrants returns the statements that the policy of a key with the given purpose needs so
+// that the roles of the cluster can use it
+func KMSKeyGrants(purpose string, hostedCP bool, roles KMSKeyRoles) []KMSKeyGrant {
+	operator := func(o KMSKeyOperator) string {
+		return roles.OperatorRoleARNs[o]
+	}
+	var grants []KMSKeyGrant
+	switch {
+	case purpose == KMSKeyPurposeEtcd:
+		grants = []KMSKeyGrant{
+			{
+				Sid:      "ROSA Installer Permissions",
+				RoleARNs: []string{roles.InstallerRoleARN},
+				Actions:  []string{"kms:DescribeKey"},
+			},
+			{
+				Sid:      "ROSA KMS Provider Permissions",
+				RoleARNs: []string{operator(KMSProviderOperator)},
+				Actions:  []string{"kms:Encrypt", "kms:Decrypt", "kms:DescribeKey"},
+			},
+		}
+	case hostedCP:
+		grants = []KMSKeyGrant{
+			{
+				Sid:      "ROSA Installer Permissions",
+				RoleARNs: []string{roles.InstallerRoleARN},
+				Actions:  []string{"kms:CreateGrant", "kms:DescribeKey", "kms:GenerateDataKeyWithoutPlaintext"},
+			},
+			{
+				Sid:      "ROSA Support Permissions",
+				RoleARNs: []string{roles.SupportRoleARN},
+				Actions:  []string{"kms:DescribeKey"},
+			},
+			{
+				Sid:      "ROSA Kube Controller Manager Permissions",
+				RoleARNs: []string{operator(KubeControllerManagerOperator)},
+				Actions:  []string{"kms:DescribeKey"},
+			},
+			{
+				Sid:      "ROSA Node Pool Management Permissions",
+				RoleARNs: []string{operator(CAPAControllerManagerOperator)},
+				Actions:  []string{"kms:DescribeKey", "kms:GenerateDataKeyWithoutPlaintext", "kms:CreateGrant"},
+			},
+			{
+				Sid:      "ROSA EBS CSI Driver Permissions",
+				RoleARNs: []string{operator(EBSCSIDriverOperator)},
+				Actions:  kmsUseActions,
+			},
+			{
+				Sid:       "ROSA EBS CSI Driver Attachment Permissions",
+				RoleARNs:  []string{operator(EBSCSIDriverOperator)},
+				Actions:   kmsGrantActions,
+				Condition: kmsGrantCondition,
+			},
+		}
+	default:
+		roleARNs := []string{
+			roles.InstallerRoleARN,
+			roles.SupportRoleARN,
+			roles.ControlPlaneRoleARN,
+			roles.WorkerRoleARN,
+			operator(EBSCSIDriverOperator),
+		}
+		grants = []KMSKeyGrant{
+			{
+				Sid:      "ROSA Use of the Key",
+				RoleARNs: roleARNs,
+				Actions:  kmsUseActions,
+			},
+			{
+				Sid:       "ROSA Attachment of Persistent Resources",
+				RoleARNs:  roleARNs,
+				Actions:   kmsGrantActions,
+				Condition: kmsGrantCondition,
+			},
+		}
+	}
+
+	// Leave out the roles that aren't known, and the statements that end up without roles:
+	result := make([]KMSKeyGrant, 0, len(grants))
+	for _, grant := range grants {
+		var roleARNs []string
+		for _, roleARN := range grant.RoleARNs {
+			if roleARN != "" {
+				roleARNs = append(roleARNs, roleARN)
+			}
+		}
+		if len(roleARNs) == 0 {
+			continue
+		}
+		grant.RoleARNs = roleARNs
+		result = append(result, grant)
+	}
+	return result
+}
+
+// BuildKMSKeyPolicy builds a key policy that gives the account full control of the key, so that it
+// can still be managed with IAM policies, and adds the given grants
+func BuildKMSKeyPolicy(partition string, accountID string, grants []KMSKeyGrant) *PolicyDocument {
+	policy := NewPolicyDocument()
+	policy.ID = "rosa-key-policy"
+	policy.Statement = append(policy.Statement, PolicyStatement{
+		Sid:    "Enable IAM User Permissions",
+		Effect: "Allow",
+		Principal: &PolicyStatementPrincipal{
+			AWS: fmt.Sprintf("arn:%s:iam::%s:root", partition, accountID),
+		},
+		Action:   "kms:*",
+		Resource: "*",
+	})
+	return AddKMSKeyGrants(policy, grants)
+}
+
+// AddKMSKeyGrants returns a copy of the given key policy with the statements of the grants. Existing
+// statements with the same identifiers are replaced, and the rest are preserved.
+func AddKMSKeyGrants(policy *PolicyDocument, grants []KMSKeyGrant) *PolicyDocument {
+	sids := map[string]bool{}
+	for _, grant := range grants {
+		sids[grant.Sid] = true
+	}
+	result := *policy
+	result.Statement = []PolicyStatement{}
+	for _, statement := range policy.Statement {
+		if !sids[statement.Sid] {
+			result.Statement = append(result.Statement, statement)
+		}
+	}
+	for _, grant := range grants {
+		result.Statement = append(result.Statement, PolicyStatement{
+			Sid:    grant.Sid,
+			Effect: "Allow",
+			Principal: &PolicyStatementPrincipal{
+				AWS: grant.RoleARNs,
+			},
+			Action:    grant.Actions,
+			Resource:  "*",
+			Condition: grant.Condition,
+		})
+	}
+	return &result
+}
+
+// MissingKMSKeyPermissions checks that the given key policy contains the grants, and returns a
+// description of the permissions that are missing for each role. Permissions that aren't granted
+// explicitly but may be granted by statements that can't be evaluated offline, like the default
+// statement that delegates to the IAM policies of the account or statements with conditions other
+// than the ones of the grants, are returned separately as unverified.
+func MissingKMSKeyPermissions(policy *PolicyDocument, grants []KMSKeyGrant) (missing []string,
+	unverified []string) {
+	for _, grant := range grants {
+		for _, roleARN := range grant.RoleARNs {
+			var missingActions, unverifiedActions []string
+			for _, action := range grant.Actions {
+				switch checkKMSAction(policy, roleARN, action, grant.Condition) {
+				case kmsActionDenied:
+					missingActions = append(missingActions, action)
+				case kmsActionUnverified:
+					unverifiedActions = append(unverifiedActions, action)
+				}
+			}
+			if len(missingActions) > 0 {
+				missing = append(missing, fmt.Sprintf("'%s' is missing %s", roleARN,
+					strings.Join(missingActions, ", ")))
+			}
+			if len(unverifiedActions) > 0 {
+				unverified = append(unverified, fmt.Sprintf("'%s' may be missing %s", roleARN,
+					strings.Join(unverifiedActions, ", ")))
+			}
+		}
+	}
+	return
+}
+
+type kmsActionResult int
+
+const (
+	kmsActionDenied kmsActionResult = iota
+	kmsActionAllowed
+	kmsActionUnverified
+)
+
+func checkKMSAction(policy *PolicyDocument, roleARN string, wanted string, condition interface{}) kmsActionResult {
+	allowed := false
+	unverified := false
+	for _, statement := range policy.Statement {
+		if !statementMatchesKMSAction(statement, wanted) {
+			continue
+		}
+		if statement.NotPrincipal != nil {
+			// Statements that apply to all the principals except some are uncommon, and evaluating
+			// them requires knowing all the principals of the account:
+			if statement.Effect == "Allow" && !helper.Contains(statement.NotPrincipal.GetAWSPrincipals(), roleARN) {
+				unverified = true
+			}
+			continue
+		}
+		principals := statement.GetAWSPrincipals()
+		applies := helper.Contains(principals, roleARN) || helper.Contains(principals, "*")
+		conditional := statement.Condition != nil && !sameCondition(statement.Condition, condition)
+		switch {
+		case statement.Effect == "Deny":
+			if applies && statement.Condition == nil {
+				return kmsActionDenied
+			}
+		case statement.Effect != "Allow":
+			continue
+		case applies && !conditional:
+			allowed = true
+		case applies || delegatesToAccount(principals, roleARN):
+			// The permission may be granted depending on the conditions, or by the IAM policies of
+			// the account, which aren't checked:
+			unverified = true
+		}
+	}
+	switch {
+	case allowed:
+		return kmsActionAllowed
+	case unverified:
+		return kmsActionUnverified
+	default:
+		return kmsActionDenied
+	}
+}
+
+func statementMatchesKMSAction(statement PolicyStatement, wanted string) bool {
+	for _, action := range statementActions(statement) {
+		matched, err := path.Match(strings.ToLower(action), strings.ToLower(wanted))
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// delegatesToAccount returns true if the principals include the root of the account of the role,
+// which means that the key policy allows the IAM policies of the account to grant access to the key
+func delegatesToAccount(principals []string, roleARN string) bool {
+	parts := strings.Split(roleARN, ":")
+	if len(parts) < 5 || parts[4] == "" {
+		return false
+	}
+	accountID := parts[4]
+	for _, principal := range principals {
+		if principal == accountID || principal == fmt.Sprintf("arn:%s:iam::%s:root", parts[1], accountID) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameCondition(a interface{}, b interface{}) bool {
+	if b == nil {
+		return false
+	}
+	first, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	second, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(first) == string(second)
+}
+
+func statementActions(statement PolicyStatement) []string {
+	switch action := statement.Action.(type) {
+	case string:
+		return []string{action}
+	case []string:
+		return action
+	case []interface{}:
+		actions := make([]string, 0, len(action))
+		for _, el := range action {
+			if a, ok := el.(string); ok {
+				actions = append(actions, a)
+			}
+		}
+		return actions
+	}
+	return nil
+}
+
+// KMSKeyAlias returns the alias of the key with the given purpose created for a cluster
+func KMSKeyAlias(clusterName string, purpose string) string {
+	return fmt.Sprintf("alias/rosa-%s-%s", clusterName, purpose)
+}
+
+// FindKMSKeyByAlias returns the ARN of the key with the given alias, or an empty string if there is
+// no such key
+func (c *awsClient) FindKMSKeyByAlias(alias string) (string, error) {
+	output, err := c.kmsClient.DescribeKey(context.Background(), &kms.DescribeKeyInput{
+		KeyId: aws.String(alias),
+	})
+	if err != nil {
+		var notFound *kmstypes.NotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return aws.ToString(output.KeyMetadata.Arn), nil
+}
+
+// CreateKMSKey creates a symmetric customer managed key with the given policy and tags, and
+// assigns it the alias. It returns the ARN of the new key.
+func (c *awsClient) CreateKMSKey(alias string, description string, policy *PolicyDocument,
+	tags map[string]string) (string, error) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	kmsTags := make([]kmstypes.Tag, 0, len(keys))
+	for _, key := range keys {
+		kmsTags = append(kmsTags, kmstypes.Tag{
+			TagKey:   aws.String(key),
+			TagValue: aws.String(tags[key]),
+		})
+	}
+	output, err := c.kmsClient.CreateKey(context.Background(), &kms.CreateKeyInput{
+		Description: aws.String(description),
+		KeySpec:     kmstypes.KeySpecSymmetricDefault,
+		KeyUsage:    kmstypes.KeyUsageTypeEncryptDecrypt,
+		Policy:      aws.String(policy.String()),
+		Tags:        kmsTags,
+	})
+	if err != nil {
+		return "", err
+	}
+	_, err = c.kmsClient.CreateAlias(context.Background(), &kms.CreateAliasInput{
+		AliasName:   aws.String(alias),
+		TargetKeyId: output.KeyMetadata.KeyId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("key '%s' was created, but failed to assign it alias '%s': %v",
+			aws.ToString(output.KeyMetadata.Arn), alias, err)
+	}
+	return aws.ToString(output.KeyMetadata.Arn), nil
+}
+
+// GetKMSKeyPolicy returns the policy of the given key
+func (c *awsClient) GetKMSKeyPolicy(keyID string) (*PolicyDocument, error) {
+	output, err := c.kmsClient.GetKeyPolicy(context.Background(), &kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyID),
+		PolicyName: aws.String(kmsKeyPolicyName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicyDocument(aws.ToString(output.Policy))
+}
+
+// PutKMSKeyPolicy replaces the policy of the given key
+func (c *awsClient) PutKMSKeyPolicy(keyID string, policy *PolicyDocument) error {
+	_, err := c.kmsClient.PutKeyPolicy(context.Background(), &kms.PutKeyPolicyInput{
+		KeyId:      aws.String(keyID),
+		PolicyName: aws.String(kmsKeyPolicyName),
+		Policy:     aws.String(policy.String()),
+	})
+	return err
+}
+
+// ValidateKMSKeyPolicy checks that the policy of the given key contains the grants, and returns
+// the permissions that are missing and the ones that can't be verified
+func (c *awsClient) ValidateKMSKeyPolicy(keyID string, grants []KMSKeyGrant) (missing []string,
+	unverified []string, err error) {
+	policy, err := c.GetKMSKeyPolicy(keyID)
+	if err != nil {
+		return
+	}
+	missing, unverified = MissingKMSKeyPermissions(policy, grants)
+	return
+}
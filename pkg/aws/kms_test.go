@@ -0,0 +1,284 @@
+package aws
+
+import (
+	"context"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("KMS", func() {
+	const (
+		installerARN = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+		supportARN   = "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role"
+		workerARN    = "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
+		csiARN       = "arn:aws:iam::123456789012:role/mycluster-openshift-cluster-csi-drivers-ebs-cloud-credent"
+		kmsARN       = "arn:aws:iam::123456789012:role/mycluster-kube-system-kms-provider"
+	)
+
+	Context("KMSKeyGrants", func() {
+		It("Grants the classic roles the use of the key", func() {
+			grants := KMSKeyGrants(KMSKeyPurposeEBS, false, KMSKeyRoles{
+				InstallerRoleARN: installerARN,
+				SupportRoleARN:   supportARN,
+				WorkerRoleARN:    workerARN,
+				OperatorRoleARNs: map[KMSKeyOperator]string{EBSCSIDriverOperator: csiARN},
+			})
+			Expect(grants).To(HaveLen(2))
+			Expect(grants[0].RoleARNs).To(Equal([]string{installerARN, supportARN, workerARN, csiARN}))
+			Expect(grants[0].Actions).To(ContainElement("kms:GenerateDataKey*"))
+			Expect(grants[1].Actions).To(ContainElement("kms:CreateGrant"))
+			Expect(grants[1].Condition).NotTo(BeNil())
+		})
+
+		It("Leaves out the statements of unknown roles", func() {
+			grants := KMSKeyGrants(KMSKeyPurposeEBS, true, KMSKeyRoles{
+				InstallerRoleARN: installerARN,
+				SupportRoleARN:   supportARN,
+			})
+			Expect(grants).To(HaveLen(2))
+			Expect(grants[0].Sid).To(Equal("ROSA Installer Permissions"))
+			Expect(grants[1].Sid).To(Equal("ROSA Support Permissions"))
+		})
+
+		It("Grants the KMS provider the use of etcd keys", func() {
+			grants := KMSKeyGrants(KMSKeyPurposeEtcd, true, KMSKeyRoles{
+				InstallerRoleARN: installerARN,
+				OperatorRoleARNs: map[KMSKeyOperator]string{KMSProviderOperator: kmsARN},
+			})
+			Expect(grants).To(HaveLen(2))
+			Expect(grants[1].RoleARNs).To(Equal([]string{kmsARN}))
+			Expect(grants[1].Actions).To(Equal([]string{"kms:Encrypt", "kms:Decrypt", "kms:DescribeKey"}))
+		})
+	})
+
+	Context("MissingKMSKeyPermissions", func() {
+		grants := KMSKeyGrants(KMSKeyPurposeEtcd, true, KMSKeyRoles{
+			InstallerRoleARN: installerARN,
+			OperatorRoleARNs: map[KMSKeyOperator]string{KMSProviderOperator: kmsARN},
+		})
+
+		It("Finds nothing missing in the generated policy", func() {
+			policy, err := ParsePolicyDocument(BuildKMSKeyPolicy("aws", "123456789012", grants).String())
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(BeEmpty())
+			Expect(unverified).To(BeEmpty())
+		})
+
+		It("Matches wildcard actions case insensitively", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": ["` + installerARN + `", "` + kmsARN + `"]},
+					"Action": "KMS:*",
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(BeEmpty())
+			Expect(unverified).To(BeEmpty())
+		})
+
+		It("Reports the missing permissions of each role", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "` + kmsARN + `"},
+					"Action": ["kms:Decrypt", "kms:DescribeKey"],
+					"Resource": "*"
+				}, {
+					"Effect": "Deny",
+					"Principal": {"AWS": "` + installerARN + `"},
+					"Action": "kms:DescribeKey",
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(Equal([]string{
+				"'" + installerARN + "' is missing kms:DescribeKey",
+				"'" + kmsARN + "' is missing kms:Encrypt",
+			}))
+			Expect(unverified).To(BeEmpty())
+		})
+
+		It("Can't verify the permissions delegated to the IAM policies of the account", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Sid": "Enable IAM User Permissions",
+					"Effect": "Allow",
+					"Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+					"Action": "kms:*",
+					"Resource": "*"
+				}, {
+					"Effect": "Allow",
+					"Principal": {"AWS": "` + kmsARN + `"},
+					"Action": ["kms:Decrypt", "kms:DescribeKey"],
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(BeEmpty())
+			Expect(unverified).To(Equal([]string{
+				"'" + installerARN + "' may be missing kms:DescribeKey",
+				"'" + kmsARN + "' may be missing kms:Encrypt",
+			}))
+		})
+
+		It("Doesn't trust the root of other accounts", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": ["arn:aws:iam::210987654321:root", "` + kmsARN + `"]},
+					"Action": "kms:*",
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(Equal([]string{"'" + installerARN + "' is missing kms:DescribeKey"}))
+			Expect(unverified).To(BeEmpty())
+		})
+
+		It("Reports explicitly denied permissions even if they are delegated to the account", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": ["arn:aws:iam::123456789012:root", "` + kmsARN + `"]},
+					"Action": "kms:*",
+					"Resource": "*"
+				}, {
+					"Effect": "Deny",
+					"Principal": {"AWS": "*"},
+					"Action": "kms:DescribeKey",
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, _ := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(Equal([]string{
+				"'" + installerARN + "' is missing kms:DescribeKey",
+				"'" + kmsARN + "' is missing kms:DescribeKey",
+			}))
+		})
+
+		It("Can't verify the permissions granted with other conditions or excluded principals", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "*"},
+					"Action": "kms:DescribeKey",
+					"Resource": "*",
+					"Condition": {"StringEquals": {"kms:CallerAccount": "123456789012"}}
+				}, {
+					"Effect": "Allow",
+					"NotPrincipal": {"AWS": "` + installerARN + `"},
+					"Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*"],
+					"Resource": "*"
+				}]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing, unverified := MissingKMSKeyPermissions(policy, grants)
+			Expect(missing).To(BeEmpty())
+			Expect(unverified).To(Equal([]string{
+				"'" + installerARN + "' may be missing kms:DescribeKey",
+				"'" + kmsARN + "' may be missing kms:Encrypt, kms:Decrypt, kms:DescribeKey",
+			}))
+		})
+	})
+
+	It("Replaces the ROSA statements and preserves the others when adding grants", func() {
+		policy := NewPolicyDocument()
+		policy.Statement = []PolicyStatement{
+			{Sid: "Custom", Effect: "Allow", Action: "kms:Decrypt", Resource: "*"},
+			{Sid: "ROSA Installer Permissions", Effect: "Allow", Action: "kms:Encrypt", Resource: "*"},
+		}
+		grants := KMSKeyGrants(KMSKeyPurposeEtcd, true, KMSKeyRoles{InstallerRoleARN: installerARN})
+		updated := AddKMSKeyGrants(policy, grants)
+		Expect(updated.Statement).To(HaveLen(2))
+		Expect(updated.Statement[0].Sid).To(Equal("Custom"))
+		Expect(updated.Statement[1].Sid).To(Equal("ROSA Installer Permissions"))
+		Expect(updated.Statement[1].Action).To(Equal([]string{"kms:DescribeKey"}))
+		Expect(policy.Statement).To(HaveLen(2))
+		Expect(policy.Statement[1].Action).To(Equal("kms:Encrypt"))
+	})
+
+	Context("Client", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockKMSAPI *mocks.MockKmsApiClient
+			client     Client
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKMSAPI = mocks.NewMockKmsApiClient(mockCtrl)
+			client = New(
+				awsSdk.Config{},
+				logrus.New(),
+				mocks.NewMockIamApiClient(mockCtrl),
+				mocks.NewMockEc2ApiClient(mockCtrl),
+				mocks.NewMockOrganizationsApiClient(mockCtrl),
+				mocks.NewMockS3ApiClient(mockCtrl),
+				mocks.NewMockSecretsManagerApiClient(mockCtrl),
+				mocks.NewMockStsApiClient(mockCtrl),
+				mocks.NewMockCloudFormationApiClient(mockCtrl),
+				mocks.NewMockServiceQuotasApiClient(mockCtrl),
+				mockKMSAPI,
+				&AccessKey{},
+				false,
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Returns an empty ARN when there is no key with the alias", func() {
+			mockKMSAPI.EXPECT().DescribeKey(gomock.Any(), gomock.Any()).Return(nil,
+				&kmstypes.NotFoundException{Message: awsSdk.String("Alias not found")})
+			keyARN, err := client.FindKMSKeyByAlias("alias/rosa-mycluster-ebs")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyARN).To(BeEmpty())
+		})
+
+		It("Creates the key with its policy, tags and alias", func() {
+			policy := NewPolicyDocument()
+			mockKMSAPI.EXPECT().CreateKey(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *kms.CreateKeyInput, _ ...func(*kms.Options)) (*kms.CreateKeyOutput, error) {
+					Expect(awsSdk.ToString(input.Policy)).To(Equal(policy.String()))
+					Expect(input.Tags).To(HaveLen(2))
+					Expect(awsSdk.ToString(input.Tags[0].TagKey)).To(Equal("a"))
+					return &kms.CreateKeyOutput{KeyMetadata: &kmstypes.KeyMetadata{
+						KeyId: awsSdk.String("1234"),
+						Arn:   awsSdk.String("arn:aws:kms:us-east-1:123456789012:key/1234"),
+					}}, nil
+				})
+			mockKMSAPI.EXPECT().CreateAlias(gomock.Any(), &kms.CreateAliasInput{
+				AliasName:   awsSdk.String("alias/rosa-mycluster-ebs"),
+				TargetKeyId: awsSdk.String("1234"),
+			}).Return(&kms.CreateAliasOutput{}, nil)
+			keyARN, err := client.CreateKMSKey("alias/rosa-mycluster-ebs", "test", policy,
+				map[string]string{"b": "2", "a": "1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyARN).To(Equal("arn:aws:kms:us-east-1:123456789012:key/1234"))
+		})
+	})
+})
@@ -132,6 +132,36 @@ func (mr *MockClientMockRecorder) CheckStackReadyOrNotExisting(stackName any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStackReadyOrNotExisting", reflect.TypeOf((*MockClient)(nil).CheckStackReadyOrNotExisting), stackName)
 }
 
+// CreateKMSKey mocks base method.
+func (m *MockClient) CreateKMSKey(alias, description string, policy *PolicyDocument, tags map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKMSKey", alias, description, policy, tags)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKMSKey indicates an expected call of CreateKMSKey.
+func (mr *MockClientMockRecorder) CreateKMSKey(alias, description, policy, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKMSKey", reflect.TypeOf((*MockClient)(nil).CreateKMSKey), alias, description, policy, tags)
+}
+
+// CreateNetworkStack mocks base method.
+func (m *MockClient) CreateNetworkStack(stackName string, params, tagList map[string]string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNetworkStack", stackName, params, tagList)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNetworkStack indicates an expected call of CreateNetworkStack.
+func (mr *MockClientMockRecorder) CreateNetworkStack(stackName, params, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkStack", reflect.TypeOf((*MockClient)(nil).CreateNetworkStack), stackName, params, tagList)
+}
+
 // CreateOpenIDConnectProvider mocks base method.
 func (m *MockClient) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -204,6 +234,20 @@ func (mr *MockClientMockRecorder) DeleteInlineRolePolicies(roleName any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInlineRolePolicies", reflect.TypeOf((*MockClient)(nil).DeleteInlineRolePolicies), roleName)
 }
 
+// DeleteNetworkStack mocks base method.
+func (m *MockClient) DeleteNetworkStack(stackName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkStack", stackName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkStack indicates an expected call of DeleteNetworkStack.
+func (mr *MockClientMockRecorder) DeleteNetworkStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkStack", reflect.TypeOf((*MockClient)(nil).DeleteNetworkStack), stackName)
+}
+
 // DeleteOCMRole mocks base method.
 func (m *MockClient) DeleteOCMRole(roleARN string, managedPolicies bool) error {
 	m.ctrl.T.Helper()
@@ -260,6 +304,20 @@ func (mr *MockClientMockRecorder) DeleteOsdCcsAdminUser(stackName any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOsdCcsAdminUser", reflect.TypeOf((*MockClient)(nil).DeleteOsdCcsAdminUser), stackName)
 }
 
+// DeletePolicy mocks base method.
+func (m *MockClient) DeletePolicy(policyArn string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", policyArn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockClientMockRecorder) DeletePolicy(policyArn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockClient)(nil).DeletePolicy), policyArn)
+}
+
 // DeleteS3Bucket mocks base method.
 func (m *MockClient) DeleteS3Bucket(bucketName string) error {
 	m.ctrl.T.Helper()
@@ -376,6 +434,21 @@ func (mr *MockClientMockRecorder) EnsureRole(name, policy, permissionsBoundary,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRole", reflect.TypeOf((*MockClient)(nil).EnsureRole), name, policy, permissionsBoundary, version, tagList, path, managedPolicies)
 }
 
+// EnsureSharedVPCStack mocks base method.
+func (m *MockClient) EnsureSharedVPCStack(stackName string, params, tagList map[string]string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureSharedVPCStack", stackName, params, tagList)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureSharedVPCStack indicates an expected call of EnsureSharedVPCStack.
+func (mr *MockClientMockRecorder) EnsureSharedVPCStack(stackName, params, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSharedVPCStack", reflect.TypeOf((*MockClient)(nil).EnsureSharedVPCStack), stackName, params, tagList)
+}
+
 // FetchPublicSubnetMap mocks base method.
 func (m *MockClient) FetchPublicSubnetMap(subnets []types.Subnet) (map[string]bool, error) {
 	m.ctrl.T.Helper()
@@ -406,6 +479,21 @@ func (mr *MockClientMockRecorder) FilterVPCsPrivateSubnets(subnets any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterVPCsPrivateSubnets", reflect.TypeOf((*MockClient)(nil).FilterVPCsPrivateSubnets), subnets)
 }
 
+// FindKMSKeyByAlias mocks base method.
+func (m *MockClient) FindKMSKeyByAlias(alias string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindKMSKeyByAlias", alias)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindKMSKeyByAlias indicates an expected call of FindKMSKeyByAlias.
+func (mr *MockClientMockRecorder) FindKMSKeyByAlias(alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindKMSKeyByAlias", reflect.TypeOf((*MockClient)(nil).FindKMSKeyByAlias), alias)
+}
+
 // FindPolicyARN mocks base method.
 func (m *MockClient) FindPolicyARN(operator Operator, version string) (string, error) {
 	m.ctrl.T.Helper()
@@ -706,6 +794,21 @@ func (mr *MockClientMockRecorder) GetInstanceProfilesForRole(role any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProfilesForRole", reflect.TypeOf((*MockClient)(nil).GetInstanceProfilesForRole), role)
 }
 
+// GetKMSKeyPolicy mocks base method.
+func (m *MockClient) GetKMSKeyPolicy(keyID string) (*PolicyDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKMSKeyPolicy", keyID)
+	ret0, _ := ret[0].(*PolicyDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKMSKeyPolicy indicates an expected call of GetKMSKeyPolicy.
+func (mr *MockClientMockRecorder) GetKMSKeyPolicy(keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).GetKMSKeyPolicy), keyID)
+}
+
 // GetLocalAWSAccessKeys mocks base method.
 func (m *MockClient) GetLocalAWSAccessKeys() (*AccessKey, error) {
 	m.ctrl.T.Helper()
@@ -1189,6 +1292,21 @@ func (mr *MockClientMockRecorder) ListSubnets(subnetIds ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnets", reflect.TypeOf((*MockClient)(nil).ListSubnets), subnetIds...)
 }
 
+// ListUnattachedOperatorPolicies mocks base method.
+func (m *MockClient) ListUnattachedOperatorPolicies() ([]PolicyDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnattachedOperatorPolicies")
+	ret0, _ := ret[0].([]PolicyDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnattachedOperatorPolicies indicates an expected call of ListUnattachedOperatorPolicies.
+func (mr *MockClientMockRecorder) ListUnattachedOperatorPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnattachedOperatorPolicies", reflect.TypeOf((*MockClient)(nil).ListUnattachedOperatorPolicies))
+}
+
 // ListUserRoles mocks base method.
 func (m *MockClient) ListUserRoles() ([]Role, error) {
 	m.ctrl.T.Helper()
@@ -1204,6 +1322,20 @@ func (mr *MockClientMockRecorder) ListUserRoles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockClient)(nil).ListUserRoles))
 }
 
+// PutKMSKeyPolicy mocks base method.
+func (m *MockClient) PutKMSKeyPolicy(keyID string, policy *PolicyDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutKMSKeyPolicy", keyID, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutKMSKeyPolicy indicates an expected call of PutKMSKeyPolicy.
+func (mr *MockClientMockRecorder) PutKMSKeyPolicy(keyID, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).PutKMSKeyPolicy), keyID, policy)
+}
+
 // PutPublicReadObjectInS3Bucket mocks base method.
 func (m *MockClient) PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error {
 	m.ctrl.T.Helper()
@@ -1232,6 +1364,51 @@ func (mr *MockClientMockRecorder) PutRolePolicy(roleName, policyName, policy any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockClient)(nil).PutRolePolicy), roleName, policyName, policy)
 }
 
+// RequestQuotaIncrease mocks base method.
+func (m *MockClient) RequestQuotaIncrease(serviceCode, quotaCode string, value float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestQuotaIncrease", serviceCode, quotaCode, value)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestQuotaIncrease indicates an expected call of RequestQuotaIncrease.
+func (mr *MockClientMockRecorder) RequestQuotaIncrease(serviceCode, quotaCode, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestQuotaIncrease", reflect.TypeOf((*MockClient)(nil).RequestQuotaIncrease), serviceCode, quotaCode, value)
+}
+
+// SimulateRolePermissions mocks base method.
+func (m *MockClient) SimulateRolePermissions(roleARN string, actions []string) ([]SimulatedAction, []SimulatedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateRolePermissions", roleARN, actions)
+	ret0, _ := ret[0].([]SimulatedAction)
+	ret1, _ := ret[1].([]SimulatedAction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SimulateRolePermissions indicates an expected call of SimulateRolePermissions.
+func (mr *MockClientMockRecorder) SimulateRolePermissions(roleARN, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateRolePermissions", reflect.TypeOf((*MockClient)(nil).SimulateRolePermissions), roleARN, actions)
+}
+
+// TagSubnets mocks base method.
+func (m *MockClient) TagSubnets(tagsBySubnet map[string][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagSubnets", tagsBySubnet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagSubnets indicates an expected call of TagSubnets.
+func (mr *MockClientMockRecorder) TagSubnets(tagsBySubnet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagSubnets", reflect.TypeOf((*MockClient)(nil).TagSubnets), tagsBySubnet)
+}
+
 // TagUserRegion mocks base method.
 func (m *MockClient) TagUserRegion(username, region string) error {
 	m.ctrl.T.Helper()
@@ -1318,6 +1495,22 @@ func (mr *MockClientMockRecorder) ValidateHCPAccountRolesManagedPolicies(prefix,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHCPAccountRolesManagedPolicies", reflect.TypeOf((*MockClient)(nil).ValidateHCPAccountRolesManagedPolicies), prefix, policies)
 }
 
+// ValidateKMSKeyPolicy mocks base method.
+func (m *MockClient) ValidateKMSKeyPolicy(keyID string, grants []KMSKeyGrant) ([]string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateKMSKeyPolicy", keyID, grants)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ValidateKMSKeyPolicy indicates an expected call of ValidateKMSKeyPolicy.
+func (mr *MockClientMockRecorder) ValidateKMSKeyPolicy(keyID, grants any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).ValidateKMSKeyPolicy), keyID, grants)
+}
+
 // ValidateOperatorRolesManagedPolicies mocks base method.
 func (m *MockClient) ValidateOperatorRolesManagedPolicies(cluster *v1.Cluster, operatorRoles map[string]*v1.STSOperator, policies map[string]*v1.AWSSTSPolicy, hostedCPPolicies bool) error {
 	m.ctrl.T.Helper()
@@ -1390,6 +1583,36 @@ func (mr *MockClientMockRecorder) ValidateSCP(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSCP", reflect.TypeOf((*MockClient)(nil).ValidateSCP), arg0, arg1)
 }
 
+// ValidateSubnets mocks base method.
+func (m *MockClient) ValidateSubnets(topology *ClusterTopology, privateLink bool, accountID string, subnets []types.Subnet) (*SubnetReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSubnets", topology, privateLink, accountID, subnets)
+	ret0, _ := ret[0].(*SubnetReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateSubnets indicates an expected call of ValidateSubnets.
+func (mr *MockClientMockRecorder) ValidateSubnets(topology, privateLink, accountID, subnets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSubnets", reflect.TypeOf((*MockClient)(nil).ValidateSubnets), topology, privateLink, accountID, subnets)
+}
+
+// VerifyCapacity mocks base method.
+func (m *MockClient) VerifyCapacity(topology *ClusterTopology) ([]CapacityCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCapacity", topology)
+	ret0, _ := ret[0].([]CapacityCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyCapacity indicates an expected call of VerifyCapacity.
+func (mr *MockClientMockRecorder) VerifyCapacity(topology any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCapacity", reflect.TypeOf((*MockClient)(nil).VerifyCapacity), topology)
+}
+
 // MockAccessKeyGetter is a mock of AccessKeyGetter interface.
 type MockAccessKeyGetter struct {
 	ctrl     *gomock.Controller
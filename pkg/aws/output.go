@@ -0,0 +1,30 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"github.com/openshift/rosa/pkg/output"
+)
+
+// Registers the printers of the types that the commands print with the '--output' flag
+func init() {
+	output.RegisterJSON[[]Role]()
+	output.RegisterJSON[map[string][]Role]()
+	output.RegisterJSON[[]OidcProviderOutput]()
+	output.RegisterJSON[[]OperatorRoleDetail]()
+	output.RegisterJSON[map[string][]OperatorRoleDetail]()
+}
@@ -3,10 +3,16 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 // SimulateParams captures any additional details that should be used
@@ -79,3 +85,104 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 
 	return true, nil
 }
+
+// Maximum number of actions simulated in each request to the IAM policy simulator
+const maxSimulatedActions = 100
+
+// SimulatedAction is an action that a role requires and that the IAM policy simulator denies or
+// can't verify, with the reason
+type SimulatedAction struct {
+	Action string
+	Reason string
+}
+
+// PolicyActions returns the sorted actions allowed by a policy document. Actions with wildcards
+// are returned separately as not verified, because the policy simulator can only evaluate
+// concrete actions.
+func PolicyActions(document string) ([]string, []SimulatedAction, error) {
+	policy, err := ParsePolicyDocument(document)
+	if err != nil {
+		return nil, nil, err
+	}
+	actions := map[string]bool{}
+	wildcards := map[string]bool{}
+	for _, action := range policy.GetAllowedActions() {
+		if strings.Contains(action, "*") {
+			wildcards[action] = true
+		} else {
+			actions[action] = true
+		}
+	}
+	result := helper.MapKeys(actions)
+	sort.Strings(result)
+	wildcardActions := helper.MapKeys(wildcards)
+	sort.Strings(wildcardActions)
+	unverified := make([]SimulatedAction, len(wildcardActions))
+	for i, action := range wildcardActions {
+		unverified[i] = SimulatedAction{Action: action, Reason: "contains a wildcard"}
+	}
+	return result, unverified, nil
+}
+
+// SimulateRolePermissions runs the IAM policy simulator for the given role and actions and
+// returns the ones it denies. The simulation takes into account the policies attached to the
+// role, its permissions boundary and the service control policies of the organization. Actions
+// that depend on conditions that need context values are returned separately as not verified,
+// as the simulator can't evaluate them.
+func (c *awsClient) SimulateRolePermissions(roleARN string,
+	actions []string) ([]SimulatedAction, []SimulatedAction, error) {
+	denied := []SimulatedAction{}
+	unverified := []SimulatedAction{}
+	for _, chunk := range helper.ChunkSlice(actions, maxSimulatedActions) {
+		input := &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(roleARN),
+			ActionNames:     chunk,
+		}
+		if region := c.GetRegion(); region != "" {
+			input.ContextEntries = []iamtypes.ContextEntry{{
+				ContextKeyName:   aws.String("aws:RequestedRegion"),
+				ContextKeyType:   iamtypes.ContextKeyTypeEnumStringList,
+				ContextKeyValues: []string{region},
+			}}
+		}
+		paginator := iam.NewSimulatePrincipalPolicyPaginator(c.iamClient, input)
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(context.Background())
+			if err != nil {
+				return nil, nil, fmt.Errorf("Failed to simulate the policies of role '%s': %v", roleARN, err)
+			}
+			for _, result := range output.EvaluationResults {
+				if result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed {
+					continue
+				}
+				if len(result.MissingContextValues) > 0 {
+					unverified = append(unverified, SimulatedAction{
+						Action: aws.ToString(result.EvalActionName),
+						Reason: fmt.Sprintf("depends on the condition keys %s",
+							strings.Join(result.MissingContextValues, ", ")),
+					})
+					continue
+				}
+				denied = append(denied, SimulatedAction{
+					Action: aws.ToString(result.EvalActionName),
+					Reason: denialReason(result),
+				})
+			}
+		}
+	}
+	return denied, unverified, nil
+}
+
+func denialReason(result iamtypes.EvaluationResult) string {
+	switch {
+	case result.OrganizationsDecisionDetail != nil && !result.OrganizationsDecisionDetail.AllowedByOrganizations:
+		return "denied by a service control policy"
+	case result.PermissionsBoundaryDecisionDetail != nil &&
+		!result.PermissionsBoundaryDecisionDetail.AllowedByPermissionsBoundary:
+		return "denied by the permissions boundary"
+	case result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeExplicitDeny:
+		return "explicitly denied"
+	default:
+		return "not allowed by the attached policies"
+	}
+}
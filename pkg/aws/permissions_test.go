@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"context"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Permissions", func() {
+	Context("PolicyActions", func() {
+		It("Returns the sorted allowed actions and the wildcards as not verified", func() {
+			actions, unverified, err := PolicyActions(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:Describe*"], "Resource": "*"},
+					{"Effect": "Allow", "Action": "ec2:CreateTags", "Resource": "*"},
+					{"Effect": "Deny", "Action": "iam:CreateUser", "Resource": "*"},
+					{"Effect": "Allow", "Action": ["ec2:RunInstances"], "Resource": "*"}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(Equal([]string{"ec2:CreateTags", "ec2:RunInstances"}))
+			Expect(unverified).To(Equal([]SimulatedAction{
+				{Action: "ec2:Describe*", Reason: "contains a wildcard"},
+			}))
+		})
+
+		It("Fails with an invalid document", func() {
+			_, _, err := PolicyActions("{")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("SimulateRolePermissions", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockIamAPI *mocks.MockIamApiClient
+			client     Client
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockIamAPI = mocks.NewMockIamApiClient(mockCtrl)
+			client = New(
+				awsSdk.Config{},
+				logrus.New(),
+				mockIamAPI,
+				mocks.NewMockEc2ApiClient(mockCtrl),
+				mocks.NewMockOrganizationsApiClient(mockCtrl),
+				mocks.NewMockS3ApiClient(mockCtrl),
+				mocks.NewMockSecretsManagerApiClient(mockCtrl),
+				mocks.NewMockStsApiClient(mockCtrl),
+				mocks.NewMockCloudFormationApiClient(mockCtrl),
+				mocks.NewMockServiceQuotasApiClient(mockCtrl),
+				mocks.NewMockKmsApiClient(mockCtrl),
+				&AccessKey{},
+				false,
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Reports the denied actions and the reason", func() {
+			roleARN := "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+			mockIamAPI.EXPECT().SimulatePrincipalPolicy(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *iam.SimulatePrincipalPolicyInput,
+					_ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+					Expect(awsSdk.ToString(input.PolicySourceArn)).To(Equal(roleARN))
+					Expect(input.ActionNames).To(HaveLen(5))
+					return &iam.SimulatePrincipalPolicyOutput{
+						EvaluationResults: []iamtypes.EvaluationResult{
+							{
+								EvalActionName: awsSdk.String("ec2:RunInstances"),
+								EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeAllowed,
+							},
+							{
+								EvalActionName: awsSdk.String("ec2:CreateTags"),
+								EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+								OrganizationsDecisionDetail: &iamtypes.OrganizationsDecisionDetail{
+									AllowedByOrganizations: false,
+								},
+							},
+							{
+								EvalActionName: awsSdk.String("iam:PassRole"),
+								EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+								PermissionsBoundaryDecisionDetail: &iamtypes.PermissionsBoundaryDecisionDetail{
+									AllowedByPermissionsBoundary: false,
+								},
+							},
+							{
+								EvalActionName: awsSdk.String("s3:CreateBucket"),
+								EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeExplicitDeny,
+							},
+							{
+								EvalActionName:       awsSdk.String("ec2:DeleteVolume"),
+								EvalDecision:         iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+								MissingContextValues: []string{"ec2:ResourceTag/red-hat-managed"},
+							},
+						},
+					}, nil
+				})
+
+			denied, unverified, err := client.SimulateRolePermissions(roleARN, []string{
+				"ec2:CreateTags", "ec2:DeleteVolume", "ec2:RunInstances", "iam:PassRole", "s3:CreateBucket",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(Equal([]SimulatedAction{
+				{Action: "ec2:CreateTags", Reason: "denied by a service control policy"},
+				{Action: "iam:PassRole", Reason: "denied by the permissions boundary"},
+				{Action: "s3:CreateBucket", Reason: "explicitly denied"},
+			}))
+			Expect(unverified).To(Equal([]SimulatedAction{
+				{Action: "ec2:DeleteVolume", Reason: "depends on the condition keys ec2:ResourceTag/red-hat-managed"},
+			}))
+		})
+	})
+})
@@ -72,12 +72,15 @@ type OperatorRoleDetail struct {
 	ClusterID         string   `json:"ClusterID,omitempty"`
 	AttachedPolicies  []string `json:"Policy,omitempty"`
 	ManagedPolicy     bool     `json:"ManagedPolicy,omitempty"`
+	// RedHatManaged indicates if the role has the tag that marks it as created by rosa
+	RedHatManaged bool `json:"-"`
 }
 
 type PolicyDetail struct {
 	PolicyName string
 	PolicyArn  string
 	PolicyType string
+	RolePrefix string
 }
 
 type Policy struct {
@@ -905,6 +908,9 @@ func (c *awsClient) ListOperatorRoles(version string,
 
 			case tags.OperatorNamespace:
 				operatorRole.OperatorNamespace = *tag.Value
+
+			case tags.RedHatManaged:
+				operatorRole.RedHatManaged = aws.ToString(tag.Value) == tags.True
 			}
 		}
 
@@ -977,6 +983,61 @@ func (c *awsClient) ListOperatorRoles(version string,
 	return operatorMap, nil
 }
 
+// ListUnattachedOperatorPolicies returns the ROSA operator policies of the account that aren't
+// attached to any role, along with the account role prefix they were created with.
+func (c *awsClient) ListUnattachedOperatorPolicies() ([]PolicyDetail, error) {
+	policies := []PolicyDetail{}
+	paginator := iam.NewListPoliciesPaginator(c.iamClient, &iam.ListPoliciesInput{
+		Scope: iamtypes.PolicyScopeTypeLocal,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return policies, err
+		}
+		for _, policy := range output.Policies {
+			if aws.ToInt32(policy.AttachmentCount) > 0 {
+				continue
+			}
+			listPolicyTagsOutput, err := c.iamClient.ListPolicyTags(context.Background(),
+				&iam.ListPolicyTagsInput{
+					PolicyArn: policy.Arn,
+				})
+			if err != nil {
+				return policies, err
+			}
+			isOperatorPolicy := false
+			isRedHatManaged := false
+			prefix := ""
+			for _, tag := range listPolicyTagsOutput.Tags {
+				switch aws.ToString(tag.Key) {
+				case tags.OperatorNamespace:
+					isOperatorPolicy = true
+				case tags.RedHatManaged:
+					isRedHatManaged = aws.ToString(tag.Value) == tags.True
+				case tags.RolePrefix:
+					prefix = aws.ToString(tag.Value)
+				}
+			}
+			if !isOperatorPolicy || !isRedHatManaged {
+				continue
+			}
+			policies = append(policies, PolicyDetail{
+				PolicyName: aws.ToString(policy.PolicyName),
+				PolicyArn:  aws.ToString(policy.Arn),
+				RolePrefix: prefix,
+			})
+		}
+	}
+	return policies, nil
+}
+
+// DeletePolicy deletes the given policy and all its versions, unless it is attached to a role
+func (c *awsClient) DeletePolicy(policyArn string) error {
+	_, err := c.deletePolicies([]string{policyArn})
+	return err
+}
+
 // Check if it is one of the ROSA account roles
 func checkIfAccountRole(roleName *string) bool {
 	for _, prefix := range AccountRoles {
@@ -0,0 +1,328 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	DiffFormatUnified = "unified"
+	DiffFormatJSON    = "json"
+)
+
+var DiffFormats = []string{DiffFormatUnified, DiffFormatJSON}
+
+const (
+	StatementAdded     = "added"
+	StatementRemoved   = "removed"
+	StatementChanged   = "changed"
+	StatementUnchanged = "unchanged"
+)
+
+// PolicyDiff describes the changes that an upgrade applies to the document of a policy
+type PolicyDiff struct {
+	Name       string          `json:"name"`
+	PolicyARN  string          `json:"policy_arn"`
+	Statements []StatementDiff `json:"statements"`
+}
+
+// StatementDiff describes the changes of a single statement of a policy document. Statements are
+// matched by their identifier, or by their position when they don't have one.
+type StatementDiff struct {
+	Sid                 string                    `json:"sid"`
+	Status              string                    `json:"status"`
+	Effect              string                    `json:"effect"`
+	EffectBefore        string                    `json:"effect_before,omitempty"`
+	AddedActions        []string                  `json:"added_actions,omitempty"`
+	RemovedActions      []string                  `json:"removed_actions,omitempty"`
+	AddedNotActions     []string                  `json:"added_not_actions,omitempty"`
+	RemovedNotActions   []string                  `json:"removed_not_actions,omitempty"`
+	AddedResources      []string                  `json:"added_resources,omitempty"`
+	RemovedResources    []string                  `json:"removed_resources,omitempty"`
+	AddedNotResources   []string                  `json:"added_not_resources,omitempty"`
+	RemovedNotResources []string                  `json:"removed_not_resources,omitempty"`
+	PrincipalBefore     *PolicyStatementPrincipal `json:"principal_before,omitempty"`
+	PrincipalAfter      *PolicyStatementPrincipal `json:"principal_after,omitempty"`
+	NotPrincipalBefore  *PolicyStatementPrincipal `json:"not_principal_before,omitempty"`
+	NotPrincipalAfter   *PolicyStatementPrincipal `json:"not_principal_after,omitempty"`
+	ConditionBefore     interface{}               `json:"condition_before,omitempty"`
+	ConditionAfter      interface{}               `json:"condition_after,omitempty"`
+}
+
+// DiffPolicyDocuments compares the current document of a policy with the desired one, statement
+// by statement. An empty current document means that the policy doesn't exist yet.
+func DiffPolicyDocuments(name string, policyARN string, current string, desired string) (*PolicyDiff, error) {
+	currentDoc := &PolicyDocument{}
+	if strings.TrimSpace(current) != "" {
+		var err error
+		currentDoc, err = ParsePolicyDocument(current)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse current document of policy '%s': %v", name, err)
+		}
+	}
+	desiredDoc, err := ParsePolicyDocument(desired)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse desired document of policy '%s': %v", name, err)
+	}
+
+	diff := &PolicyDiff{
+		Name:       name,
+		PolicyARN:  policyARN,
+		Statements: []StatementDiff{},
+	}
+	currentStatements := indexStatements(currentDoc.Statement)
+	matched := map[string]bool{}
+	for i, statement := range desiredDoc.Statement {
+		key := statementKey(statement, i)
+		before, ok := currentStatements[key]
+		if !ok {
+			before = PolicyStatement{}
+		}
+		matched[key] = true
+		diff.Statements = append(diff.Statements, diffStatements(key, ok, true, before, statement))
+	}
+	for i, statement := range currentDoc.Statement {
+		key := statementKey(statement, i)
+		if matched[key] {
+			continue
+		}
+		diff.Statements = append(diff.Statements, diffStatements(key, true, false, statement, PolicyStatement{}))
+	}
+	return diff, nil
+}
+
+// HasChanges returns true if any of the statements of the policy changes
+func (d *PolicyDiff) HasChanges() bool {
+	for _, statement := range d.Statements {
+		if statement.Status != StatementUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// Unified renders the changes in a format similar to a unified diff, where added permissions are
+// prefixed with '+' and removed permissions with '-'. Unchanged statements are omitted.
+func (d *PolicyDiff) Unified() string {
+	var b strings.Builder
+	current := d.PolicyARN
+	if current == "" {
+		current = d.Name
+	}
+	fmt.Fprintf(&b, "--- %s (current)\n", current)
+	fmt.Fprintf(&b, "+++ %s (upgrade)\n", current)
+	for _, statement := range d.Statements {
+		if statement.Status == StatementUnchanged {
+			continue
+		}
+		fmt.Fprintf(&b, "@@ Statement '%s' (%s) %s @@\n", statement.Sid, statement.Effect, statement.Status)
+		if statement.EffectBefore != "" {
+			fmt.Fprintf(&b, "-  Effect: %s\n", statement.EffectBefore)
+			fmt.Fprintf(&b, "+  Effect: %s\n", statement.Effect)
+		}
+		writeLines(&b, "Action", statement.RemovedActions, statement.AddedActions)
+		writeLines(&b, "NotAction", statement.RemovedNotActions, statement.AddedNotActions)
+		writeLines(&b, "Resource", statement.RemovedResources, statement.AddedResources)
+		writeLines(&b, "NotResource", statement.RemovedNotResources, statement.AddedNotResources)
+		if statement.PrincipalBefore != nil {
+			fmt.Fprintf(&b, "-  Principal: %s\n", compactJSON(statement.PrincipalBefore))
+		}
+		if statement.PrincipalAfter != nil {
+			fmt.Fprintf(&b, "+  Principal: %s\n", compactJSON(statement.PrincipalAfter))
+		}
+		if statement.NotPrincipalBefore != nil {
+			fmt.Fprintf(&b, "-  NotPrincipal: %s\n", compactJSON(statement.NotPrincipalBefore))
+		}
+		if statement.NotPrincipalAfter != nil {
+			fmt.Fprintf(&b, "+  NotPrincipal: %s\n", compactJSON(statement.NotPrincipalAfter))
+		}
+		if statement.ConditionBefore != nil {
+			fmt.Fprintf(&b, "-  Condition: %s\n", compactJSON(statement.ConditionBefore))
+		}
+		if statement.ConditionAfter != nil {
+			fmt.Fprintf(&b, "+  Condition: %s\n", compactJSON(statement.ConditionAfter))
+		}
+	}
+	return b.String()
+}
+
+// writeLines writes a line prefixed with '-' for each removed value of the given element, and a
+// line prefixed with '+' for each added one
+func writeLines(b *strings.Builder, element string, removed []string, added []string) {
+	for _, value := range removed {
+		fmt.Fprintf(b, "-  %s: %s\n", element, value)
+	}
+	for _, value := range added {
+		fmt.Fprintf(b, "+  %s: %s\n", element, value)
+	}
+}
+
+// FormatPolicyDiffs renders the given diffs in the unified or JSON format. Policies without
+// changes are omitted.
+func FormatPolicyDiffs(diffs []*PolicyDiff, format string) (string, error) {
+	changed := []*PolicyDiff{}
+	for _, diff := range diffs {
+		if diff.HasChanges() {
+			changed = append(changed, diff)
+		}
+	}
+	switch format {
+	case DiffFormatUnified:
+		result := make([]string, len(changed))
+		for i, diff := range changed {
+			result[i] = diff.Unified()
+		}
+		return strings.Join(result, "\n"), nil
+	case DiffFormatJSON:
+		data, err := json.MarshalIndent(changed, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("Invalid diff format '%s'. Allowed values are %s", format, DiffFormats)
+	}
+}
+
+func statementKey(statement PolicyStatement, index int) string {
+	if statement.Sid != "" {
+		return statement.Sid
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+func indexStatements(statements []PolicyStatement) map[string]PolicyStatement {
+	result := map[string]PolicyStatement{}
+	for i, statement := range statements {
+		result[statementKey(statement, i)] = statement
+	}
+	return result
+}
+
+func diffStatements(key string, hasBefore bool, hasAfter bool, before PolicyStatement,
+	after PolicyStatement) StatementDiff {
+	diff := StatementDiff{
+		Sid:    key,
+		Effect: after.Effect,
+	}
+	if !hasAfter {
+		diff.Effect = before.Effect
+	}
+	if hasBefore && hasAfter && before.Effect != after.Effect {
+		diff.EffectBefore = before.Effect
+	}
+	diff.AddedActions, diff.RemovedActions = diffStrings(toStrings(before.Action), toStrings(after.Action))
+	diff.AddedNotActions, diff.RemovedNotActions = diffStrings(toStrings(before.NotAction),
+		toStrings(after.NotAction))
+	diff.AddedResources, diff.RemovedResources = diffStrings(toStrings(before.Resource), toStrings(after.Resource))
+	diff.AddedNotResources, diff.RemovedNotResources = diffStrings(toStrings(before.NotResource),
+		toStrings(after.NotResource))
+	if !reflect.DeepEqual(before.Principal, after.Principal) {
+		diff.PrincipalBefore = before.Principal
+		diff.PrincipalAfter = after.Principal
+	}
+	if !reflect.DeepEqual(before.NotPrincipal, after.NotPrincipal) {
+		diff.NotPrincipalBefore = before.NotPrincipal
+		diff.NotPrincipalAfter = after.NotPrincipal
+	}
+	if !reflect.DeepEqual(before.Condition, after.Condition) {
+		diff.ConditionBefore = before.Condition
+		diff.ConditionAfter = after.Condition
+	}
+	switch {
+	case !hasBefore:
+		diff.Status = StatementAdded
+	case !hasAfter:
+		diff.Status = StatementRemoved
+	case diff.hasChanges():
+		diff.Status = StatementChanged
+	default:
+		diff.Status = StatementUnchanged
+	}
+	return diff
+}
+
+// hasChanges returns true if any of the elements of the statement changes
+func (d *StatementDiff) hasChanges() bool {
+	return d.EffectBefore != "" ||
+		len(d.AddedActions) > 0 || len(d.RemovedActions) > 0 ||
+		len(d.AddedNotActions) > 0 || len(d.RemovedNotActions) > 0 ||
+		len(d.AddedResources) > 0 || len(d.RemovedResources) > 0 ||
+		len(d.AddedNotResources) > 0 || len(d.RemovedNotResources) > 0 ||
+		d.PrincipalBefore != nil || d.PrincipalAfter != nil ||
+		d.NotPrincipalBefore != nil || d.NotPrincipalAfter != nil ||
+		d.ConditionBefore != nil || d.ConditionAfter != nil
+}
+
+// toStrings converts the value of an action or resource element, which can be a single string or
+// a list of strings, to a sorted list without duplicates
+func toStrings(value interface{}) []string {
+	set := map[string]bool{}
+	switch v := value.(type) {
+	case string:
+		set[v] = true
+	case []string:
+		for _, s := range v {
+			set[s] = true
+		}
+	case []interface{}:
+		for _, el := range v {
+			if s, ok := el.(string); ok {
+				set[s] = true
+			}
+		}
+	}
+	result := make([]string, 0, len(set))
+	for s := range set {
+		result = append(result, s)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func diffStrings(before []string, after []string) (added []string, removed []string) {
+	beforeSet := map[string]bool{}
+	for _, s := range before {
+		beforeSet[s] = true
+	}
+	afterSet := map[string]bool{}
+	for _, s := range after {
+		afterSet[s] = true
+		if !beforeSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range before {
+		if !afterSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,169 @@
+package aws
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy diff", func() {
+	current := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "EC2",
+				"Effect": "Allow",
+				"Action": ["ec2:DescribeInstances", "ec2:RunInstances"],
+				"Resource": "*"
+			},
+			{
+				"Sid": "KMS",
+				"Effect": "Allow",
+				"Action": "kms:Decrypt",
+				"Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/red-hat": "true"}}
+			},
+			{
+				"Sid": "Legacy",
+				"Effect": "Allow",
+				"Action": "iam:PassRole",
+				"Resource": "*"
+			}
+		]
+	}`
+	desired := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "EC2",
+				"Effect": "Allow",
+				"Action": ["ec2:RunInstances", "ec2:DescribeInstances", "ec2:CreateTags"],
+				"Resource": "*"
+			},
+			{
+				"Sid": "KMS",
+				"Effect": "Allow",
+				"Action": "kms:Decrypt",
+				"Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/red-hat-managed": "true"}}
+			},
+			{
+				"Sid": "S3",
+				"Effect": "Allow",
+				"Action": "s3:GetObject",
+				"Resource": "arn:aws:s3:::bucket/*"
+			}
+		]
+	}`
+
+	It("Compares the documents statement by statement", func() {
+		diff, err := DiffPolicyDocuments("role", "arn:aws:iam::123:policy/role", current, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.HasChanges()).To(BeTrue())
+		Expect(diff.Statements).To(HaveLen(4))
+
+		Expect(diff.Statements[0].Sid).To(Equal("EC2"))
+		Expect(diff.Statements[0].Status).To(Equal(StatementChanged))
+		Expect(diff.Statements[0].AddedActions).To(Equal([]string{"ec2:CreateTags"}))
+		Expect(diff.Statements[0].RemovedActions).To(BeEmpty())
+
+		Expect(diff.Statements[1].Status).To(Equal(StatementChanged))
+		Expect(diff.Statements[1].ConditionBefore).NotTo(BeNil())
+		Expect(diff.Statements[1].ConditionAfter).NotTo(BeNil())
+
+		Expect(diff.Statements[2].Sid).To(Equal("S3"))
+		Expect(diff.Statements[2].Status).To(Equal(StatementAdded))
+		Expect(diff.Statements[2].AddedResources).To(Equal([]string{"arn:aws:s3:::bucket/*"}))
+
+		Expect(diff.Statements[3].Sid).To(Equal("Legacy"))
+		Expect(diff.Statements[3].Status).To(Equal(StatementRemoved))
+		Expect(diff.Statements[3].RemovedActions).To(Equal([]string{"iam:PassRole"}))
+	})
+
+	It("Reports no changes when only the order of the actions differs", func() {
+		reordered := `{"Statement": [{"Effect": "Allow", "Action": ["b", "a"], "Resource": "*"}]}`
+		original := `{"Statement": [{"Effect": "Allow", "Action": ["a", "b"], "Resource": ["*"]}]}`
+		diff, err := DiffPolicyDocuments("role", "", original, reordered)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.HasChanges()).To(BeFalse())
+	})
+
+	It("Shows every permission as added when the policy doesn't exist", func() {
+		diff, err := DiffPolicyDocuments("role", "", "", desired)
+		Expect(err).NotTo(HaveOccurred())
+		for _, statement := range diff.Statements {
+			Expect(statement.Status).To(Equal(StatementAdded))
+		}
+	})
+
+	It("Renders a unified diff", func() {
+		diff, err := DiffPolicyDocuments("role", "arn:aws:iam::123:policy/role", current, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Unified()).To(Equal(`--- arn:aws:iam::123:policy/role (current)
++++ arn:aws:iam::123:policy/role (upgrade)
+@@ Statement 'EC2' (Allow) changed @@
++  Action: ec2:CreateTags
+@@ Statement 'KMS' (Allow) changed @@
+-  Condition: {"StringEquals":{"aws:ResourceTag/red-hat":"true"}}
++  Condition: {"StringEquals":{"aws:ResourceTag/red-hat-managed":"true"}}
+@@ Statement 'S3' (Allow) added @@
++  Action: s3:GetObject
++  Resource: arn:aws:s3:::bucket/*
+@@ Statement 'Legacy' (Allow) removed @@
+-  Action: iam:PassRole
+-  Resource: *
+`))
+	})
+
+	It("Renders a statement where only the effect changes", func() {
+		allow := `{"Statement": [{"Sid": "EC2", "Effect": "Allow", "Action": "ec2:*", "Resource": "*"}]}`
+		deny := `{"Statement": [{"Sid": "EC2", "Effect": "Deny", "Action": "ec2:*", "Resource": "*"}]}`
+		diff, err := DiffPolicyDocuments("role", "", allow, deny)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Statements[0].Status).To(Equal(StatementChanged))
+		Expect(diff.Unified()).To(Equal(`--- role (current)
++++ role (upgrade)
+@@ Statement 'EC2' (Deny) changed @@
+-  Effect: Allow
++  Effect: Deny
+`))
+	})
+
+	It("Compares the negated elements and the principal", func() {
+		before := `{"Statement": [{"Sid": "Trust", "Effect": "Allow", "NotAction": "iam:*",
+			"NotResource": "arn:aws:s3:::a", "Principal": {"Service": ["ec2.amazonaws.com"]}}]}`
+		after := `{"Statement": [{"Sid": "Trust", "Effect": "Allow", "NotAction": ["iam:*", "sts:*"],
+			"NotResource": "arn:aws:s3:::b", "Principal": {"Service": ["eks.amazonaws.com"]}}]}`
+		diff, err := DiffPolicyDocuments("role", "", before, after)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Statements[0].Status).To(Equal(StatementChanged))
+		Expect(diff.Unified()).To(Equal(`--- role (current)
++++ role (upgrade)
+@@ Statement 'Trust' (Allow) changed @@
++  NotAction: sts:*
+-  NotResource: arn:aws:s3:::a
++  NotResource: arn:aws:s3:::b
+-  Principal: {"Service":["ec2.amazonaws.com"]}
++  Principal: {"Service":["eks.amazonaws.com"]}
+`))
+	})
+
+	It("Renders only the changed policies as JSON", func() {
+		changed, err := DiffPolicyDocuments("changed", "", current, desired)
+		Expect(err).NotTo(HaveOccurred())
+		unchanged, err := DiffPolicyDocuments("unchanged", "", desired, desired)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := FormatPolicyDiffs([]*PolicyDiff{changed, unchanged}, DiffFormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+		parsed := []PolicyDiff{}
+		Expect(json.Unmarshal([]byte(result), &parsed)).To(Succeed())
+		Expect(parsed).To(HaveLen(1))
+		Expect(parsed[0].Name).To(Equal("changed"))
+	})
+
+	It("Fails with an unknown format", func() {
+		_, err := FormatPolicyDiffs(nil, "yaml")
+		Expect(err).To(MatchError(ContainSubstring("Invalid diff format 'yaml'")))
+	})
+})
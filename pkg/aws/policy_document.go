@@ -36,14 +36,22 @@ type PolicyStatement struct {
 	// IAM permissions policy to attach to a user or role, you cannot include this element.
 	// The principal is implied as that user or role.
 	Principal *PolicyStatementPrincipal `json:"Principal,omitempty"`
+	// Indicates the principals that the statement doesn't apply to, instead of the ones it applies to.
+	NotPrincipal *PolicyStatementPrincipal `json:"NotPrincipal,omitempty"`
 	// Include a list of actions that the policy allows or denies.
 	// (i.e. ec2:StartInstances, iam:ChangePassword)
 	Action interface{} `json:"Action,omitempty"`
+	// Indicates the actions that the statement doesn't apply to, instead of the ones it applies to.
+	NotAction interface{} `json:"NotAction,omitempty"`
 	// If you create an IAM permissions policy, you must specify a list of resources to which
 	// the actions apply. If you create a resource-based policy, this element is optional. If
 	// you do not include this element, then the resource to which the action applies is the
 	// resource to which the policy is attached.
 	Resource interface{} `json:"Resource,omitempty"`
+	// Indicates the resources that the statement doesn't apply to, instead of the ones it applies to.
+	NotResource interface{} `json:"NotResource,omitempty"`
+	// Specify the circumstances under which the policy grants permission.
+	Condition interface{} `json:"Condition,omitempty"`
 }
 
 type PolicyStatementPrincipal struct {
@@ -70,8 +78,16 @@ func ParsePolicyDocument(doc string) (*PolicyDocument, error) {
 }
 
 func (p *PolicyStatement) GetAWSPrincipals() []string {
-	awsPrincipal := p.Principal.AWS
+	return p.Principal.GetAWSPrincipals()
+}
+
+// GetAWSPrincipals returns the AWS principals, which can be a single string or a list
+func (p *PolicyStatementPrincipal) GetAWSPrincipals() []string {
 	var awsArr []string
+	if p == nil {
+		return awsArr
+	}
+	awsPrincipal := p.AWS
 	if awsPrincipal == nil {
 		return awsArr
 	}
@@ -164,11 +180,9 @@ func (p *PolicyDocument) checkPermissionsUsingQueryClient(queryClient *awsClient
 		})
 	}
 
-	client := iam.NewFromConfig(queryClient.cfg)
-
 	// Collect all failed actions
 	var failedActions []string
-	paginator := iam.NewSimulatePrincipalPolicyPaginator(client, input)
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(queryClient.iamClient, input)
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(context.TODO())
 		if err != nil {
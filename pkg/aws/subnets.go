@@ -0,0 +1,340 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check, before installing a cluster, that the
+// existing subnets it will use are tagged, sized and routed as the cluster expects.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	// Tags used by the load balancer controllers to discover the subnets where the public and
+	// internal load balancers of the cluster are created
+	ELBRoleTag         = "kubernetes.io/role/elb"
+	InternalELBRoleTag = "kubernetes.io/role/internal-elb"
+
+	// Free addresses that AWS requires in each subnet used by a load balancer
+	loadBalancerAddresses = 8
+
+	defaultRouteCIDR = "0.0.0.0/0"
+)
+
+type SubnetIssueSeverity string
+
+const (
+	SubnetIssueError   SubnetIssueSeverity = "error"
+	SubnetIssueWarning SubnetIssueSeverity = "warning"
+)
+
+// SubnetIssue is a problem found in one of the subnets of a cluster
+type SubnetIssue struct {
+	SubnetID string
+	Severity SubnetIssueSeverity
+	Message  string
+	// Tag that fixes the issue when it is added to the subnet, if any
+	MissingTag string
+}
+
+// SubnetCheck summarizes what was checked for one of the subnets of a cluster
+type SubnetCheck struct {
+	SubnetID         string
+	AvailabilityZone string
+	CIDR             string
+	Public           bool
+	RouteTableID     string
+	AvailableIPs     int
+	RequiredIPs      int
+}
+
+// SubnetReport is the result of validating the subnets of a planned cluster
+type SubnetReport struct {
+	Subnets []SubnetCheck
+	Issues  []SubnetIssue
+}
+
+// HasErrors returns true if any of the issues prevents the cluster from being installed
+func (r *SubnetReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SubnetIssueError {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingTags returns the tags that have to be added to each subnet to fix the issues found
+func (r *SubnetReport) MissingTags() map[string][]string {
+	result := map[string][]string{}
+	for _, issue := range r.Issues {
+		if issue.MissingTag != "" {
+			result[issue.SubnetID] = append(result[issue.SubnetID], issue.MissingTag)
+		}
+	}
+	return result
+}
+
+func (r *SubnetReport) addIssue(subnetID string, severity SubnetIssueSeverity, format string, a ...interface{}) {
+	r.Issues = append(r.Issues, SubnetIssue{
+		SubnetID: subnetID,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// ValidateSubnetLayout checks the tags, free addresses and routes of the subnets of a planned
+// cluster. The route tables are indexed by subnet ID and are the ones associated explicitly with
+// the subnet or, otherwise, the main route table of its VPC. Subnets are expected to be already
+// within the machine CIDR, the nodes are assumed to be spread evenly over the private subnets.
+// Subnets owned by an account other than the given one are shared by the owner of a shared VPC,
+// whose route tables may not be visible, so their routes only cause warnings.
+func ValidateSubnetLayout(topology *ClusterTopology, privateLink bool, accountID string,
+	subnets []ec2types.Subnet, routeTables map[string]ec2types.RouteTable) *SubnetReport {
+	report := &SubnetReport{}
+
+	public := map[string]bool{}
+	privateSubnets := 0
+	for _, subnet := range subnets {
+		routeTable, ok := routeTables[aws.ToString(subnet.SubnetId)]
+		public[aws.ToString(subnet.SubnetId)] = ok && hasInternetGatewayRoute(routeTable)
+		if !public[aws.ToString(subnet.SubnetId)] {
+			privateSubnets++
+		}
+	}
+
+	// Nodes are created in the private subnets, or in the public ones when there are none
+	nodes, _ := topology.nodes()
+	totalNodes := 0
+	for _, count := range nodes {
+		totalNodes += count
+	}
+	nodeSubnets := privateSubnets
+	if nodeSubnets == 0 {
+		nodeSubnets = len(subnets)
+	}
+	nodesPerSubnet := 0
+	if nodeSubnets > 0 {
+		nodesPerSubnet = (totalNodes + nodeSubnets - 1) / nodeSubnets
+	}
+
+	for _, subnet := range subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+		routeTable, hasRouteTable := routeTables[subnetID]
+		check := SubnetCheck{
+			SubnetID:         subnetID,
+			AvailabilityZone: aws.ToString(subnet.AvailabilityZone),
+			CIDR:             aws.ToString(subnet.CidrBlock),
+			Public:           public[subnetID],
+			RouteTableID:     aws.ToString(routeTable.RouteTableId),
+			AvailableIPs:     int(aws.ToInt32(subnet.AvailableIpAddressCount)),
+			RequiredIPs:      loadBalancerAddresses,
+		}
+		if !check.Public || privateSubnets == 0 {
+			check.RequiredIPs += nodesPerSubnet
+		}
+		report.Subnets = append(report.Subnets, check)
+
+		if check.AvailableIPs < check.RequiredIPs {
+			report.addIssue(subnetID, SubnetIssueError,
+				"Subnet has %d free IP addresses but the nodes and load balancers of the cluster require %d",
+				check.AvailableIPs, check.RequiredIPs)
+		}
+
+		owner := aws.ToString(subnet.OwnerId)
+		shared := accountID != "" && owner != "" && owner != accountID
+		routeSeverity := SubnetIssueError
+		if shared {
+			routeSeverity = SubnetIssueWarning
+		}
+		if !hasRouteTable {
+			if shared {
+				report.addIssue(subnetID, SubnetIssueWarning,
+					"Subnet is shared by account '%s', its route table can't be checked", owner)
+				continue
+			}
+			report.addIssue(subnetID, SubnetIssueError,
+				"Subnet isn't associated with a route table and its VPC has no main route table")
+			continue
+		}
+		defaultRoute := findDefaultRoute(routeTable)
+		switch {
+		case defaultRoute != nil && defaultRoute.State == ec2types.RouteStateBlackhole:
+			report.addIssue(subnetID, routeSeverity,
+				"The default route of route table '%s' is a blackhole, its target no longer exists",
+				check.RouteTableID)
+		case check.Public && defaultRoute == nil:
+			report.addIssue(subnetID, SubnetIssueWarning,
+				"Route table '%s' routes some traffic to an internet gateway but has no default route to it",
+				check.RouteTableID)
+		case !check.Public && defaultRoute == nil:
+			report.addIssue(subnetID, SubnetIssueWarning,
+				"Route table '%s' has no default route, the nodes can only reach the internet through a proxy",
+				check.RouteTableID)
+		}
+
+		if check.Public {
+			if privateLink {
+				continue
+			}
+			if !hasTag(subnet.Tags, ELBRoleTag) {
+				report.addMissingTag(subnetID, ELBRoleTag, "Public")
+			}
+			if hasTag(subnet.Tags, InternalELBRoleTag) {
+				report.addIssue(subnetID, SubnetIssueWarning,
+					"Public subnet has the '%s' tag, internal load balancers may be created in it",
+					InternalELBRoleTag)
+			}
+		} else {
+			if !hasTag(subnet.Tags, InternalELBRoleTag) {
+				report.addMissingTag(subnetID, InternalELBRoleTag, "Private")
+			}
+			if hasTag(subnet.Tags, ELBRoleTag) {
+				report.addIssue(subnetID, SubnetIssueWarning,
+					"Private subnet has the '%s' tag, public load balancers created in it won't be reachable",
+					ELBRoleTag)
+			}
+		}
+	}
+
+	return report
+}
+
+func (r *SubnetReport) addMissingTag(subnetID string, tag string, kind string) {
+	r.Issues = append(r.Issues, SubnetIssue{
+		SubnetID:   subnetID,
+		Severity:   SubnetIssueWarning,
+		Message:    fmt.Sprintf("%s subnet doesn't have the '%s' tag", kind, tag),
+		MissingTag: tag,
+	})
+}
+
+func hasInternetGatewayRoute(routeTable ec2types.RouteTable) bool {
+	for _, route := range routeTable.Routes {
+		if strings.HasPrefix(aws.ToString(route.GatewayId), "igw") {
+			return true
+		}
+	}
+	return false
+}
+
+func findDefaultRoute(routeTable ec2types.RouteTable) *ec2types.Route {
+	for i, route := range routeTable.Routes {
+		if aws.ToString(route.DestinationCidrBlock) == defaultRouteCIDR {
+			return &routeTable.Routes[i]
+		}
+	}
+	return nil
+}
+
+func hasTag(tags []ec2types.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSubnets finds the route tables of the given subnets and checks their layout
+func (c *awsClient) ValidateSubnets(topology *ClusterTopology, privateLink bool, accountID string,
+	subnets []ec2types.Subnet) (*SubnetReport, error) {
+	routeTables, err := c.getSubnetRouteTables(subnets)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateSubnetLayout(topology, privateLink, accountID, subnets, routeTables), nil
+}
+
+// getSubnetRouteTables returns the route table of each subnet, which is the one associated
+// explicitly with it or, otherwise, the main route table of its VPC
+func (c *awsClient) getSubnetRouteTables(subnets []ec2types.Subnet) (map[string]ec2types.RouteTable, error) {
+	vpcIDs := map[string]bool{}
+	for _, subnet := range subnets {
+		vpcIDs[aws.ToString(subnet.VpcId)] = true
+	}
+	explicit := map[string]ec2types.RouteTable{}
+	main := map[string]ec2types.RouteTable{}
+	for _, chunk := range helper.ChunkSlice(helper.MapKeys(vpcIDs), awsMaxFilterLength) {
+		paginator := ec2.NewDescribeRouteTablesPaginator(c.ec2Client, &ec2.DescribeRouteTablesInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: chunk,
+				},
+			},
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			for _, routeTable := range output.RouteTables {
+				for _, association := range routeTable.Associations {
+					if aws.ToBool(association.Main) {
+						main[aws.ToString(routeTable.VpcId)] = routeTable
+					} else if association.SubnetId != nil {
+						explicit[aws.ToString(association.SubnetId)] = routeTable
+					}
+				}
+			}
+		}
+	}
+	result := map[string]ec2types.RouteTable{}
+	for _, subnet := range subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+		if routeTable, ok := explicit[subnetID]; ok {
+			result[subnetID] = routeTable
+		} else if routeTable, ok := main[aws.ToString(subnet.VpcId)]; ok {
+			result[subnetID] = routeTable
+		}
+	}
+	return result, nil
+}
+
+// TagSubnets adds the given tags, indexed by subnet ID, to the subnets. The load balancer
+// controllers only check that the role tags exist, so they are given the value '1'.
+func (c *awsClient) TagSubnets(tagsBySubnet map[string][]string) error {
+	subnetIDs := helper.MapKeys(tagsBySubnet)
+	sort.Strings(subnetIDs)
+	for _, subnetID := range subnetIDs {
+		tags := make([]ec2types.Tag, 0, len(tagsBySubnet[subnetID]))
+		for _, key := range tagsBySubnet[subnetID] {
+			tags = append(tags, ec2types.Tag{
+				Key:   aws.String(key),
+				Value: aws.String("1"),
+			})
+		}
+		_, err := c.ec2Client.CreateTags(context.Background(), &ec2.CreateTagsInput{
+			Resources: []string{subnetID},
+			Tags:      tags,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to tag subnet '%s': %v", subnetID, err)
+		}
+	}
+	return nil
+}
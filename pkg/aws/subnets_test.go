@@ -0,0 +1,234 @@
+package aws
+
+import (
+	"context"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Subnets", func() {
+	newSubnet := func(id string, freeIPs int32, tags ...string) ec2types.Subnet {
+		subnet := ec2types.Subnet{
+			SubnetId:                awsSdk.String(id),
+			VpcId:                   awsSdk.String("vpc-1"),
+			AvailabilityZone:        awsSdk.String("us-east-1a"),
+			CidrBlock:               awsSdk.String("10.0.0.0/24"),
+			AvailableIpAddressCount: awsSdk.Int32(freeIPs),
+		}
+		for _, tag := range tags {
+			subnet.Tags = append(subnet.Tags, ec2types.Tag{Key: awsSdk.String(tag), Value: awsSdk.String("1")})
+		}
+		return subnet
+	}
+	publicRouteTable := ec2types.RouteTable{
+		RouteTableId: awsSdk.String("rtb-public"),
+		Routes: []ec2types.Route{
+			{DestinationCidrBlock: awsSdk.String("10.0.0.0/16"), GatewayId: awsSdk.String("local")},
+			{DestinationCidrBlock: awsSdk.String("0.0.0.0/0"), GatewayId: awsSdk.String("igw-1")},
+		},
+	}
+	privateRouteTable := ec2types.RouteTable{
+		RouteTableId: awsSdk.String("rtb-private"),
+		Routes: []ec2types.Route{
+			{DestinationCidrBlock: awsSdk.String("10.0.0.0/16"), GatewayId: awsSdk.String("local")},
+			{DestinationCidrBlock: awsSdk.String("0.0.0.0/0"), NatGatewayId: awsSdk.String("nat-1")},
+		},
+	}
+	topology := &ClusterTopology{ComputeInstanceType: "m5.xlarge", ComputeNodes: 2}
+
+	Context("ValidateSubnetLayout", func() {
+		It("Reports no issues for a correctly tagged, sized and routed layout", func() {
+			report := ValidateSubnetLayout(topology, false, "123",
+				[]ec2types.Subnet{
+					newSubnet("subnet-public", 100, ELBRoleTag),
+					newSubnet("subnet-private", 100, InternalELBRoleTag),
+				},
+				map[string]ec2types.RouteTable{
+					"subnet-public":  publicRouteTable,
+					"subnet-private": privateRouteTable,
+				})
+			Expect(report.Issues).To(BeEmpty())
+			Expect(report.Subnets).To(HaveLen(2))
+			Expect(report.Subnets[0].Public).To(BeTrue())
+			Expect(report.Subnets[0].RequiredIPs).To(Equal(8))
+			// 3 control plane, 2 infra, 1 bootstrap and 2 compute nodes plus the load balancers
+			Expect(report.Subnets[1].Public).To(BeFalse())
+			Expect(report.Subnets[1].RequiredIPs).To(Equal(16))
+		})
+
+		It("Reports missing tags that can be fixed", func() {
+			report := ValidateSubnetLayout(topology, false, "123",
+				[]ec2types.Subnet{
+					newSubnet("subnet-public", 100),
+					newSubnet("subnet-private", 100),
+				},
+				map[string]ec2types.RouteTable{
+					"subnet-public":  publicRouteTable,
+					"subnet-private": privateRouteTable,
+				})
+			Expect(report.HasErrors()).To(BeFalse())
+			Expect(report.MissingTags()).To(Equal(map[string][]string{
+				"subnet-public":  {ELBRoleTag},
+				"subnet-private": {InternalELBRoleTag},
+			}))
+		})
+
+		It("Doesn't require the public tag for private link clusters", func() {
+			report := ValidateSubnetLayout(topology, true, "123",
+				[]ec2types.Subnet{newSubnet("subnet-public", 100)},
+				map[string]ec2types.RouteTable{"subnet-public": publicRouteTable})
+			Expect(report.MissingTags()).To(BeEmpty())
+		})
+
+		It("Warns when a private subnet is tagged for public load balancers", func() {
+			report := ValidateSubnetLayout(topology, false, "123",
+				[]ec2types.Subnet{newSubnet("subnet-private", 100, InternalELBRoleTag, ELBRoleTag)},
+				map[string]ec2types.RouteTable{"subnet-private": privateRouteTable})
+			Expect(report.HasErrors()).To(BeFalse())
+			Expect(report.Issues).To(HaveLen(1))
+			Expect(report.Issues[0].Severity).To(Equal(SubnetIssueWarning))
+			Expect(report.Issues[0].Message).To(ContainSubstring(ELBRoleTag))
+		})
+
+		It("Fails when the subnets don't have enough free addresses", func() {
+			report := ValidateSubnetLayout(topology, false, "123",
+				[]ec2types.Subnet{newSubnet("subnet-private", 10, InternalELBRoleTag)},
+				map[string]ec2types.RouteTable{"subnet-private": privateRouteTable})
+			Expect(report.HasErrors()).To(BeTrue())
+			Expect(report.Issues[0].Message).To(ContainSubstring("10 free IP addresses"))
+		})
+
+		It("Checks the default routes of the subnets", func() {
+			blackhole := ec2types.RouteTable{
+				RouteTableId: awsSdk.String("rtb-blackhole"),
+				Routes: []ec2types.Route{{
+					DestinationCidrBlock: awsSdk.String("0.0.0.0/0"),
+					NatGatewayId:         awsSdk.String("nat-deleted"),
+					State:                ec2types.RouteStateBlackhole,
+				}},
+			}
+			isolated := ec2types.RouteTable{
+				RouteTableId: awsSdk.String("rtb-isolated"),
+				Routes: []ec2types.Route{
+					{DestinationCidrBlock: awsSdk.String("10.0.0.0/16"), GatewayId: awsSdk.String("local")},
+				},
+			}
+			report := ValidateSubnetLayout(&ClusterTopology{HostedCP: true, ComputeNodes: 2}, true, "123",
+				[]ec2types.Subnet{
+					newSubnet("subnet-blackhole", 100, InternalELBRoleTag),
+					newSubnet("subnet-isolated", 100, InternalELBRoleTag),
+					newSubnet("subnet-unrouted", 100, InternalELBRoleTag),
+				},
+				map[string]ec2types.RouteTable{
+					"subnet-blackhole": blackhole,
+					"subnet-isolated":  isolated,
+				})
+			Expect(report.Issues).To(HaveLen(3))
+			Expect(report.Issues[0].Severity).To(Equal(SubnetIssueError))
+			Expect(report.Issues[0].Message).To(ContainSubstring("blackhole"))
+			Expect(report.Issues[1].Severity).To(Equal(SubnetIssueWarning))
+			Expect(report.Issues[1].Message).To(ContainSubstring("proxy"))
+			Expect(report.Issues[2].Severity).To(Equal(SubnetIssueError))
+			Expect(report.Issues[2].SubnetID).To(Equal("subnet-unrouted"))
+		})
+
+		It("Only warns about the routes of subnets shared by another account", func() {
+			blackhole := ec2types.RouteTable{
+				RouteTableId: awsSdk.String("rtb-blackhole"),
+				Routes: []ec2types.Route{{
+					DestinationCidrBlock: awsSdk.String("0.0.0.0/0"),
+					NatGatewayId:         awsSdk.String("nat-deleted"),
+					State:                ec2types.RouteStateBlackhole,
+				}},
+			}
+			shared := newSubnet("subnet-shared", 100, InternalELBRoleTag)
+			shared.OwnerId = awsSdk.String("456")
+			sharedBlackhole := newSubnet("subnet-shared-blackhole", 100, InternalELBRoleTag)
+			sharedBlackhole.OwnerId = awsSdk.String("456")
+			report := ValidateSubnetLayout(topology, true, "123",
+				[]ec2types.Subnet{shared, sharedBlackhole},
+				map[string]ec2types.RouteTable{"subnet-shared-blackhole": blackhole})
+			Expect(report.HasErrors()).To(BeFalse())
+			Expect(report.Issues).To(HaveLen(2))
+			Expect(report.Issues[0].SubnetID).To(Equal("subnet-shared"))
+			Expect(report.Issues[0].Message).To(ContainSubstring("shared by account '456'"))
+			Expect(report.Issues[1].Message).To(ContainSubstring("blackhole"))
+		})
+	})
+
+	Context("Client", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockEC2API *mocks.MockEc2ApiClient
+			client     Client
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEC2API = mocks.NewMockEc2ApiClient(mockCtrl)
+			client = New(
+				awsSdk.Config{},
+				logrus.New(),
+				mocks.NewMockIamApiClient(mockCtrl),
+				mockEC2API,
+				mocks.NewMockOrganizationsApiClient(mockCtrl),
+				mocks.NewMockS3ApiClient(mockCtrl),
+				mocks.NewMockSecretsManagerApiClient(mockCtrl),
+				mocks.NewMockStsApiClient(mockCtrl),
+				mocks.NewMockCloudFormationApiClient(mockCtrl),
+				mocks.NewMockServiceQuotasApiClient(mockCtrl),
+				mocks.NewMockKmsApiClient(mockCtrl),
+				&AccessKey{},
+				false,
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Falls back to the main route table of the VPC", func() {
+			explicit := publicRouteTable
+			explicit.VpcId = awsSdk.String("vpc-1")
+			explicit.Associations = []ec2types.RouteTableAssociation{{SubnetId: awsSdk.String("subnet-public")}}
+			main := privateRouteTable
+			main.VpcId = awsSdk.String("vpc-1")
+			main.Associations = []ec2types.RouteTableAssociation{{Main: awsSdk.Bool(true)}}
+			mockEC2API.EXPECT().DescribeRouteTables(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *ec2.DescribeRouteTablesInput,
+					_ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+					Expect(input.Filters[0].Values).To(Equal([]string{"vpc-1"}))
+					return &ec2.DescribeRouteTablesOutput{
+						RouteTables: []ec2types.RouteTable{explicit, main},
+					}, nil
+				})
+
+			report, err := client.ValidateSubnets(topology, false, "123", []ec2types.Subnet{
+				newSubnet("subnet-public", 100, ELBRoleTag),
+				newSubnet("subnet-private", 100, InternalELBRoleTag),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Issues).To(BeEmpty())
+			Expect(report.Subnets[0].RouteTableID).To(Equal("rtb-public"))
+			Expect(report.Subnets[1].RouteTableID).To(Equal("rtb-private"))
+		})
+
+		It("Tags the subnets", func() {
+			mockEC2API.EXPECT().CreateTags(gomock.Any(), &ec2.CreateTagsInput{
+				Resources: []string{"subnet-private"},
+				Tags:      []ec2types.Tag{{Key: awsSdk.String(InternalELBRoleTag), Value: awsSdk.String("1")}},
+			}).Return(&ec2.CreateTagsOutput{}, nil)
+			err := client.TagSubnets(map[string][]string{"subnet-private": {InternalELBRoleTag}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
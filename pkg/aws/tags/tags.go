@@ -43,6 +43,14 @@ const Environment = prefix + "environment"
 // AdminRole tags the role as admin (true/false)
 const AdminRole = prefix + "admin_role"
 
+// NetworkName is the name of the tag that will contain the name given to the network created with
+// 'rosa create network'
+const NetworkName = prefix + "network_name"
+
+// KMSKeyPurpose is the name of the tag that will contain the purpose (ebs/etcd) of the KMS keys created
+// with 'rosa create kms-key'
+const KMSKeyPurpose = prefix + "kms_key_purpose"
+
 // RedHatManaged tags the role as red_hat_managed
 const RedHatManaged = "red-hat-managed"
 
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the short-lived on-disk cache of the completion candidates. Every press of
+// the tab key runs a new process, so without it every press would query OCM and AWS again.
+
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/openshift/rosa/pkg/aws/profile"
+	"github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// cacheTTL is the time that the completion candidates are kept in the cache
+const cacheTTL = time.Minute
+
+// now is replaced by the tests to simulate the expiration of the cache
+var now = time.Now
+
+type cacheEntry struct {
+	Expires    time.Time `json:"expires"`
+	Candidates []string  `json:"candidates"`
+}
+
+// cached returns the candidates stored in the cache for the given key, calling the fetch function
+// to get them if they aren't in the cache or have expired. The key is combined with the OCM
+// account and the AWS profile and region, so that candidates are never shared between accounts.
+// The cache is in the directory of the OCM cache, so 'rosa cache clear' also clears it.
+func cached(key []string, fetch func() ([]string, error)) ([]string, error) {
+	path := cachePath(key)
+	if path != "" {
+		// #nosec G304
+		data, err := os.ReadFile(path)
+		if err == nil {
+			entry := &cacheEntry{}
+			err = json.Unmarshal(data, entry)
+			if err == nil && now().Before(entry.Expires) {
+				return entry.Candidates, nil
+			}
+		}
+	}
+
+	candidates, err := fetch()
+	if err != nil || path == "" {
+		return candidates, err
+	}
+	data, err := json.Marshal(&cacheEntry{
+		Expires:    now().Add(cacheTTL),
+		Candidates: candidates,
+	})
+	if err == nil && os.MkdirAll(filepath.Dir(path), os.FileMode(0700)) == nil {
+		// Failing to store the candidates only means that the next completion queries again:
+		_ = os.WriteFile(path, data, 0600)
+	}
+	return candidates, nil
+}
+
+// cachePath returns the path of the file where the candidates for the given key are stored, or
+// the empty string if there is no cache directory.
+func cachePath(key []string) string {
+	dir, err := ocm.CacheLocation()
+	if err != nil {
+		return ""
+	}
+	scope := append([]string{}, key...)
+	scope = append(scope, ocmAccount(), profile.Profile(), region.Region())
+	sum := sha256.Sum256([]byte(strings.Join(scope, "\n")))
+	return filepath.Join(dir, "completion", hex.EncodeToString(sum[:])+".json")
+}
+
+// ocmAccount returns the URL of OCM and the subject of the token of the current configuration
+func ocmAccount() string {
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		return ""
+	}
+	account := cfg.URL
+	for _, text := range []string{cfg.AccessToken, cfg.RefreshToken} {
+		if text == "" {
+			continue
+		}
+		token, err := config.ParseToken(text)
+		if err != nil {
+			continue
+		}
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if subject, ok := claims["sub"].(string); ok {
+				return account + "\n" + subject
+			}
+		}
+	}
+	return account
+}
@@ -0,0 +1,353 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package completion contains the shell completion functions for the names and identifiers of
+// the resources of OCM and AWS, like clusters, machine pools and versions.
+package completion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Func is the type of the completion functions, as expected by cobra
+type Func func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletions are the completion functions of the flags that have the same meaning in all
+// the commands. Flags like '--version' or '--prefix' mean different things in different commands,
+// so their completion functions are registered by the commands.
+var flagCompletions = map[string]Func{
+	"cluster":              Clusters,
+	"machinepool":          MachinePools,
+	"region":               Regions,
+	"instance-type":        InstanceTypes,
+	"compute-machine-type": InstanceTypes,
+	"oidc-config-id":       OidcConfigs,
+}
+
+// Register adds the completion functions to the flags of the given command and its subcommands
+// that have the same meaning in all the commands, unless the command already has one.
+func Register(cmd *cobra.Command) {
+	for name, function := range flagCompletions {
+		if cmd.Flags().Lookup(name) == nil && cmd.PersistentFlags().Lookup(name) == nil {
+			continue
+		}
+		if _, exists := cmd.GetFlagCompletionFunc(name); exists {
+			continue
+		}
+		_ = cmd.RegisterFlagCompletionFunc(name, function)
+	}
+	for _, child := range cmd.Commands() {
+		Register(child)
+	}
+}
+
+// Clusters completes the names of the clusters created with the current AWS account, with their
+// identifiers as descriptions.
+func Clusters(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return complete([]string{"clusters"}, func() ([]string, error) {
+		ocmClient, awsClient, err := clients(true)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		creator, err := awsClient.GetCreator()
+		if err != nil {
+			return nil, err
+		}
+		clusters, err := ocmClient.GetAllClusters(creator)
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		for _, cluster := range clusters {
+			candidates = append(candidates, describe(cluster.Name(), cluster.ID()))
+		}
+		return candidates, nil
+	})
+}
+
+// MachinePools completes the identifiers of the machine pools, or node pools for hosted control
+// plane clusters, of the cluster given with the '--cluster' flag.
+func MachinePools(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	clusterKey := clusterFlag(cmd)
+	if clusterKey == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return complete([]string{"machinepools", clusterKey}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		cluster, err := ocmClient.GetCluster(clusterKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		if cluster.Hypershift().Enabled() {
+			nodePools, err := ocmClient.GetNodePools(cluster.ID())
+			if err != nil {
+				return nil, err
+			}
+			for _, nodePool := range nodePools {
+				candidates = append(candidates, nodePool.ID())
+			}
+			return candidates, nil
+		}
+		machinePools, err := ocmClient.GetMachinePools(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, machinePool := range machinePools {
+			candidates = append(candidates, machinePool.ID())
+		}
+		return candidates, nil
+	})
+}
+
+// IdentityProviders completes the names of the identity providers of the cluster given with the
+// '--cluster' flag. It is meant for the argument of the commands.
+func IdentityProviders(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	clusterKey := clusterFlag(cmd)
+	if clusterKey == "" || len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return complete([]string{"idps", clusterKey}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		cluster, err := ocmClient.GetCluster(clusterKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		idps, err := ocmClient.GetIdentityProviders(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		for _, idp := range idps {
+			candidates = append(candidates, describe(idp.Name(), string(idp.Type())))
+		}
+		return candidates, nil
+	})
+}
+
+// Ingresses completes the identifiers of the ingresses of the cluster given with the '--cluster'
+// flag. It is meant for the argument of the commands.
+func Ingresses(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	clusterKey := clusterFlag(cmd)
+	if clusterKey == "" || len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return complete([]string{"ingresses", clusterKey}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		cluster, err := ocmClient.GetCluster(clusterKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		ingresses, err := ocmClient.GetIngresses(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		for _, ingress := range ingresses {
+			candidates = append(candidates, describe(ingress.ID(), ingress.DNSName()))
+		}
+		return candidates, nil
+	})
+}
+
+// MachinePoolArgs completes the argument of the commands that take the identifier of a machine
+// pool as argument.
+func MachinePoolArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return MachinePools(cmd, args, toComplete)
+}
+
+// AccountRolePrefixes completes the prefixes of the account roles of the current AWS account
+func AccountRolePrefixes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return complete([]string{"account-role-prefixes"}, func() ([]string, error) {
+		awsClient, err := aws.NewClient().Logger(logging.NewLogger()).Build()
+		if err != nil {
+			return nil, err
+		}
+		roles, err := awsClient.ListAccountRoles("")
+		if err != nil {
+			return nil, err
+		}
+		prefixes := map[string]bool{}
+		for _, role := range roles {
+			if prefix := accountRolePrefix(role.RoleName); prefix != "" {
+				prefixes[prefix] = true
+			}
+		}
+		candidates := make([]string, 0, len(prefixes))
+		for prefix := range prefixes {
+			candidates = append(candidates, prefix)
+		}
+		sort.Strings(candidates)
+		return candidates, nil
+	})
+}
+
+// accountRolePrefix returns the prefix of the given account role name, or the empty string if it
+// isn't the name of an account role
+func accountRolePrefix(roleName string) string {
+	// The suffixes of the hosted control plane roles contain the suffixes of the classic ones, so
+	// they need to be checked first:
+	for _, roles := range []map[string]aws.AccountRole{aws.HCPAccountRoles, aws.AccountRoles} {
+		for _, role := range roles {
+			suffix := "-" + role.Name + "-Role"
+			if strings.HasSuffix(roleName, suffix) {
+				return strings.TrimSuffix(roleName, suffix)
+			}
+		}
+	}
+	return ""
+}
+
+// Versions completes the versions of OpenShift of the channel group given with the
+// '--channel-group' flag, or the stable one if the command doesn't have that flag.
+func Versions(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	channelGroup := ocm.DefaultChannelGroup
+	if flag := cmd.Flags().Lookup("channel-group"); flag != nil && flag.Value.String() != "" {
+		channelGroup = flag.Value.String()
+	}
+	return complete([]string{"versions", channelGroup}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		return ocmClient.GetVersionsList(channelGroup, true)
+	})
+}
+
+// Regions completes the AWS regions supported by OCM
+func Regions(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return complete([]string{"regions"}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		return ocmClient.GetDatabaseRegionList()
+	})
+}
+
+// InstanceTypes completes the AWS instance types supported by OCM
+func InstanceTypes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return complete([]string{"instance-types"}, func() ([]string, error) {
+		ocmClient, _, err := clients(false)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		machineTypes, err := ocmClient.GetMachineTypes()
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		for _, machineType := range machineTypes.Items {
+			candidates = append(candidates, describe(machineType.MachineType.ID(), machineType.MachineType.Name()))
+		}
+		return candidates, nil
+	})
+}
+
+// OidcConfigs completes the identifiers of the OIDC configurations of the current AWS account,
+// with their issuer URLs as descriptions.
+func OidcConfigs(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return complete([]string{"oidc-configs"}, func() ([]string, error) {
+		ocmClient, awsClient, err := clients(true)
+		if err != nil {
+			return nil, err
+		}
+		defer ocmClient.Close()
+		creator, err := awsClient.GetCreator()
+		if err != nil {
+			return nil, err
+		}
+		oidcConfigs, err := ocmClient.ListOidcConfigs(creator.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		candidates := []string{}
+		for _, oidcConfig := range oidcConfigs {
+			candidates = append(candidates, describe(oidcConfig.ID(), oidcConfig.IssuerUrl()))
+		}
+		return candidates, nil
+	})
+}
+
+// complete returns the candidates for the given key, from the cache if possible. Errors aren't
+// reported, as there is no way to show them while completing.
+func complete(key []string, fetch func() ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	candidates, err := cached(key, fetch)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// clients creates the OCM client and, if requested, the AWS client
+func clients(withAWS bool) (*ocm.Client, aws.Client, error) {
+	logger := logging.NewLogger()
+	ocmClient, err := ocm.NewClient().Logger(logger).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !withAWS {
+		return ocmClient, nil, nil
+	}
+	awsClient, err := aws.NewClient().Logger(logger).Build()
+	if err != nil {
+		ocmClient.Close()
+		return nil, nil, err
+	}
+	return ocmClient, awsClient, nil
+}
+
+func clusterFlag(cmd *cobra.Command) string {
+	flag := cmd.Flag("cluster")
+	if flag == nil {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// describe adds the given description to the candidate, for the shells that support it
+func describe(candidate string, description string) string {
+	if description == "" || description == candidate {
+		return candidate
+	}
+	return candidate + "\t" + description
+}
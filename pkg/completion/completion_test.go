@@ -0,0 +1,124 @@
+package completion
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Account role prefixes", func() {
+	DescribeTable("Extracts the prefix from the role name",
+		func(roleName string, expected string) {
+			Expect(accountRolePrefix(roleName)).To(Equal(expected))
+		},
+		Entry("Classic installer role", "ManagedOpenShift-Installer-Role", "ManagedOpenShift"),
+		Entry("Classic control plane role", "my-prefix-ControlPlane-Role", "my-prefix"),
+		Entry("Hosted control plane worker role", "ManagedOpenShift-HCP-ROSA-Worker-Role", "ManagedOpenShift"),
+		Entry("Operator role", "my-cluster-openshift-ingress-operator-cloud-credentials", ""),
+	)
+})
+
+var _ = Describe("Cache", func() {
+	var fetches int
+	var fetch func() ([]string, error)
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		GinkgoT().Setenv(ocm.CacheLocationEnvKey, filepath.Join(dir, "cache"))
+		GinkgoT().Setenv("OCM_CONFIG", filepath.Join(dir, "ocm.json"))
+		fetches = 0
+		fetch = func() ([]string, error) {
+			fetches++
+			return []string{"mycluster\t123"}, nil
+		}
+		DeferCleanup(func() {
+			now = time.Now
+		})
+	})
+
+	It("Reuses the candidates until they expire", func() {
+		start := time.Now()
+		now = func() time.Time { return start }
+		candidates, err := cached([]string{"clusters"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidates).To(Equal([]string{"mycluster\t123"}))
+		Expect(fetches).To(Equal(1))
+
+		now = func() time.Time { return start.Add(cacheTTL / 2) }
+		candidates, err = cached([]string{"clusters"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidates).To(Equal([]string{"mycluster\t123"}))
+		Expect(fetches).To(Equal(1))
+
+		now = func() time.Time { return start.Add(2 * cacheTTL) }
+		_, err = cached([]string{"clusters"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(Equal(2))
+	})
+
+	It("Doesn't share candidates between keys", func() {
+		_, err := cached([]string{"machinepools", "cluster-a"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cached([]string{"machinepools", "cluster-b"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(Equal(2))
+	})
+
+	It("Doesn't cache errors", func() {
+		failure := func() ([]string, error) {
+			fetches++
+			return nil, errors.New("boom")
+		}
+		_, err := cached([]string{"regions"}, failure)
+		Expect(err).To(HaveOccurred())
+		_, err = cached([]string{"regions"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(Equal(2))
+	})
+
+	It("Is removed when the OCM cache is cleared", func() {
+		_, err := cached([]string{"versions", "stable"}, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ocm.ClearCache()).To(Succeed())
+		dir, err := ocm.CacheLocation()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = os.Stat(filepath.Join(dir, "completion"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Register", func() {
+	It("Adds completion functions to the known flags of all the commands", func() {
+		root := &cobra.Command{Use: "rosa"}
+		root.PersistentFlags().String("region", "", "")
+		child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+		child.Flags().String("cluster", "", "")
+		child.Flags().String("version", "", "")
+		custom := func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return []string{"custom"}, cobra.ShellCompDirectiveNoFileComp
+		}
+		child.Flags().String("instance-type", "", "")
+		Expect(child.RegisterFlagCompletionFunc("instance-type", custom)).To(Succeed())
+		root.AddCommand(child)
+
+		Register(root)
+
+		_, exists := root.GetFlagCompletionFunc("region")
+		Expect(exists).To(BeTrue())
+		_, exists = child.GetFlagCompletionFunc("cluster")
+		Expect(exists).To(BeTrue())
+		_, exists = child.GetFlagCompletionFunc("version")
+		Expect(exists).To(BeFalse())
+		function, exists := child.GetFlagCompletionFunc("instance-type")
+		Expect(exists).To(BeTrue())
+		candidates, _ := function(child, nil, "")
+		Expect(candidates).To(Equal([]string{"custom"}))
+	})
+})
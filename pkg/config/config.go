@@ -56,6 +56,11 @@ type Config struct {
 	TokenURL     string   `json:"token_url,omitempty" doc:"OpenID token URL."`
 	URL          string   `json:"url,omitempty" doc:"URL of the API gateway."`
 	FedRAMP      bool     `json:"fedramp,omitempty" doc:"Indicates FedRAMP."`
+	Cache        bool     `json:"cache,omitempty" doc:"Enables the on-disk cache of read-only OCM responses."`
+	// Custom endpoints of the AWS services, by service name or 'default' for all the services
+	AWSEndpoints map[string]string `json:"aws_endpoints,omitempty" doc:"Custom AWS endpoints, as 'service=url,...'."`
+	Audit        bool              `json:"audit,omitempty" doc:"Enables the local journal of the mutating commands."`
+	AuditWebhook string            `json:"audit_webhook,omitempty" doc:"URL that also receives the journal entries."`
 }
 
 var DisallowedSetConfigProperties = []string{"scopes"}
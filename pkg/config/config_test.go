@@ -55,6 +55,10 @@ var _ = Describe("Config", Ordered, func() {
 		"token_url":     "OpenID token URL.",
 		"url":           "URL of the API gateway.",
 		"fedramp":       "Indicates FedRAMP.",
+		"cache":         "Enables the on-disk cache of read-only OCM responses.",
+		"aws_endpoints": "Custom AWS endpoints, as 'service=url,...'.",
+		"audit":         "Enables the local journal of the mutating commands.",
+		"audit_webhook": "URL that also receives the journal entries.",
 	}
 
 	It("Shows properties and docs for config", func() {
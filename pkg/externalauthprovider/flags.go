@@ -1,8 +1,11 @@
 package externalauthprovider
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -34,6 +37,8 @@ type ExternalAuthServiceImpl struct {
 type ExternalAuthService interface {
 	IsExternalAuthProviderSupported(cluster *cmv1.Cluster) error
 	CreateExternalAuthProvider(cluster *cmv1.Cluster, args ExternalAuthProvidersArgs) error
+	UpdateExternalAuthProvider(cluster *cmv1.Cluster, clusterKey string,
+		args *ExternalAuthProvidersArgs, r *rosa.Runtime) error
 }
 
 func NewExternalAuthService(ocm *ocm.Client) *ExternalAuthServiceImpl {
@@ -46,6 +51,7 @@ type ExternalAuthProvidersArgs struct {
 	issuerAudiences           []string
 	issuerUrl                 string
 	issuerCaFile              string
+	issuerCa                  string
 	claimMappingGroupsClaim   string
 	claimMappingUsernameClaim string
 	claimValidationRule       []string
@@ -76,6 +82,12 @@ func (e *ExternalAuthServiceImpl) CreateExternalAuthProvider(cluster *cmv1.Clust
 	clusterKey string,
 	args *ExternalAuthProvidersArgs, r *rosa.Runtime) error {
 
+	err := ValidateExternalAuthProvidersArgs(args)
+	if err != nil {
+		return fmt.Errorf("failed to create an external authentication provider for cluster '%s': %s",
+			clusterKey, err)
+	}
+
 	externalAuthConfig, err := CreateExternalAuthConfig(args)
 	if err != nil {
 		return fmt.Errorf("failed to create an external authentication provider for cluster '%s': %s",
@@ -92,6 +104,30 @@ func (e *ExternalAuthServiceImpl) CreateExternalAuthProvider(cluster *cmv1.Clust
 
 }
 
+func (e *ExternalAuthServiceImpl) UpdateExternalAuthProvider(cluster *cmv1.Cluster,
+	clusterKey string,
+	args *ExternalAuthProvidersArgs, r *rosa.Runtime) error {
+
+	err := ValidateExternalAuthProvidersArgs(args)
+	if err != nil {
+		return fmt.Errorf("failed to update external authentication provider '%s' for cluster '%s': %s",
+			args.name, clusterKey, err)
+	}
+
+	externalAuthConfig, err := CreateExternalAuthConfig(args)
+	if err != nil {
+		return fmt.Errorf("failed to update external authentication provider '%s' for cluster '%s': %s",
+			args.name, clusterKey, err)
+	}
+
+	_, err = r.OCMClient.UpdateExternalAuth(cluster.ID(), args.name, externalAuthConfig)
+	if err != nil {
+		return fmt.Errorf("failed to update external authentication provider '%s' for cluster '%s': %s",
+			args.name, clusterKey, err)
+	}
+	return nil
+}
+
 func ValidateHCPCluster(cluster *cmv1.Cluster) error {
 	if !cluster.Hypershift().Enabled() {
 		return fmt.Errorf(
@@ -184,6 +220,7 @@ func GetExternalAuthOptions(
 	result.issuerAudiences = externalAuthProvidersArgs.issuerAudiences
 	result.issuerUrl = externalAuthProvidersArgs.issuerUrl
 	result.issuerCaFile = externalAuthProvidersArgs.issuerCaFile
+	result.issuerCa = externalAuthProvidersArgs.issuerCa
 	result.claimMappingGroupsClaim = externalAuthProvidersArgs.claimMappingGroupsClaim
 	result.claimMappingUsernameClaim = externalAuthProvidersArgs.claimMappingUsernameClaim
 	result.claimValidationRule = externalAuthProvidersArgs.claimValidationRule
@@ -199,7 +236,7 @@ func GetExternalAuthOptions(
 		}
 	}
 
-	if interactive.Enabled() && !cmd.Changed(nameFlag) {
+	if interactive.Enabled() && !cmd.Changed(nameFlag) && result.name == "" {
 		result.name, err = interactive.GetString(interactive.Input{
 			Question: "Name",
 			Default:  result.name,
@@ -324,27 +361,21 @@ func CreateExternalAuthConfig(args *ExternalAuthProvidersArgs) (*cmv1.ExternalAu
 	externalAuthBuilder := cmv1.NewExternalAuth().ID(args.name)
 	claimValidationRules := args.claimValidationRule
 
-	if args.issuerUrl != "" || args.issuerCaFile != "" || args.issuerAudiences != nil {
+	if args.issuerUrl != "" || args.issuerCaFile != "" || args.issuerCa != "" || args.issuerAudiences != nil {
 		tokenIssuerBuilder := cmv1.NewTokenIssuer()
 
 		if args.issuerUrl != "" {
 			tokenIssuerBuilder.URL(args.issuerUrl)
 		}
 
-		if args.issuerCaFile != "" {
-			// Get certificate contents
-			ca := ""
-			if args.issuerCaFile != "" {
-				cert, err := os.ReadFile(args.issuerCaFile)
-				if err != nil {
-					return &cmv1.ExternalAuth{}, fmt.Errorf("expected a valid certificate bundle: %s", err)
-				}
-				ca = string(cert)
-			}
-			// Set the CA file, if any
-			if ca != "" {
-				tokenIssuerBuilder.CA(ca)
-			}
+		// Get certificate contents
+		ca, err := issuerCaContents(args)
+		if err != nil {
+			return &cmv1.ExternalAuth{}, err
+		}
+		// Set the CA file, if any
+		if ca != "" {
+			tokenIssuerBuilder.CA(ca)
 		}
 
 		if args.issuerAudiences != nil {
@@ -408,3 +439,145 @@ func IsExternalAuthProviderSetViaCLI(cmd *pflag.FlagSet, prefix string) bool {
 
 	return false
 }
+
+// IsExternalAuthProviderEditedViaCLI reports whether any attribute other than the name has been set,
+// which is what an edit needs to know since the name only selects the provider to update.
+func IsExternalAuthProviderEditedViaCLI(cmd *pflag.FlagSet, prefix string) bool {
+	for _, parameter := range []string{issuerAudiencesFlag, issuerUrlFlag,
+		issuerCaFileFlag, claimMappingGroupsClaimFlag, claimMappingUsernameClaimFlag,
+		claimValidationRuleFlag, consoleClientIdFlag, consoleClientSecretFlag} {
+
+		if cmd.Changed(fmt.Sprintf("%s%s", prefix, parameter)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MergeExternalAuthArgs returns the arguments for updating the given external authentication provider,
+// taking every attribute that hasn't been set via the command line from its current configuration.
+// The console client is only carried over when one of its flags is set, as OCM never returns its secret.
+func MergeExternalAuthArgs(cmd *pflag.FlagSet, prefix string, externalAuth *cmv1.ExternalAuth,
+	args *ExternalAuthProvidersArgs) *ExternalAuthProvidersArgs {
+	result := *args
+	result.name = externalAuth.ID()
+
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, issuerUrlFlag)) {
+		result.issuerUrl = externalAuth.Issuer().URL()
+	}
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, issuerAudiencesFlag)) {
+		result.issuerAudiences = externalAuth.Issuer().Audiences()
+	}
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, issuerCaFileFlag)) {
+		result.issuerCa = externalAuth.Issuer().CA()
+	}
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, claimMappingGroupsClaimFlag)) {
+		result.claimMappingGroupsClaim = externalAuth.Claim().Mappings().Groups().Claim()
+	}
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, claimMappingUsernameClaimFlag)) {
+		result.claimMappingUsernameClaim = externalAuth.Claim().Mappings().UserName().Claim()
+	}
+	if !cmd.Changed(fmt.Sprintf("%s%s", prefix, claimValidationRuleFlag)) {
+		result.claimValidationRule = nil
+		for _, rule := range externalAuth.Claim().ValidationRules() {
+			result.claimValidationRule = append(result.claimValidationRule,
+				fmt.Sprintf("%s:%s", rule.Claim(), rule.RequiredValue()))
+		}
+	}
+	consoleClientChanged := cmd.Changed(fmt.Sprintf("%s%s", prefix, consoleClientIdFlag)) ||
+		cmd.Changed(fmt.Sprintf("%s%s", prefix, consoleClientSecretFlag))
+	if consoleClientChanged && !cmd.Changed(fmt.Sprintf("%s%s", prefix, consoleClientIdFlag)) &&
+		len(externalAuth.Clients()) > 0 {
+		result.consoleClientId = externalAuth.Clients()[0].ID()
+	}
+
+	return &result
+}
+
+var claimNameRE = regexp.MustCompile(`^\S+$`)
+
+// ValidateExternalAuthProvidersArgs checks the external authentication provider configuration locally,
+// without reaching out to the issuer, so that obvious mistakes are caught before submitting it to OCM.
+func ValidateExternalAuthProvidersArgs(args *ExternalAuthProvidersArgs) error {
+	if args.issuerUrl != "" {
+		err := interactive.IsURLHttps(args.issuerUrl)
+		if err != nil {
+			return fmt.Errorf("invalid issuer URL: %s", err)
+		}
+	}
+
+	for _, audience := range args.issuerAudiences {
+		if strings.TrimSpace(audience) == "" {
+			return fmt.Errorf("issuer audiences must not contain empty values")
+		}
+	}
+
+	ca, err := issuerCaContents(args)
+	if err != nil {
+		return err
+	}
+	if ca != "" {
+		err = validateCaBundle(ca)
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.claimMappingUsernameClaim != "" && !claimNameRE.MatchString(args.claimMappingUsernameClaim) {
+		return fmt.Errorf("invalid claim mapping username '%s': claim names must not contain whitespace",
+			args.claimMappingUsernameClaim)
+	}
+	if args.claimMappingGroupsClaim != "" && !claimNameRE.MatchString(args.claimMappingGroupsClaim) {
+		return fmt.Errorf("invalid claim mapping groups '%s': claim names must not contain whitespace",
+			args.claimMappingGroupsClaim)
+	}
+
+	if len(args.claimValidationRule) > 0 {
+		err = ocm.ValidateClaimValidationRules(strings.Join(args.claimValidationRule, ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.consoleClientSecret != "" && args.consoleClientId == "" {
+		return fmt.Errorf("a console client secret requires a console client id")
+	}
+
+	return nil
+}
+
+func issuerCaContents(args *ExternalAuthProvidersArgs) (string, error) {
+	if args.issuerCaFile == "" {
+		return args.issuerCa, nil
+	}
+	cert, err := os.ReadFile(args.issuerCaFile)
+	if err != nil {
+		return "", fmt.Errorf("expected a valid certificate bundle: %s", err)
+	}
+	return string(cert), nil
+}
+
+func validateCaBundle(ca string) error {
+	rest := []byte(ca)
+	certificates := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("expected a valid certificate bundle: unexpected PEM block of type '%s'", block.Type)
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("expected a valid certificate bundle: %s", err)
+		}
+		certificates++
+	}
+	if certificates == 0 || strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("expected a valid certificate bundle: no PEM encoded certificates found")
+	}
+	return nil
+}
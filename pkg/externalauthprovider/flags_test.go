@@ -1,9 +1,21 @@
 package externalauthprovider
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2/dsl/core"
 	. "github.com/onsi/gomega"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
@@ -67,3 +79,150 @@ var _ = Describe("External authentication provider", func() {
 		Expect(err).To(Not(HaveOccurred()))
 	})
 })
+
+var _ = Describe("External authentication provider validation", func() {
+
+	var args *ExternalAuthProvidersArgs
+
+	BeforeEach(func() {
+		args = &ExternalAuthProvidersArgs{
+			name:                      "exauth",
+			issuerUrl:                 "https://test.com",
+			issuerAudiences:           []string{"abc"},
+			claimMappingUsernameClaim: "email",
+			claimMappingGroupsClaim:   "groups",
+		}
+	})
+
+	It("OK: valid configuration", func() {
+		Expect(ValidateExternalAuthProvidersArgs(args)).To(Succeed())
+	})
+
+	It("KO: issuer url without https scheme", func() {
+		args.issuerUrl = "http://test.com"
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid issuer URL"))
+	})
+
+	It("KO: empty audience", func() {
+		args.issuerAudiences = []string{"abc", " "}
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("issuer audiences must not contain empty values"))
+	})
+
+	It("KO: claim mapping with whitespace", func() {
+		args.claimMappingGroupsClaim = "my groups"
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid claim mapping groups 'my groups'"))
+	})
+
+	It("KO: malformed claim validation rule", func() {
+		args.claimValidationRule = []string{"claim"}
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Should be in a <claim>:<required_value> format"))
+	})
+
+	It("KO: console client secret without client id", func() {
+		args.consoleClientSecret = "secret"
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("a console client secret requires a console client id"))
+	})
+
+	It("OK: PEM encoded CA bundle", func() {
+		args.issuerCaFile = writeCaFile(generateCertificate())
+		Expect(ValidateExternalAuthProvidersArgs(args)).To(Succeed())
+	})
+
+	It("KO: CA file without certificates", func() {
+		args.issuerCaFile = writeCaFile("not a certificate")
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no PEM encoded certificates found"))
+	})
+
+	It("KO: CA bundle from the current configuration is invalid", func() {
+		args.issuerCa = "-----BEGIN CERTIFICATE-----\nYWJj\n-----END CERTIFICATE-----\n"
+		err := ValidateExternalAuthProvidersArgs(args)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected a valid certificate bundle"))
+	})
+})
+
+var _ = Describe("Merging external authentication provider arguments", func() {
+
+	var cmd *cobra.Command
+	var args *ExternalAuthProvidersArgs
+	var externalAuth *cmv1.ExternalAuth
+
+	BeforeEach(func() {
+		cmd = &cobra.Command{}
+		args = AddExternalAuthProvidersFlags(cmd, "")
+
+		var err error
+		externalAuth, err = cmv1.NewExternalAuth().ID("exauth").
+			Issuer(cmv1.NewTokenIssuer().URL("https://test.com").Audiences("abc").CA("ca")).
+			Claim(cmv1.NewExternalAuthClaim().
+				Mappings(cmv1.NewTokenClaimMappings().
+					UserName(cmv1.NewUsernameClaim().Claim("email")).
+					Groups(cmv1.NewGroupsClaim().Claim("groups"))).
+				ValidationRules(cmv1.NewTokenClaimValidationRule().Claim("tenant").RequiredValue("abc"))).
+			Clients(cmv1.NewExternalAuthClientConfig().ID("console")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Keeps the current values for flags that are not set", func() {
+		Expect(cmd.Flags().Set(issuerAudiencesFlag, "def,ghi")).To(Succeed())
+
+		result := MergeExternalAuthArgs(cmd.Flags(), "", externalAuth, args)
+		Expect(result.name).To(Equal("exauth"))
+		Expect(result.issuerAudiences).To(Equal([]string{"def", "ghi"}))
+		Expect(result.issuerUrl).To(Equal("https://test.com"))
+		Expect(result.issuerCa).To(Equal("ca"))
+		Expect(result.claimMappingUsernameClaim).To(Equal("email"))
+		Expect(result.claimMappingGroupsClaim).To(Equal("groups"))
+		Expect(result.claimValidationRule).To(Equal([]string{"tenant:abc"}))
+		Expect(result.consoleClientId).To(BeEmpty())
+	})
+
+	It("Carries over the console client id when only the secret is set", func() {
+		Expect(cmd.Flags().Set(consoleClientSecretFlag, "secret")).To(Succeed())
+
+		result := MergeExternalAuthArgs(cmd.Flags(), "", externalAuth, args)
+		Expect(result.consoleClientId).To(Equal("console"))
+		Expect(result.consoleClientSecret).To(Equal("secret"))
+	})
+
+	It("Only reports edits of attributes other than the name", func() {
+		Expect(cmd.Flags().Set(nameFlag, "exauth")).To(Succeed())
+		Expect(IsExternalAuthProviderEditedViaCLI(cmd.Flags(), "")).To(BeFalse())
+
+		Expect(cmd.Flags().Set(issuerUrlFlag, "https://other.com")).To(Succeed())
+		Expect(IsExternalAuthProviderEditedViaCLI(cmd.Flags(), "")).To(BeTrue())
+	})
+})
+
+func generateCertificate() string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func writeCaFile(contents string) string {
+	path := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+	Expect(os.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+	return path
+}
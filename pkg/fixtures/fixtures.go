@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures replays HTTP exchanges with OCM and AWS recorded in HAR files, so that
+// command tests can run offline against realistic traffic. Tests pass the transport returned by
+// the Start function to the OCM and AWS client builders. Fixtures are recorded with the
+// '--trace-file' flag, or by running the tests with the ROSA_RECORD_FIXTURES environment variable
+// set to 'true', which sends the requests to the real services and records their responses.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/openshift/rosa/pkg/logging"
+)
+
+// RecordEnvKey is the environment variable that, when set to 'true', makes the Start function
+// record the fixtures instead of replaying them.
+const RecordEnvKey = "ROSA_RECORD_FIXTURES"
+
+// Headers of the requests that identify the operation in addition to the method, URL and body
+var matchHeaders = []string{"X-Amz-Target"}
+
+// Start returns a transport that replays the exchanges recorded in the given HAR file. If the
+// ROSA_RECORD_FIXTURES environment variable is 'true' the transport sends the requests to the real
+// services instead, and the trace recorder of the clients writes them to that file. Call the Stop
+// method when the test finishes.
+func Start(path string) (*Transport, error) {
+	if Recording() {
+		logging.SetTraceFile(path)
+		return &Transport{
+			path:      path,
+			recording: true,
+		}, nil
+	}
+	err := Check(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Transport{
+		path:    path,
+		entries: entries,
+	}, nil
+}
+
+// Recording returns true if fixtures are recorded instead of replayed
+func Recording() bool {
+	record, _ := strconv.ParseBool(os.Getenv(RecordEnvKey))
+	return record
+}
+
+// Check returns an error if the given HAR file contains values of sensitive headers, query
+// parameters or body fields that haven't been redacted, so that secrets are never committed.
+func Check(path string) error {
+	entries, err := load(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		var leaks []string
+		for _, header := range entry.Request.Headers {
+			if logging.Sensitive(header.Name) && header.Value != redacted {
+				leaks = append(leaks, header.Name)
+			}
+		}
+		for _, header := range entry.Response.Headers {
+			if logging.Sensitive(header.Name) && header.Value != redacted {
+				leaks = append(leaks, header.Name)
+			}
+		}
+		parsed, err := url.Parse(entry.Request.URL)
+		if err == nil {
+			for name, values := range parsed.Query() {
+				for _, value := range values {
+					if logging.Sensitive(name) && value != redacted {
+						leaks = append(leaks, name)
+					}
+				}
+			}
+		}
+		if entry.Request.PostData != nil {
+			leaks = append(leaks, logging.UnredactedFields(entry.Request.header("Content-Type"),
+				[]byte(entry.Request.PostData.Text))...)
+		}
+		leaks = append(leaks, logging.UnredactedFields(entry.Response.header("Content-Type"),
+			[]byte(entry.Response.Content.Text))...)
+		if len(leaks) > 0 {
+			return fmt.Errorf("Fixtures file '%s' contains unredacted values of '%s' in %s %s",
+				path, strings.Join(leaks, "', '"), entry.Request.Method, entry.Request.URL)
+		}
+	}
+	return nil
+}
+
+// redacted is the value of the sensitive values in the recorded fixtures
+const redacted = "***"
+
+// Transport answers the requests with the responses recorded for them. Recorded exchanges are
+// used in order, and once all the exchanges for a request have been used the last one is
+// repeated, so that polling loops end with the last recorded state.
+type Transport struct {
+	path      string
+	recording bool
+	lock      sync.Mutex
+	entries   []*entry
+}
+
+type entry struct {
+	Request  request  `json:"request"`
+	Response response `json:"response"`
+	used     bool
+}
+
+type request struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Headers  []nameValue `json:"headers"`
+	PostData *struct {
+		Text string `json:"text"`
+	} `json:"postData"`
+}
+
+type response struct {
+	Status  int         `json:"status"`
+	Headers []nameValue `json:"headers"`
+	Content struct {
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+type nameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func load(path string) ([]*entry, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read fixtures file: %v", err)
+	}
+	document := struct {
+		Log struct {
+			Entries []*entry `json:"entries"`
+		} `json:"log"`
+	}{}
+	err = json.Unmarshal(data, &document)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse fixtures file '%s': %v", path, err)
+	}
+	return document.Log.Entries, nil
+}
+
+// Stop stops recording the fixtures, and checks that the recorded file doesn't contain secrets
+func (t *Transport) Stop() error {
+	if !t.recording {
+		return nil
+	}
+	logging.SetTraceFile("")
+	return Check(t.path)
+}
+
+// Unused returns the recorded exchanges that haven't been replayed, as 'METHOD URL' strings
+func (t *Transport) Unused() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	result := []string{}
+	for _, entry := range t.entries {
+		if !entry.used {
+			result = append(result, entry.Request.Method+" "+entry.Request.URL)
+		}
+	}
+	return result
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.recording {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	url := logging.RedactURL(req.URL)
+	text := string(logging.RedactBody(req.Header.Get("Content-Type"), body))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var found *entry
+	for _, candidate := range t.entries {
+		if !candidate.matches(req, url, text) {
+			continue
+		}
+		found = candidate
+		if !candidate.used {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("No recorded exchange for %s %s", req.Method, url)
+	}
+	found.used = true
+	return found.response(req), nil
+}
+
+func (e *entry) matches(req *http.Request, url string, body string) bool {
+	if e.Request.Method != req.Method || e.Request.URL != url {
+		return false
+	}
+	recorded := ""
+	if e.Request.PostData != nil {
+		recorded = e.Request.PostData.Text
+	}
+	if recorded != body {
+		return false
+	}
+	for _, name := range matchHeaders {
+		if e.Request.header(name) != req.Header.Get(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *request) header(name string) string {
+	return headerValue(r.Headers, name)
+}
+
+func (r *response) header(name string) string {
+	return headerValue(r.Headers, name)
+}
+
+func headerValue(headers []nameValue, name string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value
+		}
+	}
+	return ""
+}
+
+func (e *entry) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	for _, value := range e.Response.Headers {
+		header.Add(value.Name, value.Value)
+	}
+	// The recorded body may have been redacted, so the recorded length may be wrong:
+	header.Del("Content-Length")
+	body := []byte(e.Response.Content.Text)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Response.Status, http.StatusText(e.Response.Status)),
+		StatusCode:    e.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -168,6 +168,22 @@ func DisplaySpinnerWithDelay(reporter *reporter.Object, infoMessage string, dela
 	}
 }
 
+// FormatTimeUntil returns a short human readable description of the time left until t, such as
+// 'in 3d 4h', 'in 5h 20m' or 'expired' when t is not after now
+func FormatTimeUntil(t time.Time, now time.Time) string {
+	left := t.Sub(now)
+	switch {
+	case left <= 0:
+		return "expired"
+	case left < time.Hour:
+		return fmt.Sprintf("in %dm", int(left.Minutes()))
+	case left < 48*time.Hour:
+		return fmt.Sprintf("in %dh %dm", int(left.Hours()), int(left.Minutes())%60)
+	default:
+		return fmt.Sprintf("in %dd %dh", int(left.Hours())/24, int(left.Hours())%24)
+	}
+}
+
 func SaveDocument(doc, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -157,5 +158,18 @@ var _ = Describe("Helper", func() {
 				Entry("Uses the first 27 characters of the cluster name when the cluster name is > 27 chars",
 					strings.Repeat("a", 54), strings.Repeat("a", 27)))
 		})
+
+		var _ = Context("FormatTimeUntil()", func() {
+			now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+			DescribeTable("FormatTimeUntil test cases",
+				func(t time.Time, expected string) {
+					Expect(FormatTimeUntil(t, now)).To(Equal(expected))
+				},
+				Entry("Past time", now.Add(-time.Minute), "expired"),
+				Entry("Minutes", now.Add(42*time.Minute), "in 42m"),
+				Entry("Hours", now.Add(26*time.Hour+5*time.Minute), "in 26h 5m"),
+				Entry("Days", now.Add(74*time.Hour), "in 3d 2h"),
+			)
+		})
 	})
 })
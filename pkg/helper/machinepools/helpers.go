@@ -104,6 +104,7 @@ func GetTaints(cmd *cobra.Command, r *rosa.Runtime, existingTaints []*cmv1.Taint
 		var err error
 		inputTaints, err = interactive.GetString(interactive.Input{
 			Question: "Taints",
+			Flag:     "taints",
 			Help:     cmd.Flags().Lookup("taints").Usage,
 			Default:  inputTaints,
 			Validators: []interactive.Validator{
@@ -195,6 +196,7 @@ func GetLabelMap(cmd *cobra.Command, r *rosa.Runtime, existingLabels map[string]
 		var err error
 		inputLabels, err = interactive.GetString(interactive.Input{
 			Question: "Labels",
+			Flag:     "labels",
 			Help:     cmd.Flags().Lookup("labels").Usage,
 			Default:  inputLabels,
 			Validators: []interactive.Validator{
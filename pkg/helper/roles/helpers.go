@@ -5,9 +5,11 @@ import (
 	"os"
 	"time"
 
+	awserr "github.com/openshift-online/ocm-common/pkg/aws/errors"
 	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
 	awsCommonValidations "github.com/openshift-online/ocm-common/pkg/aws/validations"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
@@ -16,12 +18,14 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 const (
 	RosaUpgradeAccRolesModeAuto            = "ROSAUpgradeAccountRolesModeAuto"
 	maxClusterNameLengthToUseForRolePrefix = 27
+	ShowDiffFlag                           = "show-diff"
 )
 
 // GeOperatorRolePrefixFromClusterName returns a valid operator role prefix from the cluster name
@@ -280,3 +284,43 @@ func upgradeMissingOperatorRole(missingRoles map[string]*cmv1.STSOperator, clust
 	}
 	return nil
 }
+
+// AddShowDiffFlag adds the flag used by the upgrade commands to display the permission changes of
+// each policy before upgrading it
+func AddShowDiffFlag(cmd *cobra.Command, value *string) {
+	cmd.Flags().StringVar(
+		value,
+		ShowDiffFlag,
+		"",
+		fmt.Sprintf("Show the permission changes of each policy before upgrading it. "+
+			"Valid formats are %s, defaults to '%s' when no format is given.", aws.DiffFormats, aws.DiffFormatUnified),
+	)
+	cmd.Flags().Lookup(ShowDiffFlag).NoOptDefVal = aws.DiffFormatUnified
+}
+
+// GetPolicyDiff compares the current document of the policy with the desired one. A policy that
+// doesn't exist yet is compared with an empty document, so all its permissions are shown as added.
+func GetPolicyDiff(awsClient aws.Client, name string, policyARN string, desired string) (*aws.PolicyDiff, error) {
+	current, err := awsClient.GetDefaultPolicyDocument(policyARN)
+	if err != nil {
+		if !awserr.IsNoSuchEntityException(err) {
+			return nil, fmt.Errorf("Failed to get the document of policy '%s': %v", policyARN, err)
+		}
+		current = ""
+	}
+	return aws.DiffPolicyDocuments(name, policyARN, current, desired)
+}
+
+// PrintPolicyDiffs writes the changes of the given policies to the standard output
+func PrintPolicyDiffs(reporter *rprtr.Object, diffs []*aws.PolicyDiff, format string) error {
+	result, err := aws.FormatPolicyDiffs(diffs, format)
+	if err != nil {
+		return err
+	}
+	if format == aws.DiffFormatUnified && result == "" {
+		reporter.Infof("The upgrade doesn't change the permissions of any policy")
+		return nil
+	}
+	fmt.Print(result)
+	return nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to manage the local hibernation schedules that
+// 'rosa hibernation run' executes against OCM.
+
+package hibernation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	// Embed the time zone database so that schedules can be evaluated in minimal containers
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+// LocationEnvKey is the environment variable that overrides the location of the schedules file
+const LocationEnvKey = "ROSA_HIBERNATION_SCHEDULES"
+
+type Action string
+
+const (
+	ActionNone      Action = ""
+	ActionHibernate Action = "hibernate"
+	ActionResume    Action = "resume"
+)
+
+// Schedule describes when a cluster should be hibernated and resumed, using cron expressions
+// evaluated in the given time zone
+type Schedule struct {
+	ClusterID   string    `json:"cluster_id"`
+	ClusterName string    `json:"cluster_name"`
+	Hibernate   string    `json:"hibernate"`
+	Resume      string    `json:"resume"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastRun     time.Time `json:"last_run,omitempty"`
+}
+
+// Spec is the content of the schedules file
+type Spec struct {
+	Schedules []*Schedule `json:"schedules"`
+}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// Location returns the location of the schedules file. It can be overridden with the
+// ROSA_HIBERNATION_SCHEDULES environment variable, which is useful when running in a container.
+func Location() (string, error) {
+	if path := os.Getenv(LocationEnvKey); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "rosa", "hibernation-schedules.json"), nil
+}
+
+// Load reads the schedules file. If the file doesn't exist it returns an empty spec.
+func Load(path string) (*Spec, error) {
+	spec := &Spec{}
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return spec, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read hibernation schedules file '%s': %v", path, err)
+	}
+	err = json.Unmarshal(data, spec)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse hibernation schedules file '%s': %v", path, err)
+	}
+	return spec, nil
+}
+
+// Save writes the schedules file, creating its directory if needed. The content is written to a
+// temporary file that then replaces the existing one, so that readers never see a partial file.
+func Save(path string, spec *Spec) error {
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't marshal hibernation schedules: %v", err)
+	}
+	dir := filepath.Dir(path)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("Failed to create directory %s: %v", dir, err)
+	}
+	file, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Failed to write file '%s': %v", path, err)
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("Failed to write file '%s': %v", path, err)
+	}
+	return nil
+}
+
+// Update loads the schedules file, applies the given change to it and saves it. Reading the file
+// right before writing it keeps the changes made by other commands since it was last loaded.
+func Update(path string, change func(spec *Spec) error) error {
+	spec, err := Load(path)
+	if err != nil {
+		return err
+	}
+	err = change(spec)
+	if err != nil {
+		return err
+	}
+	return Save(path, spec)
+}
+
+// RecordRuns sets the time of the last run of the schedules of the given cluster IDs. Schedules
+// that were deleted since they were executed are not added back.
+func RecordRuns(path string, runs map[string]time.Time) error {
+	return Update(path, func(spec *Spec) error {
+		for _, schedule := range spec.Schedules {
+			if lastRun, ok := runs[schedule.ClusterID]; ok {
+				schedule.LastRun = lastRun
+			}
+		}
+		return nil
+	})
+}
+
+// Find returns the schedule of the cluster with the given name or ID, or nil if there is none
+func (s *Spec) Find(clusterKey string) *Schedule {
+	for _, schedule := range s.Schedules {
+		if schedule.ClusterID == clusterKey || schedule.ClusterName == clusterKey {
+			return schedule
+		}
+	}
+	return nil
+}
+
+// Remove deletes the schedule of the cluster with the given name or ID and reports whether it existed
+func (s *Spec) Remove(clusterKey string) bool {
+	for i, schedule := range s.Schedules {
+		if schedule.ClusterID == clusterKey || schedule.ClusterName == clusterKey {
+			s.Schedules = append(s.Schedules[:i], s.Schedules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the cron expressions and the time zone of the schedule can be parsed
+func (s *Schedule) Validate() error {
+	_, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return fmt.Errorf("Time zone '%s' is not valid: %v", s.Timezone, err)
+	}
+	_, err = cronParser.Parse(s.Hibernate)
+	if err != nil {
+		return fmt.Errorf("Hibernate schedule '%s' is not a valid cron expression: %v", s.Hibernate, err)
+	}
+	_, err = cronParser.Parse(s.Resume)
+	if err != nil {
+		return fmt.Errorf("Resume schedule '%s' is not a valid cron expression: %v", s.Resume, err)
+	}
+	return nil
+}
+
+// Next returns the next action of the schedule after the given time and when it will happen
+func (s *Schedule) Next(now time.Time) (Action, time.Time, error) {
+	hibernate, resume, err := s.parse()
+	if err != nil {
+		return ActionNone, time.Time{}, err
+	}
+	nextHibernate := hibernate.Next(now)
+	nextResume := resume.Next(now)
+	if nextResume.Before(nextHibernate) {
+		return ActionResume, nextResume, nil
+	}
+	return ActionHibernate, nextHibernate, nil
+}
+
+// Due returns the action that should be executed at the given time: the most recent hibernate or
+// resume occurrence since the schedule last ran. If nothing happened since then it returns ActionNone.
+func (s *Schedule) Due(now time.Time) (Action, time.Time, error) {
+	hibernate, resume, err := s.parse()
+	if err != nil {
+		return ActionNone, time.Time{}, err
+	}
+	since := s.LastRun
+	if since.IsZero() {
+		since = s.CreatedAt
+	}
+	lastHibernate := lastOccurrence(hibernate, since, now)
+	lastResume := lastOccurrence(resume, since, now)
+	switch {
+	case lastHibernate.IsZero() && lastResume.IsZero():
+		return ActionNone, time.Time{}, nil
+	case lastResume.After(lastHibernate):
+		return ActionResume, lastResume, nil
+	default:
+		return ActionHibernate, lastHibernate, nil
+	}
+}
+
+func (s *Schedule) parse() (cron.Schedule, cron.Schedule, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	hibernate, _ := cronParser.Parse(fmt.Sprintf("CRON_TZ=%s %s", s.Timezone, s.Hibernate))
+	resume, _ := cronParser.Parse(fmt.Sprintf("CRON_TZ=%s %s", s.Timezone, s.Resume))
+	return hibernate, resume, nil
+}
+
+// lastOccurrence returns the latest activation of the schedule in the (since, now] interval, or
+// the zero time if there is none
+func lastOccurrence(schedule cron.Schedule, since time.Time, now time.Time) time.Time {
+	var last time.Time
+	for next := schedule.Next(since); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		last = next
+	}
+	return last
+}
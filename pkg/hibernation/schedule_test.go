@@ -0,0 +1,126 @@
+package hibernation
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hibernation schedule", func() {
+	var schedule *Schedule
+	var prague *time.Location
+
+	BeforeEach(func() {
+		var err error
+		prague, err = time.LoadLocation("Europe/Prague")
+		Expect(err).NotTo(HaveOccurred())
+		schedule = &Schedule{
+			ClusterID:   "24vf9iitg3p6tlml88iml6j6mu095mh8",
+			ClusterName: "dev",
+			Hibernate:   "0 19 * * 1-5",
+			Resume:      "0 7 * * 1-5",
+			Timezone:    "Europe/Prague",
+			// Monday
+			CreatedAt: time.Date(2024, 3, 4, 12, 0, 0, 0, prague),
+		}
+	})
+
+	It("Fails with an invalid cron expression", func() {
+		schedule.Hibernate = "0 19 * *"
+		err := schedule.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Hibernate schedule '0 19 * *' is not a valid cron expression"))
+	})
+
+	It("Fails with an invalid time zone", func() {
+		schedule.Timezone = "Europe/Nowhere"
+		err := schedule.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Time zone 'Europe/Nowhere' is not valid"))
+	})
+
+	It("Returns the next action in the schedule time zone", func() {
+		action, at, err := schedule.Next(time.Date(2024, 3, 4, 17, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action).To(Equal(ActionHibernate))
+		Expect(at.Equal(time.Date(2024, 3, 4, 19, 0, 0, 0, prague))).To(BeTrue())
+	})
+
+	It("Has nothing due before the first occurrence", func() {
+		action, _, err := schedule.Due(time.Date(2024, 3, 4, 18, 59, 0, 0, prague))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action).To(Equal(ActionNone))
+	})
+
+	It("Returns the hibernate action once its time has passed", func() {
+		action, at, err := schedule.Due(time.Date(2024, 3, 4, 19, 5, 0, 0, prague))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action).To(Equal(ActionHibernate))
+		Expect(at.Equal(time.Date(2024, 3, 4, 19, 0, 0, 0, prague))).To(BeTrue())
+	})
+
+	It("Returns the most recent action when several have passed since the last run", func() {
+		schedule.LastRun = time.Date(2024, 3, 4, 19, 5, 0, 0, prague)
+		action, at, err := schedule.Due(time.Date(2024, 3, 5, 8, 0, 0, 0, prague))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action).To(Equal(ActionResume))
+		Expect(at.Equal(time.Date(2024, 3, 5, 7, 0, 0, 0, prague))).To(BeTrue())
+	})
+
+	It("Does not resume on weekends", func() {
+		// Friday evening until Sunday
+		schedule.LastRun = time.Date(2024, 3, 8, 19, 5, 0, 0, prague)
+		action, _, err := schedule.Due(time.Date(2024, 3, 10, 12, 0, 0, 0, prague))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action).To(Equal(ActionNone))
+	})
+
+	It("Saves and loads the schedules file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rosa", "schedules.json")
+		spec, err := Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec.Schedules).To(BeEmpty())
+
+		spec.Schedules = append(spec.Schedules, schedule)
+		Expect(Save(path, spec)).To(Succeed())
+
+		spec, err = Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec.Find("dev")).NotTo(BeNil())
+		Expect(spec.Find(schedule.ClusterID).Hibernate).To(Equal("0 19 * * 1-5"))
+		Expect(spec.Remove("dev")).To(BeTrue())
+		Expect(spec.Find("dev")).To(BeNil())
+	})
+
+	It("Records the runs without losing schedules changed since they were loaded", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "schedules.json")
+		Expect(Save(path, &Spec{Schedules: []*Schedule{schedule}})).To(Succeed())
+
+		// Another command adds a schedule while the actions are executed
+		added := *schedule
+		added.ClusterID = "24vf9iitg3p6tlml88iml6j6mu095mh9"
+		added.ClusterName = "test"
+		Expect(Update(path, func(spec *Spec) error {
+			spec.Schedules = append(spec.Schedules, &added)
+			return nil
+		})).To(Succeed())
+
+		now := time.Date(2024, 3, 4, 20, 0, 0, 0, prague)
+		Expect(RecordRuns(path, map[string]time.Time{
+			schedule.ClusterID:                 now,
+			"24vf9iitg3p6tlml88iml6j6mu095mh0": now,
+		})).To(Succeed())
+
+		spec, err := Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec.Schedules).To(HaveLen(2))
+		Expect(spec.Find("dev").LastRun.Equal(now)).To(BeTrue())
+		Expect(spec.Find("test").LastRun.IsZero()).To(BeTrue())
+		entries, err := os.ReadDir(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--answers' and '--save-answers' command
+// line options, which replay and record the answers given in interactive mode. Answers are
+// stored in a YAML file keyed by the name of the flag that each prompt corresponds to.
+
+package interactive
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+)
+
+var (
+	answersFile     string
+	saveAnswersFile string
+
+	// Answers loaded from the answers file, used as the defaults of the prompts
+	answers = map[string]interface{}{}
+
+	// Answers given to the prompts in this session
+	recorded = map[string]interface{}{}
+)
+
+// AddAnswersFlags adds the flags that load and save the answers of the interactive mode
+func AddAnswersFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&answersFile,
+		"answers",
+		"",
+		"Path to a YAML file with the answers of a previous interactive session, keyed by flag name. "+
+			"The answers are used as the defaults of the prompts. Enables interactive mode.",
+	)
+	flags.StringVar(
+		&saveAnswersFile,
+		"save-answers",
+		"",
+		"Path of the YAML file where the answers given in interactive mode are saved, keyed by flag name.",
+	)
+}
+
+// LoadAnswers reads the file given with the '--answers' flag, if any, and enables interactive
+// mode. Answers for flags that were set in the command line are ignored, so that the command
+// line always takes precedence.
+func LoadAnswers(flags *pflag.FlagSet) error {
+	if answersFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(answersFile)
+	if err != nil {
+		return fmt.Errorf("Failed to read answers file: %v", err)
+	}
+	loaded := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &loaded)
+	if err != nil {
+		return fmt.Errorf("Failed to parse answers file '%s': %v", answersFile, err)
+	}
+	for name, value := range loaded {
+		if flags.Lookup(name) == nil {
+			return fmt.Errorf("Answers file '%s' has an answer for unknown flag '%s'", answersFile, name)
+		}
+		if flags.Changed(name) {
+			continue
+		}
+		answers[name] = value
+	}
+	Enable()
+	return nil
+}
+
+// SaveAnswers writes the answers given in this session to the file given with the
+// '--save-answers' flag, if any. Passwords are never saved.
+func SaveAnswers() error {
+	if saveAnswersFile == "" {
+		return nil
+	}
+	data, err := yaml.Marshal(recorded)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(saveAnswersFile, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to save answers file: %v", err)
+	}
+	return nil
+}
+
+func recordAnswer(flag string, value interface{}) {
+	if flag == "" {
+		return
+	}
+	recorded[flag] = value
+}
+
+// The following functions return the answer loaded for the given flag converted to the type of
+// the prompt. Lists are accepted as comma-separated strings and vice versa, as in the flags.
+
+func answerString(flag string) (string, bool, error) {
+	value, ok := answers[flag]
+	if flag == "" || !ok {
+		return "", false, nil
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed, true, nil
+	case []interface{}:
+		items, err := answerItems(flag, typed)
+		return strings.Join(items, ","), true, err
+	case nil:
+		return "", true, nil
+	default:
+		return fmt.Sprintf("%v", typed), true, nil
+	}
+}
+
+func answerStrings(flag string) ([]string, bool, error) {
+	value, ok := answers[flag]
+	if flag == "" || !ok {
+		return nil, false, nil
+	}
+	switch typed := value.(type) {
+	case []interface{}:
+		items, err := answerItems(flag, typed)
+		return items, true, err
+	case string:
+		if typed == "" {
+			return []string{}, true, nil
+		}
+		return strings.Split(typed, ","), true, nil
+	case nil:
+		return []string{}, true, nil
+	default:
+		return nil, false, invalidAnswer(flag, value)
+	}
+}
+
+func answerItems(flag string, values []interface{}) ([]string, error) {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		item, ok := value.(string)
+		if !ok {
+			return nil, invalidAnswer(flag, values)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func answerInt(flag string) (int, bool, error) {
+	value, ok := answers[flag]
+	if flag == "" || !ok {
+		return 0, false, nil
+	}
+	switch typed := value.(type) {
+	case float64:
+		if typed != float64(int(typed)) {
+			return 0, false, invalidAnswer(flag, value)
+		}
+		return int(typed), true, nil
+	case string:
+		num, err := strconv.Atoi(typed)
+		if err != nil {
+			return 0, false, invalidAnswer(flag, value)
+		}
+		return num, true, nil
+	default:
+		return 0, false, invalidAnswer(flag, value)
+	}
+}
+
+func answerFloat(flag string) (float64, bool, error) {
+	value, ok := answers[flag]
+	if flag == "" || !ok {
+		return 0, false, nil
+	}
+	switch typed := value.(type) {
+	case float64:
+		return typed, true, nil
+	case string:
+		num, err := parseFloat(typed)
+		if err != nil {
+			return 0, false, invalidAnswer(flag, value)
+		}
+		return num, true, nil
+	default:
+		return 0, false, invalidAnswer(flag, value)
+	}
+}
+
+func answerBool(flag string) (bool, bool, error) {
+	value, ok := answers[flag]
+	if flag == "" || !ok {
+		return false, false, nil
+	}
+	switch typed := value.(type) {
+	case bool:
+		return typed, true, nil
+	case string:
+		b, err := strconv.ParseBool(typed)
+		if err != nil {
+			return false, false, invalidAnswer(flag, value)
+		}
+		return b, true, nil
+	default:
+		return false, false, invalidAnswer(flag, value)
+	}
+}
+
+func invalidAnswer(flag string, value interface{}) error {
+	return fmt.Errorf("Invalid answer '%v' for flag '%s' in answers file '%s'", value, flag, answersFile)
+}
@@ -0,0 +1,111 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func newAnswersFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Cleanup(func() {
+		answersFile = ""
+		saveAnswersFile = ""
+		answers = map[string]interface{}{}
+		recorded = map[string]interface{}{}
+		enabled = false
+	})
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("cluster-name", "", "")
+	flags.Int("compute-nodes", 2, "")
+	flags.Bool("multi-az", false, "")
+	flags.StringSlice("availability-zones", nil, "")
+	AddAnswersFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return flags
+}
+
+func writeAnswers(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_LoadAnswers(t *testing.T) {
+	path := writeAnswers(t, "cluster-name: my-cluster\ncompute-nodes: 3\nmulti-az: true\n"+
+		"availability-zones:\n- us-east-1a\n- us-east-1b\n")
+	flags := newAnswersFlags(t, "--answers", path)
+	if err := LoadAnswers(flags); err != nil {
+		t.Fatal(err)
+	}
+	if !Enabled() {
+		t.Errorf("LoadAnswers() didn't enable interactive mode")
+	}
+	if got, found, err := answerString("cluster-name"); err != nil || !found || got != "my-cluster" {
+		t.Errorf("answerString() = %v, %v, %v", got, found, err)
+	}
+	if got, found, err := answerInt("compute-nodes"); err != nil || !found || got != 3 {
+		t.Errorf("answerInt() = %v, %v, %v", got, found, err)
+	}
+	if got, found, err := answerBool("multi-az"); err != nil || !found || !got {
+		t.Errorf("answerBool() = %v, %v, %v", got, found, err)
+	}
+	zones := []string{"us-east-1a", "us-east-1b"}
+	if got, found, err := answerStrings("availability-zones"); err != nil || !found || !reflect.DeepEqual(got, zones) {
+		t.Errorf("answerStrings() = %v, %v, %v", got, found, err)
+	}
+	if got, _, _ := answerString("availability-zones"); got != "us-east-1a,us-east-1b" {
+		t.Errorf("answerString() of a list = %v", got)
+	}
+}
+
+func Test_LoadAnswersCommandLinePrecedence(t *testing.T) {
+	path := writeAnswers(t, "cluster-name: my-cluster\n")
+	flags := newAnswersFlags(t, "--answers", path, "--cluster-name", "other")
+	if err := LoadAnswers(flags); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := answerString("cluster-name"); found {
+		t.Errorf("answer for a flag set in the command line wasn't ignored")
+	}
+}
+
+func Test_LoadAnswersErrors(t *testing.T) {
+	path := writeAnswers(t, "cluster-nme: my-cluster\ncompute-nodes: three\n")
+	flags := newAnswersFlags(t, "--answers", path)
+	err := LoadAnswers(flags)
+	if err == nil || !strings.Contains(err.Error(), "unknown flag 'cluster-nme'") {
+		t.Errorf("LoadAnswers() error = %v", err)
+	}
+
+	answers["compute-nodes"] = "three"
+	_, _, err = answerInt("compute-nodes")
+	if err == nil || !strings.Contains(err.Error(), "Invalid answer 'three' for flag 'compute-nodes'") {
+		t.Errorf("answerInt() error = %v", err)
+	}
+}
+
+func Test_SaveAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saved.yaml")
+	newAnswersFlags(t, "--save-answers", path)
+	recordAnswer("cluster-name", "my-cluster")
+	recordAnswer("compute-nodes", 3)
+	recordAnswer("", "ignored")
+	if err := SaveAnswers(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "cluster-name: my-cluster\ncompute-nodes: 3\n"; string(data) != want {
+		t.Errorf("SaveAnswers() wrote %q, want %q", data, want)
+	}
+}
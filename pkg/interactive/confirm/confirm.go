@@ -20,11 +20,18 @@ import (
 	"fmt"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 var yes bool
 
+// yesFlagAvailable indicates if the command being executed has the '--yes' flag
+var yesFlagAvailable bool
+
 // AddFlag adds the --yes flag to the given set of command line flags.
 func AddFlag(flags *pflag.FlagSet) {
 	flags.BoolVarP(
@@ -36,6 +43,12 @@ func AddFlag(flags *pflag.FlagSet) {
 	)
 }
 
+// SetCommand records whether the given command, which is the one being executed, has the '--yes'
+// flag, so that the prompts only suggest it to the commands that have it.
+func SetCommand(cmd *cobra.Command) {
+	yesFlagAvailable = cmd.Flags().Lookup("yes") != nil
+}
+
 func Yes() bool {
 	return yes
 }
@@ -50,10 +63,21 @@ func Confirm(q string, v ...interface{}) bool {
 	return ConfirmRaw(msg)
 }
 
+// Prompt asks the user to answer yes or no to the question. When prompts are disabled it reports
+// that the operation can't be confirmed and returns false, so that the caller cancels it.
 func Prompt(dflt bool, q string, v ...interface{}) bool {
 	if yes {
 		return yes
 	}
+	flag := ""
+	if yesFlagAvailable {
+		flag = "yes"
+	}
+	err := interactive.CheckPrompt(fmt.Sprintf(q, v...), flag)
+	if err != nil {
+		reporter.CreateReporter().Errorf("%v", err)
+		return false
+	}
 	prompt := &survey.Confirm{
 		Message: fmt.Sprintf(q, v...),
 		Default: dflt,
@@ -0,0 +1,64 @@
+package confirm
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+// captureStderr returns what the given function writes to the standard error stream
+func captureStderr(t *testing.T, function func()) string {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	function()
+	os.Stderr = stderr
+	writer.Close()
+	data, _ := io.ReadAll(reader)
+	return string(data)
+}
+
+func Test_Prompt(t *testing.T) {
+	interactive.SetNoPrompt(true)
+	t.Cleanup(func() {
+		interactive.SetNoPrompt(false)
+		yes = false
+		yesFlagAvailable = false
+	})
+
+	withYes := &cobra.Command{Use: "delete"}
+	AddFlag(withYes.Flags())
+	SetCommand(withYes)
+	var answer bool
+	message := captureStderr(t, func() {
+		answer = Prompt(true, "Delete cluster '%s'?", "mycluster")
+	})
+	if answer {
+		t.Errorf("Prompt() with prompts disabled = true")
+	}
+	if !strings.Contains(message, "Can't prompt for 'Delete cluster 'mycluster'?'") ||
+		!strings.Contains(message, "use the '--yes' flag") {
+		t.Errorf("Prompt() with prompts disabled reported %q", message)
+	}
+
+	SetCommand(&cobra.Command{Use: "upgrade"})
+	message = captureStderr(t, func() {
+		answer = Prompt(true, "Continue?")
+	})
+	if answer || strings.Contains(message, "--yes") {
+		t.Errorf("Prompt() without the '--yes' flag = %t, reported %q", answer, message)
+	}
+
+	yes = true
+	if !Prompt(false, "Continue?") {
+		t.Errorf("Prompt() with '--yes' = false")
+	}
+}
@@ -33,7 +33,9 @@ import (
 )
 
 type Input struct {
-	Question   string
+	Question string
+	// Name of the flag that the prompt corresponds to, used to save and load its answer
+	Flag       string
 	Help       string
 	Options    []string
 	Default    interface{}
@@ -45,10 +47,21 @@ type Input struct {
 func GetString(input Input) (a string, err error) {
 	transformer := survey.TransformString(helper.HandleEscapedEmptyString)
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
 	}
+	answer, found, err := answerString(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	question := input.Question
 	if !input.Required && dflt == "" {
 		question = fmt.Sprintf("%s (optional)", question)
@@ -63,16 +76,30 @@ func GetString(input Input) (a string, err error) {
 	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
 	a = transformer(a).(string)
+	if err == nil {
+		recordAnswer(input.Flag, a)
+	}
 	return
 }
 
 // Gets int number input from the command line
 func GetInt(input Input) (a int, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(int)
 	if !ok {
 		dflt = 0
 	}
+	answer, found, err := answerInt(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	dfltStr := fmt.Sprintf("%d", dflt)
 	if dfltStr == "0" && input.Required {
 		dfltStr = ""
@@ -97,7 +124,11 @@ func GetInt(input Input) (a int, err error) {
 	if str == "" {
 		return
 	}
-	return parseInt(str)
+	a, err = parseInt(str)
+	if err == nil {
+		recordAnswer(input.Flag, a)
+	}
+	return
 }
 
 func parseInt(str string) (num int, err error) {
@@ -107,10 +138,21 @@ func parseInt(str string) (num int, err error) {
 // Gets float number input from the command line
 func GetFloat(input Input) (a float64, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(float64)
 	if !ok {
 		dflt = 0
 	}
+	answer, found, err := answerFloat(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	dfltStr := fmt.Sprintf("%f", dflt)
 	if dfltStr == "0" {
 		dfltStr = ""
@@ -123,7 +165,7 @@ func GetFloat(input Input) (a float64, err error) {
 		Message: fmt.Sprintf("%s:", question),
 		Help:    input.Help,
 	}
-	if input.Default != nil {
+	if input.Default != nil || found {
 		prompt.Default = dfltStr
 	}
 	var str string
@@ -137,7 +179,11 @@ func GetFloat(input Input) (a float64, err error) {
 	if str == "" {
 		return
 	}
-	return parseFloat(str)
+	a, err = parseFloat(str)
+	if err == nil {
+		recordAnswer(input.Flag, a)
+	}
+	return
 }
 
 func parseFloat(str string) (num float64, err error) {
@@ -147,12 +193,22 @@ func parseFloat(str string) (num float64, err error) {
 // Asks for multiple options selection
 func GetMultipleOptions(input Input) ([]string, error) {
 	core.DisableColor = !color.UseColor()
-	var err error
+	err := CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return nil, err
+	}
 	res := make([]string, 0)
 	dflt, ok := input.Default.([]string)
 	if !ok {
 		dflt = []string{}
 	}
+	answer, found, err := answerStrings(input.Flag)
+	if err != nil {
+		return res, err
+	}
+	if found {
+		dflt = answer
+	}
 	question := input.Question
 	if !input.Required && len(dflt) == 0 {
 		question = fmt.Sprintf("%s (optional)", question)
@@ -167,16 +223,30 @@ func GetMultipleOptions(input Input) ([]string, error) {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
 	err = survey.AskOne(prompt, &res, survey.WithValidator(compose(input.Validators)))
+	if err == nil {
+		recordAnswer(input.Flag, res)
+	}
 	return res, err
 }
 
 // Asks for option selection in the command line
 func GetOption(input Input) (a string, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
 	}
+	answer, found, err := answerString(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	defaultMessage := ""
 	if dflt != "" {
 		defaultMessage = fmt.Sprintf("default = '%s'", dflt)
@@ -215,7 +285,10 @@ func GetOption(input Input) (a string, err error) {
 	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
 	if a == consts.SkipSelectionOption {
-		return "", nil
+		a = ""
+	}
+	if err == nil {
+		recordAnswer(input.Flag, a)
 	}
 	return
 }
@@ -233,10 +306,21 @@ func containsString(s []string, input string) bool {
 // Asks for true/false value in the command line
 func GetBool(input Input) (a bool, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(bool)
 	if !ok {
 		dflt = false
 	}
+	answer, found, err := answerBool(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	prompt := &survey.Confirm{
 		Message: fmt.Sprintf("%s:", input.Question),
 		Help:    input.Help,
@@ -246,12 +330,19 @@ func GetBool(input Input) (a bool, err error) {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	if err == nil {
+		recordAnswer(input.Flag, a)
+	}
 	return
 }
 
 // Asks for CIDR value in the command line
 func GetIPNet(input Input) (a net.IPNet, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(net.IPNet)
 	if !ok {
 		dflt = net.IPNet{}
@@ -260,6 +351,13 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 	if dfltStr == "<nil>" {
 		dfltStr = ""
 	}
+	answer, found, err := answerString(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dfltStr = answer
+	}
 	question := input.Question
 	if !input.Required && dfltStr == "" {
 		question = fmt.Sprintf("%s (optional)", question)
@@ -286,6 +384,7 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 	}
 	if cidr != nil {
 		a = *cidr
+		recordAnswer(input.Flag, cidr.String())
 	}
 	return
 }
@@ -293,6 +392,10 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 // Gets password input from the command line
 func GetPassword(input Input) (a string, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	question := input.Question
 	if !input.Required {
 		question = fmt.Sprintf("%s (optional)", question)
@@ -311,10 +414,21 @@ func GetPassword(input Input) (a string, err error) {
 // Gets path to certificate file from the command line
 func GetCert(input Input) (a string, err error) {
 	core.DisableColor = !color.UseColor()
+	err = CheckPrompt(input.Question, input.Flag)
+	if err != nil {
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
 	}
+	answer, found, err := answerString(input.Flag)
+	if err != nil {
+		return
+	}
+	if found {
+		dflt = answer
+	}
 	question := input.Question
 	if !input.Required && dflt == "" {
 		question = fmt.Sprintf("%s (optional)", question)
@@ -328,6 +442,9 @@ func GetCert(input Input) (a string, err error) {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCert))
+	if err == nil {
+		recordAnswer(input.Flag, a)
+	}
 	return
 }
 
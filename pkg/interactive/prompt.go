@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--no-prompt' command line option, which
+// makes prompts fail instead of waiting for an answer that will never come.
+
+package interactive
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// AddNoPromptFlag adds the no-prompt flag to the given set of command line flags.
+func AddNoPromptFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&noPrompt,
+		"no-prompt",
+		false,
+		"Fail instead of prompting when a value is missing. Enabled automatically when the "+
+			"standard input is not a terminal.",
+	)
+}
+
+// PromptsDisabled returns true if prompts must fail instead of asking the user, either because
+// the '--no-prompt' flag was set or because the standard input is not a terminal.
+func PromptsDisabled() bool {
+	return noPrompt || !stdinIsTerminal()
+}
+
+// SetNoPrompt enables or disables the no-prompt mode
+func SetNoPrompt(value bool) {
+	noPrompt = value
+}
+
+// noPrompt is a boolean flag that indicates that prompts are disabled.
+var noPrompt bool
+
+// stdinIsTerminal checks if the standard input is a terminal. Pipes, as used by CI systems, and
+// redirections from files, including '/dev/null', aren't.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// NoPromptError is returned by the prompts when prompts are disabled
+type NoPromptError struct {
+	Question string
+	// Flag that provides the value instead of the prompt, if any
+	Flag string
+}
+
+func (e *NoPromptError) Error() string {
+	if e.Flag != "" {
+		return fmt.Sprintf("Can't prompt for '%s' because prompts are disabled, use the '--%s' flag to provide it",
+			e.Question, e.Flag)
+	}
+	return fmt.Sprintf("Can't prompt for '%s' because prompts are disabled, run the command in a terminal",
+		e.Question)
+}
+
+// CheckPrompt returns a NoPromptError if prompts are disabled
+func CheckPrompt(question string, flag string) error {
+	if !PromptsDisabled() {
+		return nil
+	}
+	return &NoPromptError{
+		Question: question,
+		Flag:     flag,
+	}
+}
@@ -0,0 +1,36 @@
+package interactive
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_CheckPrompt(t *testing.T) {
+	terminal := stdinIsTerminal
+	t.Cleanup(func() {
+		stdinIsTerminal = terminal
+		noPrompt = false
+	})
+	stdinIsTerminal = func() bool { return true }
+
+	if err := CheckPrompt("Cluster name", "cluster-name"); err != nil {
+		t.Errorf("CheckPrompt() in a terminal = %v", err)
+	}
+
+	noPrompt = true
+	_, err := GetString(Input{Question: "Cluster name", Flag: "cluster-name"})
+	var noPromptErr *NoPromptError
+	if !errors.As(err, &noPromptErr) || noPromptErr.Flag != "cluster-name" {
+		t.Errorf("GetString() with prompts disabled error = %v", err)
+	}
+	want := "Can't prompt for 'Cluster name' because prompts are disabled, use the '--cluster-name' flag to provide it"
+	if err.Error() != want {
+		t.Errorf("GetString() error = %q, want %q", err, want)
+	}
+
+	noPrompt = false
+	stdinIsTerminal = func() bool { return false }
+	if err := CheckPrompt("Cluster name", ""); err == nil {
+		t.Errorf("CheckPrompt() without a terminal didn't fail")
+	}
+}
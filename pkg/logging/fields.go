@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the fields that describe the context of the command, which are added to all
+// the messages sent to the log.
+
+package logging
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CommandField   = "command"
+	ClusterIDField = "cluster_id"
+	RequestIDField = "request_id"
+
+	// Header of the OCM responses that contains the identifier of the request
+	operationIDHeader = "X-Operation-Id"
+)
+
+var (
+	fieldsLock sync.Mutex
+	fields     = logrus.Fields{}
+)
+
+// SetField sets a field that will be added to all the messages sent to the log from now on.
+// Empty values remove the field.
+func SetField(key string, value string) {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+	if value == "" {
+		delete(fields, key)
+		return
+	}
+	fields[key] = value
+}
+
+// fieldsHook adds the context fields to the messages that don't already have them
+type fieldsHook struct{}
+
+func (h *fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *fieldsHook) Fire(entry *logrus.Entry) error {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+	for key, value := range fields {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
+
+// RequestIDTransportWrapper wraps a transport so that the identifier of the last OCM request is
+// added to the messages sent to the log
+func RequestIDTransportWrapper(next http.RoundTripper) http.RoundTripper {
+	return &requestIDRoundTripper{next: next}
+}
+
+type requestIDRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (t *requestIDRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.next.RoundTrip(request)
+	if err == nil {
+		if id := response.Header.Get(operationIDHeader); id != "" {
+			SetField(RequestIDField, id)
+		}
+	}
+	return response, err
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--log-format', '--log-file', '--log-level'
+// and '--trace-file' command line options.
+
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/debug"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+var formats = []string{TextFormat, JSONFormat}
+
+var levels = []string{"trace", "debug", "info", "warn", "error"}
+
+var (
+	format = TextFormat
+	file   string
+	level  *logrus.Level
+)
+
+// AddFlags adds the logging flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Var(
+		&formatValue{},
+		"log-format",
+		fmt.Sprintf("Format of the log messages, one of %s. In JSON format the messages of the "+
+			"command are also written to the log, in addition to the console.", strings.Join(formats, ", ")),
+	)
+	flags.StringVar(
+		&file,
+		"log-file",
+		"",
+		"Path of the file where the log messages, including a copy of the messages of the command "+
+			"with secrets redacted, are appended.",
+	)
+	flags.Var(
+		&levelValue{},
+		"log-level",
+		fmt.Sprintf("Minimum level of the log messages, one of %s. The 'debug' and 'trace' levels "+
+			"enable debug mode. Defaults to 'info', or 'debug' in debug mode.", strings.Join(levels, ", ")),
+	)
+	flags.StringVar(
+		&traceFile,
+		"trace-file",
+		"",
+		"Path of a HAR file where the HTTP requests sent to OCM and AWS and their responses are "+
+			"recorded, with sensitive values redacted.",
+	)
+}
+
+// Format returns the format of the log messages
+func Format() string {
+	return format
+}
+
+// File returns the path of the file where the log messages are written, if any
+func File() string {
+	return file
+}
+
+// Level returns the minimum level of the log messages
+func Level() logrus.Level {
+	if level != nil {
+		return *level
+	}
+	if debug.Enabled() {
+		return logrus.DebugLevel
+	}
+	return logrus.InfoLevel
+}
+
+type formatValue struct{}
+
+func (v *formatValue) String() string {
+	return format
+}
+
+func (v *formatValue) Set(value string) error {
+	for _, f := range formats {
+		if value == f {
+			format = value
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid log format '%s', valid formats are %s", value, strings.Join(formats, ", "))
+}
+
+func (v *formatValue) Type() string {
+	return "string"
+}
+
+type levelValue struct{}
+
+func (v *levelValue) String() string {
+	if level == nil {
+		return ""
+	}
+	return level.String()
+}
+
+func (v *levelValue) Set(value string) error {
+	for _, l := range levels {
+		if value != l {
+			continue
+		}
+		parsed, err := logrus.ParseLevel(value)
+		if err != nil {
+			return err
+		}
+		level = &parsed
+		if parsed >= logrus.DebugLevel {
+			debug.SetEnabled(true)
+		}
+		return nil
+	}
+	return fmt.Errorf("Invalid log level '%s', valid levels are %s", value, strings.Join(levels, ", "))
+}
+
+func (v *levelValue) Type() string {
+	return "string"
+}
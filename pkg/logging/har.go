@@ -0,0 +1,474 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the implementation of the '--trace-file' command line option, which records
+// the HTTP exchanges with OCM and AWS in a HAR file that can be opened with the developer tools
+// of browsers. Security sensitive headers, parameters and fields are redacted.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.com/c0b/go-ordered-json"
+
+	"github.com/openshift/rosa/pkg/info"
+)
+
+const harVersion = "1.2"
+
+// traceFile is the path of the HAR file where the HTTP exchanges are recorded
+var traceFile string
+
+// Fields, parameters and XML elements whose values are redacted in the trace, in the messages sent
+// to the log and in the command lines recorded in the audit journal
+var traceRedact = map[string]bool{
+	"access_key_id":          true,
+	"access_token":           true,
+	"bind_password":          true,
+	"client_secret":          true,
+	"cluster_admin_password": true,
+	"console_client_secret":  true,
+	"hashed_password":        true,
+	"id_token":               true,
+	"kubeconfig":             true,
+	"mfa_token":              true,
+	"password":               true,
+	"refresh_token":          true,
+	"secret_access_key":      true,
+	"token":                  true,
+	"users":                  true,
+	"Password":               true,
+	"SecretAccessKey":        true,
+	"SecretBinary":           true,
+	"SecretString":           true,
+	"SessionToken":           true,
+}
+
+// Headers and query parameters whose values are redacted in the trace
+var traceRedactHeaders = map[string]bool{
+	"Authorization":        true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+	"X-Amz-Credential":     true,
+	"X-Amz-Security-Token": true,
+	"X-Amz-Signature":      true,
+}
+
+// TraceTransport wraps the given transport so that the HTTP exchanges are recorded in the file
+// given with the '--trace-file' flag. Without that flag the transport is returned as is.
+func TraceTransport(next http.RoundTripper) http.RoundTripper {
+	if traceFile == "" {
+		return next
+	}
+	return &traceRoundTripper{
+		next:     next,
+		recorder: defaultRecorder,
+	}
+}
+
+// SetTraceFile changes the file where the HTTP exchanges are recorded, discarding the exchanges
+// recorded so far. An empty path stops recording. Only the transports created after calling this
+// function use the new file.
+func SetTraceFile(path string) {
+	defaultRecorder.lock.Lock()
+	defer defaultRecorder.lock.Unlock()
+	traceFile = path
+	defaultRecorder.entries = nil
+	defaultRecorder.failed = false
+}
+
+type traceRoundTripper struct {
+	next     http.RoundTripper
+	recorder *harRecorder
+}
+
+func (t *traceRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+	}
+
+	started := time.Now()
+	response, err := t.next.RoundTrip(request)
+	wait := time.Since(started)
+	if err != nil {
+		t.recorder.record(newHAREntry(started, request, requestBody, nil, nil, wait, 0))
+		return response, err
+	}
+
+	var responseBody []byte
+	if response.Body != nil {
+		responseBody, err = io.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Body = io.NopCloser(bytes.NewBuffer(responseBody))
+	}
+	receive := time.Since(started) - wait
+	t.recorder.record(newHAREntry(started, request, requestBody, response, responseBody, wait, receive))
+	return response, nil
+}
+
+// harRecorder keeps the entries of the trace. The complete file is written after each exchange,
+// so that the trace is complete even when the command exits abruptly.
+type harRecorder struct {
+	lock    sync.Mutex
+	entries []harEntry
+	failed  bool
+}
+
+var defaultRecorder = &harRecorder{}
+
+func (r *harRecorder) record(entry harEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, entry)
+	err := r.write(traceFile)
+	if err != nil && !r.failed {
+		r.failed = true
+		fmt.Fprintf(os.Stderr, "Failed to write trace file: %v\n", err)
+	}
+}
+
+func (r *harRecorder) write(path string) error {
+	document := harDocument{
+		Log: harLog{
+			Version: harVersion,
+			Creator: harCreator{
+				Name:    "rosa",
+				Version: info.Version,
+			},
+			Entries: r.entries,
+		},
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func newHAREntry(started time.Time, request *http.Request, requestBody []byte,
+	response *http.Response, responseBody []byte, wait time.Duration, receive time.Duration) harEntry {
+	entry := harEntry{
+		StartedDateTime: started.UTC().Format(time.RFC3339Nano),
+		Time:            milliseconds(wait + receive),
+		Request: harRequest{
+			Method:      request.Method,
+			URL:         RedactURL(request.URL),
+			HTTPVersion: request.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     redactHeaders(request.Header),
+			QueryString: redactQuery(request.URL.Query()),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Cache: struct{}{},
+		Timings: harTimings{
+			Send:    0,
+			Wait:    milliseconds(wait),
+			Receive: milliseconds(receive),
+		},
+	}
+	if requestBody != nil {
+		contentType := request.Header.Get("Content-Type")
+		entry.Request.PostData = &harPostData{
+			MimeType: contentType,
+			Text:     string(RedactBody(contentType, requestBody)),
+		}
+	}
+	if response != nil {
+		contentType := response.Header.Get("Content-Type")
+		entry.Response.Status = response.StatusCode
+		entry.Response.StatusText = http.StatusText(response.StatusCode)
+		entry.Response.HTTPVersion = response.Proto
+		entry.Response.Headers = redactHeaders(response.Header)
+		entry.Response.BodySize = len(responseBody)
+		entry.Response.Content = harContent{
+			Size:     len(responseBody),
+			MimeType: contentType,
+			Text:     string(RedactBody(contentType, responseBody)),
+		}
+	}
+	return entry
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
+func redactHeaders(header http.Header) []harNameValue {
+	result := []harNameValue{}
+	for _, name := range sortedKeys(header) {
+		for _, value := range header[name] {
+			if traceRedactHeaders[name] {
+				value = redactedReplacement
+			}
+			result = append(result, harNameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
+
+func redactQuery(query url.Values) []harNameValue {
+	result := []harNameValue{}
+	for _, name := range sortedKeys(query) {
+		for _, value := range query[name] {
+			if traceRedact[name] || traceRedactHeaders[name] {
+				value = redactedReplacement
+			}
+			result = append(result, harNameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
+
+// RedactURL returns the given URL with the values of the sensitive query parameters redacted, as
+// recorded in the trace
+func RedactURL(value *url.URL) string {
+	redacted := *value
+	query := redacted.Query()
+	for name, values := range query {
+		if traceRedact[name] || traceRedactHeaders[name] {
+			for i := range values {
+				values[i] = redactedReplacement
+			}
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	if value.RawQuery == "" {
+		redacted.RawQuery = ""
+	}
+	return redacted.String()
+}
+
+var traceRedactXML = func() *regexp.Regexp {
+	names := ""
+	for _, name := range sortedKeys(traceRedact) {
+		if names != "" {
+			names += "|"
+		}
+		names += regexp.QuoteMeta(name)
+	}
+	return regexp.MustCompile(fmt.Sprintf(`<(%s)>([^<]*)<`, names))
+}()
+
+// RedactBody replaces the values of the sensitive fields of the body, using the same redaction
+// as the messages sent to the log. This is the body recorded in the trace.
+func RedactBody(contentType string, body []byte) []byte {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		return redactForm(form, traceRedact)
+	case "application/json", "application/x-amz-json-1.0", "application/x-amz-json-1.1":
+		parsed := ordered.NewOrderedMap()
+		err := json.Unmarshal(body, parsed)
+		if err != nil {
+			return body
+		}
+		redactJSON(parsed, traceRedact)
+		redacted, err := json.Marshal(parsed)
+		if err != nil {
+			return body
+		}
+		return redacted
+	case "text/xml", "application/xml":
+		return traceRedactXML.ReplaceAll(body, []byte("<$1>"+redactedReplacement+"<"))
+	default:
+		return body
+	}
+}
+
+// Sensitive returns true if the values of the headers, query parameters or body fields with the
+// given name are redacted in the trace
+func Sensitive(name string) bool {
+	return traceRedact[name] || traceRedactHeaders[name]
+}
+
+// UnredactedFields returns the names of the sensitive fields of the given body whose values
+// haven't been redacted. It is used to check that recorded traces don't contain secrets.
+func UnredactedFields(contentType string, body []byte) []string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	result := []string{}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return result
+		}
+		for _, name := range sortedKeys(form) {
+			for _, value := range form[name] {
+				if traceRedact[name] && value != redactedReplacement {
+					result = append(result, name)
+				}
+			}
+		}
+	case "application/json", "application/x-amz-json-1.0", "application/x-amz-json-1.1":
+		var parsed interface{}
+		err := json.Unmarshal(body, &parsed)
+		if err != nil {
+			return result
+		}
+		result = unredactedJSON(parsed, result)
+	case "text/xml", "application/xml":
+		for _, match := range traceRedactXML.FindAllSubmatch(body, -1) {
+			if string(match[2]) != redactedReplacement {
+				result = append(result, string(match[1]))
+			}
+		}
+	}
+	return result
+}
+
+func unredactedJSON(value interface{}, result []string) []string {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, name := range sortedKeys(typed) {
+			if traceRedact[name] {
+				if typed[name] != redactedReplacement {
+					result = append(result, name)
+				}
+				continue
+			}
+			result = unredactedJSON(typed[name], result)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			result = unredactedJSON(item, result)
+		}
+	}
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
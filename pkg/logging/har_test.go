@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HAR trace", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		traceFile = filepath.Join(GinkgoT().TempDir(), "trace.har")
+		defaultRecorder = &harRecorder{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/sts" {
+				w.Header().Set("Content-Type", "text/xml")
+				_, _ = w.Write([]byte("<Credentials><AccessKeyId>AKIA</AccessKeyId>" +
+					"<SecretAccessKey>secret</SecretAccessKey></Credentials>"))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		traceFile = ""
+	})
+
+	readTrace := func() harDocument {
+		data, err := os.ReadFile(traceFile)
+		Expect(err).NotTo(HaveOccurred())
+		document := harDocument{}
+		Expect(json.Unmarshal(data, &document)).To(Succeed())
+		return document
+	}
+
+	It("Doesn't wrap the transport without a trace file", func() {
+		traceFile = ""
+		Expect(TraceTransport(http.DefaultTransport)).To(BeIdenticalTo(http.DefaultTransport))
+	})
+
+	It("Records the exchanges redacting the sensitive values", func() {
+		client := &http.Client{Transport: TraceTransport(http.DefaultTransport)}
+		form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"xyz"}}
+		request, err := http.NewRequest(http.MethodPost, server.URL+"/token?X-Amz-Signature=sig",
+			strings.NewReader(form.Encode()))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.Header.Set("Authorization", "Bearer xyz")
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+
+		response, err = client.Get(server.URL + "/sts")
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+
+		document := readTrace()
+		Expect(document.Log.Version).To(Equal(harVersion))
+		Expect(document.Log.Entries).To(HaveLen(2))
+
+		entry := document.Log.Entries[0]
+		Expect(entry.Request.Method).To(Equal(http.MethodPost))
+		Expect(entry.Request.URL).NotTo(ContainSubstring("sig"))
+		Expect(entry.Request.QueryString).To(ContainElement(harNameValue{
+			Name: "X-Amz-Signature", Value: redactedReplacement}))
+		Expect(entry.Request.Headers).To(ContainElement(harNameValue{
+			Name: "Authorization", Value: redactedReplacement}))
+		Expect(entry.Request.PostData.Text).To(Equal("grant_type=refresh_token&refresh_token=***"))
+		Expect(entry.Response.Status).To(Equal(http.StatusOK))
+		Expect(entry.Response.Content.Text).To(Equal(`{"access_token":"***","token_type":"Bearer"}`))
+
+		Expect(document.Log.Entries[1].Response.Content.Text).To(Equal(
+			"<Credentials><AccessKeyId>AKIA</AccessKeyId><SecretAccessKey>***</SecretAccessKey></Credentials>"))
+	})
+
+	DescribeTable("Redacts the sensitive fields of nested JSON bodies",
+		func(body string, expected string) {
+			Expect(string(RedactBody("application/json", []byte(body)))).To(Equal(expected))
+		},
+		Entry("AWS credentials of a cluster",
+			`{"name":"mycluster","aws":{"access_key_id":"AKIA","secret_access_key":"secret"}}`,
+			`{"name":"mycluster","aws":{"access_key_id":"***","secret_access_key":"***"}}`),
+		Entry("HTPasswd users",
+			`{"htpasswd":{"users":{"items":[{"username":"a","password":"p1"},{"username":"b","password":"p2"}]}}}`,
+			`{"htpasswd":{"users":"***"}}`),
+		Entry("HTPasswd user",
+			`{"items":[{"username":"a","hashed_password":"h1"},{"username":"b","password":"p2"}]}`,
+			`{"items":[{"username":"a","hashed_password":"***"},{"username":"b","password":"***"}]}`),
+		Entry("LDAP bind password",
+			`{"type":"LDAPIdentityProvider","ldap":{"bind_dn":"cn=admin","bind_password":"secret"}}`,
+			`{"type":"LDAPIdentityProvider","ldap":{"bind_dn":"cn=admin","bind_password":"***"}}`),
+		Entry("GitHub client secret",
+			`{"type":"GithubIdentityProvider","github":{"client_id":"id","client_secret":"secret"}}`,
+			`{"type":"GithubIdentityProvider","github":{"client_id":"id","client_secret":"***"}}`),
+		Entry("Break glass kubeconfig",
+			`{"id":"123","kubeconfig":"apiVersion: v1"}`,
+			`{"id":"123","kubeconfig":"***"}`),
+		Entry("Arrays of arrays",
+			`{"items":[[{"token":"abc"}],["plain"]]}`,
+			`{"items":[[{"token":"***"}],["plain"]]}`),
+	)
+})
@@ -19,25 +19,114 @@ limitations under the License.
 package logging
 
 import (
-	"github.com/sirupsen/logrus"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 
-	"github.com/openshift/rosa/pkg/debug"
+	"github.com/sirupsen/logrus"
 )
 
-// NewLogger creates a new logger with the default config for the project
+// NewLogger creates a new logger with the default config for the project, or with the format,
+// file and level selected with the logging flags
 func NewLogger() (result *logrus.Logger) {
 	// Create the logger:
 	result = logrus.New()
-	result.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		DisableQuote:  true,
-		FullTimestamp: true,
+	if format == JSONFormat {
+		result.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		result.SetFormatter(&logrus.TextFormatter{
+			DisableColors: true,
+			DisableQuote:  true,
+			FullTimestamp: true,
+		})
+	}
+	result.SetOutput(output())
+	result.SetLevel(Level())
+	result.AddHook(&fieldsHook{})
+
+	return
+}
+
+var (
+	outputOnce   sync.Once
+	outputWriter io.Writer
+)
+
+// output returns the destination of the log messages, which is the log file if one was given
+// and can be opened, or the standard error stream otherwise
+func output() io.Writer {
+	outputOnce.Do(func() {
+		outputWriter = os.Stderr
+		if file == "" {
+			return
+		}
+		logFile, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open log file, logging to the console: %v\n", err)
+			return
+		}
+		outputWriter = logFile
 	})
+	return outputWriter
+}
 
-	// Enable the debug level if needed:
-	if debug.Enabled() {
-		result.SetLevel(logrus.DebugLevel)
+var (
+	reportLoggerOnce sync.Once
+	reportLogger     *logrus.Logger
+)
+
+// ReportToLog copies a message printed by the reporter to the log when the log is being collected,
+// either because it is written to a file or because it is in JSON format, and the level of the
+// message is enabled by the '--log-level' flag. Security sensitive values are redacted in the copy.
+// It returns true if the copy has been written to the standard error stream, where the reporter
+// would otherwise print warnings and errors again.
+func ReportToLog(level logrus.Level, message string) bool {
+	if file == "" && format != JSONFormat {
+		return false
+	}
+	if level > Level() {
+		return false
 	}
+	reportLoggerOnce.Do(func() {
+		reportLogger = NewLogger()
+	})
+	reportLogger.Log(level, RedactMessage(message))
+	return output() == io.Writer(os.Stderr)
+}
 
-	return
+var (
+	redactMessageOnce sync.Once
+	redactMessageExp  *regexp.Regexp
+)
+
+// RedactMessage replaces the values of the security sensitive fields that appear in the given
+// message as command line flags, like '--password secret', or as keys, like 'password: secret'.
+func RedactMessage(message string) string {
+	redactMessageOnce.Do(func() {
+		names := map[string]bool{}
+		for name := range traceRedact {
+			name = strings.ToLower(name)
+			names[regexp.QuoteMeta(name)] = true
+			names[regexp.QuoteMeta(strings.ReplaceAll(name, "_", "-"))] = true
+		}
+		alternatives := make([]string, 0, len(names))
+		for name := range names {
+			alternatives = append(alternatives, name)
+		}
+		// Longer names first, so that 'client-secret' is preferred to 'secret':
+		sort.Slice(alternatives, func(i, j int) bool {
+			if len(alternatives[i]) != len(alternatives[j]) {
+				return len(alternatives[i]) > len(alternatives[j])
+			}
+			return alternatives[i] < alternatives[j]
+		})
+		pattern := strings.Join(alternatives, "|")
+		redactMessageExp = regexp.MustCompile(fmt.Sprintf(
+			`(?i)(--(?:%[1]s)(?:=|\s+)|\b(?:%[1]s)"?\s*[:=]\s*"?)([^\s"',]+)`, pattern))
+	})
+	return redactMessageExp.ReplaceAllString(message, "${1}"+redactedReplacement)
 }
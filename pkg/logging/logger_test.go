@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/debug"
+)
+
+var _ = Describe("Logger", func() {
+	var flags *pflag.FlagSet
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(flags)
+	})
+
+	AfterEach(func() {
+		format = TextFormat
+		file = ""
+		level = nil
+		fields = logrus.Fields{}
+		outputOnce = sync.Once{}
+		reportLoggerOnce = sync.Once{}
+		reportLogger = nil
+		debug.SetEnabled(false)
+	})
+
+	It("Rejects unknown formats and levels", func() {
+		Expect(flags.Parse([]string{"--log-format", "xml"})).To(MatchError(ContainSubstring(
+			"Invalid log format 'xml'")))
+		Expect(flags.Parse([]string{"--log-level", "verbose"})).To(MatchError(ContainSubstring(
+			"Invalid log level 'verbose'")))
+	})
+
+	It("Enables debug mode with the debug level", func() {
+		Expect(Level()).To(Equal(logrus.InfoLevel))
+		Expect(flags.Parse([]string{"--log-level", "debug"})).To(Succeed())
+		Expect(debug.Enabled()).To(BeTrue())
+		Expect(NewLogger().GetLevel()).To(Equal(logrus.DebugLevel))
+	})
+
+	It("Doesn't send the messages of the reporter to the console log", func() {
+		Expect(ReportToLog(logrus.InfoLevel, "Creating cluster")).To(BeFalse())
+	})
+
+	It("Only copies the messages of the reporter of the selected level to the log", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rosa.log")
+		Expect(flags.Parse([]string{"--log-level", "error", "--log-file", path})).To(Succeed())
+		Expect(ReportToLog(logrus.InfoLevel, "Creating cluster")).To(BeFalse())
+		Expect(ReportToLog(logrus.WarnLevel, "Cluster is old")).To(BeFalse())
+		Expect(ReportToLog(logrus.ErrorLevel, "Failed to create cluster")).To(BeFalse())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("Creating cluster"))
+		Expect(string(data)).NotTo(ContainSubstring("Cluster is old"))
+		Expect(string(data)).To(ContainSubstring("Failed to create cluster"))
+	})
+
+	It("Redacts the secrets of the messages copied to the log", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rosa.log")
+		Expect(flags.Parse([]string{"--log-file", path})).To(Succeed())
+		ReportToLog(logrus.InfoLevel, "oc login https://api.example.com --username cluster-admin --password s3cr3t")
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("--username cluster-admin --password ***"))
+		Expect(string(data)).NotTo(ContainSubstring("s3cr3t"))
+	})
+
+	DescribeTable("Redacts messages",
+		func(message string, expected string) {
+			Expect(RedactMessage(message)).To(Equal(expected))
+		},
+		Entry("flag", "oc login --password abc", "oc login --password ***"),
+		Entry("flag with equals", "rosa create idp --client-secret=abc --type github",
+			"rosa create idp --client-secret=*** --type github"),
+		Entry("key", "password: abc", "password: ***"),
+		Entry("JSON field", `{"bind_password":"abc"}`, `{"bind_password":"***"}`),
+		Entry("text without values", "Please securely store this generated password.",
+			"Please securely store this generated password."),
+	)
+
+	It("Writes the messages of the reporter to the log file with the context fields", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rosa.log")
+		Expect(flags.Parse([]string{"--log-format", "json", "--log-file", path})).To(Succeed())
+		SetField(CommandField, "rosa create cluster")
+		SetField(ClusterIDField, "123")
+
+		Expect(ReportToLog(logrus.WarnLevel, "Creating cluster")).To(BeFalse())
+		Expect(ReportToLog(logrus.DebugLevel, "Hidden")).To(BeFalse())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		Expect(lines).To(HaveLen(1))
+		entry := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue("level", "warning"))
+		Expect(entry).To(HaveKeyWithValue("msg", "Creating cluster"))
+		Expect(entry).To(HaveKeyWithValue(CommandField, "rosa create cluster"))
+		Expect(entry).To(HaveKeyWithValue(ClusterIDField, "123"))
+	})
+})
@@ -97,8 +97,11 @@ func (b *RoundTripperBuilder) Build() (result *RoundTripper, err error) {
 		return
 	}
 
-	// Copy the set of redactedReplacement fields:
+	// Copy the set of redactedReplacement fields, starting with the ones that are always redacted:
 	redact := make(map[string]bool)
+	for key, value := range traceRedact {
+		redact[key] = value
+	}
 	for key, value := range b.redact {
 		redact[key] = value
 	}
@@ -246,17 +249,30 @@ func (d *RoundTripper) dumpForm(what string, data []byte) {
 		return
 	}
 
-	// Redact values corresponding to security sensitive fields:
-	for name, values := range form {
-		if d.redact[name] {
-			for i := range values {
-				values[i] = redactedReplacement
+	// Get and sort the names of the fields of the form, so that the generated output will be
+	// predictable:
+	names := make([]string, 0, len(form))
+	for name := range form {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range form[name] {
+			if d.redact[name] {
+				d.logger.Debugf("%s field '%s' is redacted", what, name)
+			} else {
+				d.logger.Debugf("%s field '%s' is '%s'", what, name, value)
 			}
 		}
 	}
 
-	// Get and sort the names of the fields of the form, so that the generated output will be
-	// predictable:
+	// Send the redactedReplacement data to the log:
+	d.dumpBytes(what, redactForm(form, d.redact))
+}
+
+// redactForm encodes the given form data replacing the values of the security sensitive fields.
+// The fields are sorted by name, so that the generated output will be predictable.
+func redactForm(form url.Values, redact map[string]bool) []byte {
 	names := make([]string, 0, len(form))
 	for name := range form {
 		names = append(names, name)
@@ -267,15 +283,10 @@ func (d *RoundTripper) dumpForm(what string, data []byte) {
 	buffer := &bytes.Buffer{}
 	for _, name := range names {
 		key := url.QueryEscape(name)
-		values := form[name]
-		for _, value := range values {
-			var redacted string
-			if d.redact[name] {
+		for _, value := range form[name] {
+			redacted := url.QueryEscape(value)
+			if redact[name] {
 				redacted = redactedReplacement
-				d.logger.Debugf("%s field '%s' is redacted", what, name)
-			} else {
-				redacted = url.QueryEscape(value)
-				d.logger.Debugf("%s field '%s' is '%s'", what, name, value)
 			}
 			if buffer.Len() > 0 {
 				buffer.WriteByte('&') // #nosec G104
@@ -285,9 +296,7 @@ func (d *RoundTripper) dumpForm(what string, data []byte) {
 			buffer.WriteString(redacted) // #nosec G104
 		}
 	}
-
-	// Send the redactedReplacement data to the log:
-	d.dumpBytes(what, buffer.Bytes())
+	return buffer.Bytes()
 }
 
 // dumpJSON tries to parse the given data as a JSON document. If that works, then it dumps it
@@ -299,7 +308,7 @@ func (d *RoundTripper) dumpJSON(what string, data []byte) {
 		d.logger.Debugf("%s", data)
 	} else {
 		// remove sensitive information
-		d.redactSensitive(parsed)
+		redactJSON(parsed, d.redact)
 
 		indented, err := json.MarshalIndent(parsed, "", "  ")
 		if err != nil {
@@ -323,18 +332,62 @@ func (d *RoundTripper) dumpBytes(what string, data []byte) {
 	}
 }
 
-// redactSensitive replaces sensitive fields within a response with redactionStr.
-func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
+// redactJSON replaces sensitive fields within a response with redactionStr. Nested objects and
+// the objects inside arrays are redacted as well.
+func redactJSON(body *ordered.OrderedMap, redact map[string]bool) {
 	iterator := body.EntriesIter()
 	for {
 		pair, ok := iterator()
 		if !ok {
 			break
 		}
-		if d.redact[pair.Key] {
+		if redact[pair.Key] {
 			body.Set(pair.Key, redactedReplacement)
+			continue
+		}
+		redactJSONValue(pair.Value, redact)
+	}
+}
+
+func redactJSONValue(value interface{}, redact map[string]bool) {
+	switch typed := value.(type) {
+	case *ordered.OrderedMap:
+		redactJSON(typed, redact)
+	case []interface{}:
+		for _, item := range typed {
+			redactJSONValue(item, redact)
+		}
+	}
+}
+
+// RedactArgs returns a copy of the given command line arguments where the values of the sensitive
+// flags are replaced, using the same set of fields that the round tripper and the trace redact. The
+// dashes of the flag names are replaced with underscores before checking them, so that the
+// --client-secret flag is redacted like the client_secret field.
+func RedactArgs(args []string) []string {
+	sensitive := func(name string) bool {
+		return Sensitive(strings.ReplaceAll(name, "-", "_"))
+	}
+	result := make([]string, len(args))
+	copy(result, args)
+	for i := 0; i < len(result); i++ {
+		arg := result[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if index := strings.Index(name, "="); index >= 0 {
+			if sensitive(name[:index]) {
+				result[i] = arg[:len(arg)-len(name)+index+1] + redactedReplacement
+			}
+			continue
+		}
+		if sensitive(name) && i+1 < len(result) && !strings.HasPrefix(result[i+1], "-") {
+			result[i+1] = redactedReplacement
+			i++
 		}
 	}
+	return result
 }
 
 // String that replaces redactedReplacement fields in messages sent to the log:
@@ -19,11 +19,23 @@ package ocm
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 
+	"github.com/ghodss/yaml"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/output"
 )
 
+// Registers the printers of the add-ons listed with the '--output' flag
+func init() {
+	output.RegisterJSON[[]*AddOnResource]()
+	output.RegisterJSON[[]*ClusterAddOn]()
+}
+
 type AddOnBilling struct {
 	BillingModel     string
 	BillingAccountID string
@@ -50,6 +62,14 @@ type AddOnParam struct {
 	Val string
 }
 
+// AddOnParamChange describes how the value of an add-on installation parameter changes on update.
+// An empty From means the parameter was not set on the installation.
+type AddOnParamChange struct {
+	Key  string
+	From string
+	To   string
+}
+
 type AddOnResource struct {
 	AddOn     *cmv1.AddOn
 	AZType    string
@@ -175,6 +195,38 @@ func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []Add
 	return nil
 }
 
+// UpgradeAddOnInstallation moves an add-on installation to the given add-on version
+func (c *Client) UpgradeAddOnInstallation(clusterID, addOnID, version string) error {
+	addOnInstallation, err := cmv1.NewAddOnInstallation().
+		Addon(cmv1.NewAddOn().ID(addOnID)).
+		AddonVersion(cmv1.NewAddOnVersion().ID(version)).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		Addons().Addoninstallation(addOnID).
+		Update().Body(addOnInstallation).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+
+	return nil
+}
+
+func (c *Client) GetAddOnVersions(addOnID string) ([]*cmv1.AddOnVersion, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Addons().Addon(addOnID).Versions().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
 func (c *Client) GetAddOnParameters(clusterID, addOnID string) (*cmv1.AddOnParameterList, error) {
 	response, err := c.ocm.ClustersMgmt().V1().Clusters().
 		Cluster(clusterID).AddonInquiries().AddonInquiry(addOnID).Get().Send()
@@ -350,3 +402,72 @@ func (c *Client) AddClusterOperatorRole(cluster *cmv1.Cluster, role *cmv1.Operat
 
 	return nil
 }
+
+// ParseAddOnParamsFile reads add-on installation parameters from a YAML or JSON file containing
+// a map of parameter IDs to values. Non-string values are converted to their string representation
+// and every ID must be one of the given add-on parameters.
+func ParseAddOnParamsFile(path string, addOnParameters *cmv1.AddOnParameterList) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read add-on parameters file '%s': %v", path, err)
+	}
+
+	var values map[string]interface{}
+	err = yaml.Unmarshal(content, &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse add-on parameters file '%s': %v", path, err)
+	}
+
+	params := make(map[string]string, len(values))
+	for key, value := range values {
+		found := false
+		addOnParameters.Each(func(param *cmv1.AddOnParameter) bool {
+			found = param.ID() == key
+			return !found
+		})
+		if !found {
+			return nil, fmt.Errorf("parameter '%s' in file '%s' is not a parameter of the add-on", key, path)
+		}
+		switch v := value.(type) {
+		case nil:
+			params[key] = ""
+		case string:
+			params[key] = v
+		case bool:
+			params[key] = strconv.FormatBool(v)
+		case float64:
+			// Numbers are always decoded as floating point, format them without exponent so that
+			// large integers aren't written as something like '1e+06':
+			params[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("invalid value for add-on parameter '%s' in file '%s': "+
+				"expected a string, number or boolean", key, path)
+		}
+	}
+	return params, nil
+}
+
+// DiffAddOnParams returns the parameters whose value differs between the add-on installation
+// and the requested parameters, sorted by parameter ID
+func DiffAddOnParams(current *cmv1.AddOnInstallationParameterList, params []AddOnParam) []AddOnParamChange {
+	currentValues := map[string]string{}
+	current.Each(func(p *cmv1.AddOnInstallationParameter) bool {
+		currentValues[p.ID()] = p.Value()
+		return true
+	})
+
+	var changes []AddOnParamChange
+	for _, param := range params {
+		if currentValues[param.Key] != param.Val {
+			changes = append(changes, AddOnParamChange{
+				Key:  param.Key,
+				From: currentValues[param.Key],
+				To:   param.Val,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
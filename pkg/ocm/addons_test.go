@@ -0,0 +1,84 @@
+package ocm
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Add-on parameters", func() {
+	var addOnParameters *cmv1.AddOnParameterList
+
+	BeforeEach(func() {
+		var err error
+		addOnParameters, err = cmv1.NewAddOnParameterList().Items(
+			cmv1.NewAddOnParameter().ID("retention"),
+			cmv1.NewAddOnParameter().ID("enabled"),
+			cmv1.NewAddOnParameter().ID("storage-class"),
+		).Build()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	writeParamsFile := func(contents string) string {
+		path := filepath.Join(GinkgoT().TempDir(), "params.yaml")
+		Expect(os.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	It("Parses a YAML parameters file", func() {
+		path := writeParamsFile("retention: 7\nenabled: true\nstorage-class: gp3\n")
+		params, err := ParseAddOnParamsFile(path, addOnParameters)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params).To(Equal(map[string]string{
+			"retention":     "7",
+			"enabled":       "true",
+			"storage-class": "gp3",
+		}))
+	})
+
+	It("Doesn't use the exponent notation for large numbers", func() {
+		path := writeParamsFile(`{"retention": 1000000, "storage-class": 12345678901, "enabled": 0.5}`)
+		params, err := ParseAddOnParamsFile(path, addOnParameters)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params).To(Equal(map[string]string{
+			"retention":     "1000000",
+			"enabled":       "0.5",
+			"storage-class": "12345678901",
+		}))
+	})
+
+	It("Fails on parameters that are not part of the add-on", func() {
+		path := writeParamsFile("unknown: value\n")
+		_, err := ParseAddOnParamsFile(path, addOnParameters)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("parameter 'unknown' in file"))
+	})
+
+	It("Fails on nested values", func() {
+		path := writeParamsFile("retention:\n  days: 7\n")
+		_, err := ParseAddOnParamsFile(path, addOnParameters)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid value for add-on parameter 'retention'"))
+	})
+
+	It("Reports only changed parameters", func() {
+		current, err := cmv1.NewAddOnInstallationParameterList().Items(
+			cmv1.NewAddOnInstallationParameter().ID("retention").Value("7"),
+			cmv1.NewAddOnInstallationParameter().ID("enabled").Value("true"),
+		).Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		changes := DiffAddOnParams(current, []AddOnParam{
+			{Key: "retention", Val: "14"},
+			{Key: "enabled", Val: "true"},
+			{Key: "storage-class", Val: "gp3"},
+		})
+		Expect(changes).To(Equal([]AddOnParamChange{
+			{Key: "retention", From: "7", To: "14"},
+			{Key: "storage-class", From: "", To: "gp3"},
+		}))
+	})
+})
@@ -0,0 +1,264 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the on-disk cache of the responses of OCM to read-only requests for data
+// that rarely changes, like versions, regions, machine types and policies. The cache is enabled
+// with 'rosa config set cache true', bypassed with the '--no-cache' flag and removed with the
+// 'rosa cache clear' command.
+
+package ocm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// CacheLocationEnvKey is the environment variable that overrides the location of the cache directory
+const CacheLocationEnvKey = "ROSA_CACHE_DIR"
+
+// noCache is a boolean flag that indicates that the cache must be bypassed.
+var noCache bool
+
+// AddNoCacheFlag adds the no-cache flag to the given set of command line flags.
+func AddNoCacheFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&noCache,
+		"no-cache",
+		false,
+		"Don't use the cached responses of OCM, even if the cache is enabled in the configuration.",
+	)
+}
+
+// cacheRule describes a set of requests whose responses can be cached, and for how long
+type cacheRule struct {
+	path *regexp.Regexp
+	ttl  time.Duration
+}
+
+var cacheRules = []cacheRule{
+	{
+		path: regexp.MustCompile(`^/api/clusters_mgmt/v1/versions(/[^/]+)?$`),
+		ttl:  time.Hour,
+	},
+	{
+		path: regexp.MustCompile(`^/api/clusters_mgmt/v1/cloud_providers(/[^/]+(/regions(/[^/]+)?)?)?$`),
+		ttl:  24 * time.Hour,
+	},
+	{
+		path: regexp.MustCompile(`^/api/clusters_mgmt/v1/machine_types$`),
+		ttl:  24 * time.Hour,
+	},
+	{
+		path: regexp.MustCompile(`^/api/clusters_mgmt/v1/aws_inquiries/sts_(policies|credential_requests)$`),
+		ttl:  24 * time.Hour,
+	},
+}
+
+// cacheTTL returns the time that the response to the given request can be kept in the cache, or
+// zero if it can't be cached.
+func cacheTTL(request *http.Request) time.Duration {
+	if request.Method != http.MethodGet {
+		return 0
+	}
+	for _, rule := range cacheRules {
+		if rule.path.MatchString(request.URL.Path) {
+			return rule.ttl
+		}
+	}
+	return 0
+}
+
+// CacheLocation returns the location of the cache directory. It can be overridden with the
+// ROSA_CACHE_DIR environment variable.
+func CacheLocation() (string, error) {
+	if path := os.Getenv(CacheLocationEnvKey); path != "" {
+		return path, nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "rosa", "ocm"), nil
+}
+
+// ClearCache removes all the cached responses
+func ClearCache() error {
+	dir, err := CacheLocation()
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return fmt.Errorf("Failed to remove cache directory '%s': %v", dir, err)
+	}
+	return nil
+}
+
+// cacheTransportWrapper returns a transport wrapper that stores the responses in the given
+// directory, for use with the TransportWrapper method of the connection builder.
+func cacheTransportWrapper(dir string) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &cacheRoundTripper{
+			next: next,
+			dir:  dir,
+			now:  time.Now,
+		}
+	}
+}
+
+type cacheRoundTripper struct {
+	next http.RoundTripper
+	dir  string
+	now  func() time.Time
+}
+
+// cacheEntry is the content of the file where a response is stored
+type cacheEntry struct {
+	URL     string      `json:"url"`
+	Account string      `json:"account"`
+	Expires time.Time   `json:"expires"`
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+}
+
+func (t *cacheRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ttl := cacheTTL(request)
+	if ttl == 0 {
+		return t.next.RoundTrip(request)
+	}
+	account := cacheAccount(request)
+	if account == "" {
+		return t.next.RoundTrip(request)
+	}
+	url := request.URL.String()
+	path := filepath.Join(t.dir, cacheKey(account, url))
+
+	entry, err := t.load(path)
+	if err == nil && entry.URL == url && entry.Account == account {
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+			StatusCode:    entry.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.Header,
+			Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Failing to store the response only means that the next request will go to OCM:
+	_ = t.store(path, &cacheEntry{
+		URL:     url,
+		Account: account,
+		Expires: t.now().Add(ttl),
+		Status:  response.StatusCode,
+		Header:  response.Header,
+		Body:    body,
+	})
+	return response, nil
+}
+
+func (t *cacheRoundTripper) load(path string) (*cacheEntry, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{}
+	err = json.Unmarshal(data, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !t.now().Before(entry.Expires) {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("Cache entry '%s' has expired", path)
+	}
+	return entry, nil
+}
+
+func (t *cacheRoundTripper) store(path string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(t.dir, os.FileMode(0700))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// cacheAccount returns the identifier of the account that sends the request, extracted from the
+// bearer token, so that the responses are never shared between accounts. Requests whose account
+// can't be determined aren't cached.
+func cacheAccount(request *http.Request) string {
+	textToken, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	token, err := config.ParseToken(textToken)
+	if err != nil {
+		return ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	for _, claim := range []string{"sub", "username", "preferred_username"} {
+		if value, ok := claims[claim].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// cacheKey returns the name of the file where the response to the given URL is stored
+func cacheKey(account string, url string) string {
+	sum := sha256.Sum256([]byte(account + "\n" + url))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
@@ -0,0 +1,96 @@
+package ocm
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+// countingTransport answers all the requests with the number of requests received so far
+type countingTransport struct {
+	count int
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.count++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(strings.Repeat("x", t.count))),
+		Request:    request,
+	}, nil
+}
+
+var _ = Describe("Cache", func() {
+	var dir string
+	var next *countingTransport
+	var now time.Time
+	var transport http.RoundTripper
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		next = &countingTransport{}
+		now = time.Now()
+		transport = cacheTransportWrapper(dir)(next)
+		transport.(*cacheRoundTripper).now = func() time.Time {
+			return now
+		}
+	})
+
+	send := func(method string, path string, subject string) string {
+		request, err := http.NewRequest(method, "https://api.openshift.com"+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if subject != "" {
+			token := MakeTokenObject(jwt.MapClaims{"sub": subject})
+			request.Header.Set("Authorization", "Bearer "+token.Raw)
+		}
+		response, err := transport.RoundTrip(request)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("Returns the cached response until it expires", func() {
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=1", "alice")).To(Equal("x"))
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=1", "alice")).To(Equal("x"))
+		Expect(next.count).To(Equal(1))
+
+		now = now.Add(2 * time.Hour)
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=1", "alice")).To(Equal("xx"))
+		Expect(next.count).To(Equal(2))
+	})
+
+	It("Keys the responses by URL and account", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types", "alice")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types?search=foo", "alice")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types", "bob")
+		Expect(next.count).To(Equal(3))
+	})
+
+	It("Doesn't cache other requests", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "alice")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "alice")
+		send(http.MethodPost, "/api/clusters_mgmt/v1/versions", "alice")
+		send(http.MethodPost, "/api/clusters_mgmt/v1/versions", "alice")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions", "")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions", "")
+		Expect(next.count).To(Equal(6))
+	})
+
+	It("Clears the cache", func() {
+		GinkgoT().Setenv(CacheLocationEnvKey, dir)
+		send(http.MethodGet, "/api/clusters_mgmt/v1/aws_inquiries/sts_policies", "alice")
+		Expect(os.ReadDir(dir)).To(HaveLen(1))
+		Expect(ClearCache()).To(Succeed())
+		_, err := os.Stat(dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
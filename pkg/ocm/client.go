@@ -18,6 +18,7 @@ package ocm
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -39,8 +40,9 @@ type Client struct {
 // ClientBuilder contains the information and logic needed to build a connection to OCM. Don't
 // create instances of this type directly; use the NewClient function instead.
 type ClientBuilder struct {
-	logger *logrus.Logger
-	cfg    *config.Config
+	logger    *logrus.Logger
+	cfg       *config.Config
+	transport http.RoundTripper
 }
 
 // NewClient creates a builder that can then be used to configure and build an OCM connection.
@@ -80,6 +82,13 @@ func (b *ClientBuilder) Config(value *config.Config) *ClientBuilder {
 	return b
 }
 
+// Transport sets the transport that the connection will use to send the requests, instead of the
+// default one. This is intended for tests.
+func (b *ClientBuilder) Transport(value http.RoundTripper) *ClientBuilder {
+	b.transport = value
+	return b
+}
+
 // Build uses the information stored in the builder to create a new OCM connection.
 func (b *ClientBuilder) Build() (result *Client, err error) {
 	if b.cfg == nil {
@@ -118,6 +127,20 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 	// values in the configuration, so that default values won't be overridden:
 	builder := sdk.NewConnectionBuilder()
 	builder.Logger(logger)
+	if b.cfg.Cache && !noCache {
+		cacheDir, err := CacheLocation()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to find cache directory: %v", err)
+		}
+		builder.TransportWrapper(cacheTransportWrapper(cacheDir))
+	}
+	builder.TransportWrapper(logging.RequestIDTransportWrapper)
+	builder.TransportWrapper(logging.TraceTransport)
+	if b.transport != nil {
+		builder.TransportWrapper(func(http.RoundTripper) http.RoundTripper {
+			return b.transport
+		})
+	}
 	builder.Agent(info.UserAgent + "/" + info.Version + " " + sdk.DefaultAgent)
 	if b.cfg.TokenURL != "" {
 		builder.TokenURL(b.cfg.TokenURL)
@@ -35,6 +35,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/interactive/consts"
+	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/properties"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
@@ -336,7 +337,8 @@ func (c *Client) GetAllClusters(creator *aws.Creator) (clusters []*cmv1.Cluster,
 	return response.Items().Slice(), nil
 }
 
-// GetCluster gets a cluster key that can be either 'id', 'name' or 'external_id'
+// GetCluster gets a cluster key that can be either 'id', 'name' or 'external_id'. The identifier of
+// the cluster is added to the messages sent to the log from then on.
 func (c *Client) GetCluster(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error) {
 	query := fmt.Sprintf("%s AND (id = '%s' OR name = '%s' OR external_id = '%s')",
 		getClusterFilter(creator),
@@ -355,7 +357,9 @@ func (c *Client) GetCluster(clusterKey string, creator *aws.Creator) (*cmv1.Clus
 	case 0:
 		return nil, errors.NotFound.Errorf("There is no cluster with identifier or name '%s'", clusterKey)
 	case 1:
-		return response.Items().Slice()[0], nil
+		cluster := response.Items().Slice()[0]
+		logging.SetField(logging.ClusterIDField, cluster.ID())
+		return cluster, nil
 	default:
 		return nil, fmt.Errorf("There are %d clusters with identifier or name '%s'", response.Total(), clusterKey)
 	}
@@ -394,7 +398,9 @@ func (c *Client) GetClusterByID(clusterKey string, creator *aws.Creator) (*cmv1.
 	case 0:
 		return nil, errors.NotFound.Errorf("There is no cluster with identifier '%s'", clusterKey)
 	case 1:
-		return response.Items().Slice()[0], nil
+		cluster := response.Items().Slice()[0]
+		logging.SetField(logging.ClusterIDField, cluster.ID())
+		return cluster, nil
 	default:
 		return nil, fmt.Errorf("There are %d clusters with identifier '%s'", response.Total(), clusterKey)
 	}
@@ -1086,6 +1092,23 @@ func IsConsoleAvailable(cluster *cmv1.Cluster) bool {
 	return cluster.Console() != nil && cluster.Console().URL() != ""
 }
 
+// ClusterExpiresBefore reports whether the cluster has an expiration timestamp that is not after the given time
+func ClusterExpiresBefore(cluster *cmv1.Cluster, t time.Time) bool {
+	expiration, ok := cluster.GetExpirationTimestamp()
+	return ok && !expiration.IsZero() && !expiration.After(t)
+}
+
+// FilterClustersExpiringBefore returns the clusters that expire before the given time
+func FilterClustersExpiringBefore(clusters []*cmv1.Cluster, t time.Time) []*cmv1.Cluster {
+	filtered := []*cmv1.Cluster{}
+	for _, cluster := range clusters {
+		if ClusterExpiresBefore(cluster, t) {
+			filtered = append(filtered, cluster)
+		}
+	}
+	return filtered
+}
+
 func IsHyperShiftCluster(cluster *cmv1.Cluster) bool {
 	return cluster != nil && cluster.Hypershift() != nil && cluster.Hypershift().Enabled()
 }
@@ -1,11 +1,18 @@
 package ocm
 
 import (
+	"bytes"
+	"encoding/json"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/test/fakeocm"
 )
 
 var _ = Describe("New Operator Iam Role From Cmv1", func() {
@@ -93,3 +100,65 @@ var _ = Context("List Clusters", func() {
 
 	})
 })
+
+var _ = Describe("Filter clusters by expiration", func() {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	buildCluster := func(name string, expiration time.Time) *cmv1.Cluster {
+		builder := cmv1.NewCluster().ID(name).Name(name)
+		if !expiration.IsZero() {
+			builder.ExpirationTimestamp(expiration)
+		}
+		cluster, err := builder.Build()
+		Expect(err).NotTo(HaveOccurred())
+		return cluster
+	}
+
+	It("Returns only the clusters expiring before the given time", func() {
+		clusters := []*cmv1.Cluster{
+			buildCluster("soon", now.Add(2*time.Hour)),
+			buildCluster("later", now.Add(72*time.Hour)),
+			buildCluster("never", time.Time{}),
+			buildCluster("expired", now.Add(-time.Hour)),
+		}
+		filtered := FilterClustersExpiringBefore(clusters, now.Add(48*time.Hour))
+		Expect(filtered).To(HaveLen(2))
+		Expect(filtered[0].Name()).To(Equal("soon"))
+		Expect(filtered[1].Name()).To(Equal("expired"))
+	})
+})
+
+var _ = Describe("Get cluster", func() {
+	var server *fakeocm.Server
+	var client *Client
+
+	BeforeEach(func() {
+		server = fakeocm.NewServer()
+		DeferCleanup(server.Close)
+		var err error
+		client, err = NewClient().Logger(logging.NewLogger()).Config(server.Config()).Build()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Close)
+		DeferCleanup(logging.SetField, logging.ClusterIDField, "")
+	})
+
+	It("Adds the identifier of the cluster to the log messages", func() {
+		body, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := client.ocm.ClustersMgmt().V1().Clusters().Add().Body(body).Send()
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster, err := client.GetCluster("mycluster", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.ID()).To(Equal(response.Body().ID()))
+
+		buffer := &bytes.Buffer{}
+		logger := logging.NewLogger()
+		logger.SetOutput(buffer)
+		logger.SetFormatter(&logrus.JSONFormatter{})
+		logger.Info("Loaded cluster")
+		entry := map[string]interface{}{}
+		Expect(json.Unmarshal(buffer.Bytes(), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue(logging.ClusterIDField, cluster.ID()))
+	})
+})
@@ -52,3 +52,17 @@ func (c *Client) DeleteExternalAuth(clusterID string, externalAuthId string) err
 	}
 	return nil
 }
+
+func (c *Client) UpdateExternalAuth(clusterID string, externalAuthId string,
+	ExternalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		ExternalAuthConfig().ExternalAuths().
+		ExternalAuth(externalAuthId).
+		Update().Body(ExternalAuth).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
@@ -159,6 +159,32 @@ var _ = Describe("ExternalAuthConfig", Ordered, func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("Updates ExternalAuthConfig", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				body,
+			),
+		)
+
+		externalAuth, err := ocmClient.UpdateExternalAuth(clusterId, externalAuthId, externalAuth)
+
+		Expect(externalAuth).NotTo(BeNil())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Fails to update ExternalAuthConfig if none exists", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusNotFound,
+				body,
+			),
+		)
+
+		_, err := ocmClient.UpdateExternalAuth(clusterId, externalAuthId, externalAuth)
+		Expect(err).To(HaveOccurred())
+	})
+
 })
 
 func CreateExternalAuthConfig() (*cmv1.ExternalAuth, string, error) {
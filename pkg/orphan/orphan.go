@@ -0,0 +1,363 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find the ROSA IAM and OIDC resources that no live
+// cluster references anymore, and to delete them.
+
+package orphan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+type Kind string
+
+const (
+	KindOperatorRoles  Kind = "operator-roles"
+	KindOperatorPolicy Kind = "operator-policy"
+	KindOidcConfig     Kind = "oidc-config"
+	KindOidcProvider   Kind = "oidc-provider"
+)
+
+// MinimumOidcConfigAge is the age that an OIDC config needs to have to be considered orphaned. OIDC
+// configs aren't linked to any cluster, and they are often created ahead of time for Hosted Control
+// Plane clusters that don't exist yet.
+const MinimumOidcConfigAge = 7 * 24 * time.Hour
+
+// Resource is a resource, or a group of resources in the case of operator roles sharing a prefix,
+// that no live cluster references
+type Resource struct {
+	Kind Kind
+	// ID is the prefix of the operator roles, the ARN of the policy or OIDC provider, or the ID of
+	// the OIDC config
+	ID string
+	// RoleNames are the names of the operator roles sharing the prefix
+	RoleNames       []string
+	ManagedPolicies bool
+	// Managed indicates if the OIDC config is managed by Red Hat
+	Managed   bool
+	IssuerUrl string
+	Reason    string
+}
+
+// Find returns the resources of the current AWS account that no live cluster references, with
+// the reason why each of them is considered orphaned. Operator roles and OIDC providers that were
+// not created for a cluster are ignored, as well as OIDC configs created recently, as they may
+// have been created ahead of time for clusters that don't exist yet.
+func Find(r *rosa.Runtime) ([]*Resource, error) {
+	resources := []*Resource{}
+
+	operatorRoles, err := findOperatorRoles(r)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, operatorRoles...)
+
+	operatorPolicies, err := findOperatorPolicies(r)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, operatorPolicies...)
+
+	oidcConfigs, inUseIssuers, err := findOidcConfigs(r)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, oidcConfigs...)
+
+	oidcProviders, err := findOidcProviders(r, oidcConfigs, inUseIssuers)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, oidcProviders...)
+
+	return resources, nil
+}
+
+func findOperatorRoles(r *rosa.Runtime) ([]*Resource, error) {
+	operatorRoles, err := r.AWSClient.ListOperatorRoles("", "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list operator roles: %v", err)
+	}
+	prefixes := make([]string, 0, len(operatorRoles))
+	for prefix := range operatorRoles {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	resources := []*Resource{}
+	for _, key := range prefixes {
+		roles := createdByRosa(operatorRoles[key])
+		if len(roles) == 0 || roles[0].ClusterID == "" {
+			continue
+		}
+		// The keys of the map are lower case, but the prefix used by the clusters keeps the
+		// original case of the role names
+		prefix := roles[0].RoleName[:len(key)]
+		inUse, err := r.OCMClient.HasAClusterUsingOperatorRolesPrefix(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if any cluster uses operator roles with prefix '%s': %v",
+				prefix, err)
+		}
+		if inUse {
+			continue
+		}
+		resource := &Resource{
+			Kind:            KindOperatorRoles,
+			ID:              prefix,
+			ManagedPolicies: roles[0].ManagedPolicy,
+			Reason: fmt.Sprintf("No cluster uses operator roles with prefix '%s', they were created for "+
+				"cluster '%s'", prefix, roles[0].ClusterID),
+		}
+		for _, role := range roles {
+			resource.RoleNames = append(resource.RoleNames, role.RoleName)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// createdByRosa returns the roles that have the tags that rosa adds to the operator roles. The
+// names of the roles alone aren't enough, as customer roles may look like operator roles.
+func createdByRosa(roles []aws.OperatorRoleDetail) []aws.OperatorRoleDetail {
+	result := []aws.OperatorRoleDetail{}
+	for _, role := range roles {
+		if role.RedHatManaged && role.OperatorNamespace != "" && role.OperatorName != "" {
+			result = append(result, role)
+		}
+	}
+	return result
+}
+
+func findOperatorPolicies(r *rosa.Runtime) ([]*Resource, error) {
+	policies, err := r.AWSClient.ListUnattachedOperatorPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list operator policies: %v", err)
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	accountRoles, err := r.AWSClient.ListAccountRoles("")
+	// An account without account roles is reported as an error, but it only means that none of
+	// the policies can be reused
+	if err != nil && !strings.Contains(err.Error(), "no account roles found") {
+		return nil, fmt.Errorf("Failed to list account roles: %v", err)
+	}
+
+	resources := []*Resource{}
+	for _, policy := range policies {
+		if policy.RolePrefix != "" && hasAccountRoleWithPrefix(accountRoles, policy.RolePrefix) {
+			// Operator policies are shared by all the clusters created with the same account roles,
+			// so they are still needed while the account roles exist
+			continue
+		}
+		reason := "Not attached to any operator role"
+		if policy.RolePrefix != "" {
+			reason = fmt.Sprintf("%s and there are no account roles with prefix '%s'", reason,
+				policy.RolePrefix)
+		}
+		resources = append(resources, &Resource{
+			Kind:   KindOperatorPolicy,
+			ID:     policy.PolicyArn,
+			Reason: reason,
+		})
+	}
+	return resources, nil
+}
+
+func hasAccountRoleWithPrefix(accountRoles []aws.Role, prefix string) bool {
+	for _, role := range accountRoles {
+		if strings.HasPrefix(role.RoleName, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// findOidcConfigs returns the OIDC configs older than the minimum age that no cluster uses, and the
+// issuer URLs of the ones still in use
+func findOidcConfigs(r *rosa.Runtime) ([]*Resource, map[string]bool, error) {
+	oidcConfigs, err := r.OCMClient.ListOidcConfigs(r.Creator.AccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to list OIDC configs: %v", err)
+	}
+	resources := []*Resource{}
+	inUseIssuers := map[string]bool{}
+	for _, oidcConfig := range oidcConfigs {
+		if time.Since(oidcConfig.CreationTimestamp()) < MinimumOidcConfigAge {
+			continue
+		}
+		inUse, err := r.OCMClient.HasAClusterUsingOidcEndpointUrl(oidcConfig.IssuerUrl())
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to check if any cluster uses OIDC config '%s': %v",
+				oidcConfig.ID(), err)
+		}
+		if inUse {
+			inUseIssuers[normalizeIssuerUrl(oidcConfig.IssuerUrl())] = true
+			continue
+		}
+		resources = append(resources, &Resource{
+			Kind:      KindOidcConfig,
+			ID:        oidcConfig.ID(),
+			Managed:   oidcConfig.Managed(),
+			IssuerUrl: oidcConfig.IssuerUrl(),
+			Reason:    fmt.Sprintf("No cluster uses issuer URL '%s'", oidcConfig.IssuerUrl()),
+		})
+	}
+	return resources, inUseIssuers, nil
+}
+
+func findOidcProviders(r *rosa.Runtime, orphanedConfigs []*Resource,
+	inUseIssuers map[string]bool) ([]*Resource, error) {
+	providers, err := r.AWSClient.ListOidcProviders("", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list OIDC providers: %v", err)
+	}
+	orphanedIssuers := map[string]string{}
+	for _, oidcConfig := range orphanedConfigs {
+		orphanedIssuers[normalizeIssuerUrl(oidcConfig.IssuerUrl)] = oidcConfig.ID
+	}
+
+	resources := []*Resource{}
+	for _, provider := range providers {
+		resourceId, err := aws.GetResourceIdFromOidcProviderARN(provider.Arn)
+		if err != nil {
+			return nil, err
+		}
+		issuerUrl := "https://" + resourceId
+		if inUseIssuers[normalizeIssuerUrl(issuerUrl)] {
+			continue
+		}
+		configID, hasOrphanedConfig := orphanedIssuers[normalizeIssuerUrl(issuerUrl)]
+		if provider.ClusterId == "" && !hasOrphanedConfig {
+			continue
+		}
+		inUse, err := r.OCMClient.HasAClusterUsingOidcProvider(issuerUrl, r.Creator.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if any cluster uses OIDC provider '%s': %v",
+				provider.Arn, err)
+		}
+		if inUse {
+			continue
+		}
+		reason := fmt.Sprintf("No cluster uses issuer URL '%s'", issuerUrl)
+		if hasOrphanedConfig {
+			reason = fmt.Sprintf("%s, it is only registered by unused OIDC config '%s'", reason, configID)
+		}
+		if provider.ClusterId != "" {
+			reason = fmt.Sprintf("%s, it was created for cluster '%s'", reason, provider.ClusterId)
+		}
+		resources = append(resources, &Resource{
+			Kind:      KindOidcProvider,
+			ID:        provider.Arn,
+			IssuerUrl: issuerUrl,
+			Reason:    reason,
+		})
+	}
+	return resources, nil
+}
+
+func normalizeIssuerUrl(issuerUrl string) string {
+	return strings.TrimSuffix(issuerUrl, "/")
+}
+
+// Delete removes the given resource using the current AWS account and OCM connection
+func Delete(r *rosa.Runtime, resource *Resource) error {
+	switch resource.Kind {
+	case KindOperatorRoles:
+		for _, roleName := range resource.RoleNames {
+			err := r.AWSClient.DeleteOperatorRole(roleName, resource.ManagedPolicies)
+			if err != nil {
+				return fmt.Errorf("Failed to delete operator role '%s': %v", roleName, err)
+			}
+		}
+	case KindOperatorPolicy:
+		err := r.AWSClient.DeletePolicy(resource.ID)
+		if err != nil {
+			return fmt.Errorf("Failed to delete operator policy '%s': %v", resource.ID, err)
+		}
+	case KindOidcConfig:
+		if !resource.Managed {
+			return fmt.Errorf("OIDC config '%s' is not managed by Red Hat, delete it together with its "+
+				"S3 bucket and secret using 'rosa delete oidc-config --oidc-config-id %s'",
+				resource.ID, resource.ID)
+		}
+		err := r.OCMClient.DeleteOidcConfig(resource.ID)
+		if err != nil {
+			return fmt.Errorf("Failed to delete OIDC config '%s': %v", resource.ID, err)
+		}
+	case KindOidcProvider:
+		err := r.AWSClient.DeleteOpenIDConnectProvider(resource.ID)
+		if err != nil {
+			return fmt.Errorf("Failed to delete OIDC provider '%s': %v", resource.ID, err)
+		}
+	default:
+		return fmt.Errorf("Unknown resource type '%s'", resource.Kind)
+	}
+	return nil
+}
+
+// ManualCommands returns the commands that the user needs to run to delete the given resource
+func ManualCommands(r *rosa.Runtime, resource *Resource) ([]string, error) {
+	commands := []string{}
+	switch resource.Kind {
+	case KindOperatorRoles:
+		policyMap, err := r.AWSClient.GetPolicies(resource.RoleNames)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get the policies of the operator roles with prefix '%s': %v",
+				resource.ID, err)
+		}
+		for _, roleName := range resource.RoleNames {
+			for _, policyArn := range policyMap[roleName] {
+				commands = append(commands, awscb.NewIAMCommandBuilder().
+					SetCommand(awscb.DetachRolePolicy).
+					AddParam(awscb.RoleName, roleName).
+					AddParam(awscb.PolicyArn, policyArn).
+					Build())
+			}
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DeleteRole).
+				AddParam(awscb.RoleName, roleName).
+				Build())
+		}
+	case KindOperatorPolicy:
+		commands = append(commands, awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.DeletePolicy).
+			AddParam(awscb.PolicyArn, resource.ID).
+			Build())
+	case KindOidcConfig:
+		command := fmt.Sprintf("rosa delete oidc-config --oidc-config-id %s", resource.ID)
+		if !resource.Managed {
+			command = fmt.Sprintf("%s --mode manual", command)
+		}
+		commands = append(commands, command)
+	case KindOidcProvider:
+		commands = append(commands, awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.DeleteOpenIdConnectProvider).
+			AddParam(awscb.OpenIdConnectProviderArn, resource.ID).
+			Build())
+	default:
+		return nil, fmt.Errorf("Unknown resource type '%s'", resource.Kind)
+	}
+	return commands, nil
+}
@@ -0,0 +1,13 @@
+package orphan
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOrphan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Orphan Suite")
+}
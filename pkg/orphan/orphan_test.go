@@ -0,0 +1,161 @@
+package orphan
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const (
+	issuerUrl   = "https://bucket.s3.us-east-1.amazonaws.com/2a3b4c"
+	providerArn = "arn:aws:iam::123:oidc-provider/bucket.s3.us-east-1.amazonaws.com/2a3b4c"
+)
+
+func clusterCount(total int) string {
+	return fmt.Sprintf(`{"kind": "ClusterList", "page": 1, "size": %d, "total": %d, "items": []}`, total, total)
+}
+
+var _ = Describe("Orphaned resources", func() {
+	var testRuntime *test.TestingRuntime
+	var awsClient *aws.MockClient
+
+	BeforeEach(func() {
+		testRuntime = test.NewTestRuntime()
+		awsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		testRuntime.RosaRuntime.AWSClient = awsClient
+	})
+
+	It("Finds the resources that no cluster references", func() {
+		awsClient.EXPECT().ListOperatorRoles("", "").Return(map[string][]aws.OperatorRoleDetail{
+			"myprefix": {
+				{RoleName: "MyPrefix-openshift-ingress-operator-cloud-credentials", ClusterID: "abc",
+					ManagedPolicy: true, RedHatManaged: true, OperatorNamespace: "openshift-ingress-operator",
+					OperatorName: "cloud-credentials"},
+				{RoleName: "MyPrefix-kube-system-capa-controller-manager", ClusterID: "abc", ManagedPolicy: true,
+					RedHatManaged: true, OperatorNamespace: "kube-system", OperatorName: "capa-controller-manager"},
+			},
+			"used": {
+				{RoleName: "used-openshift-ingress-operator-cloud-credentials", ClusterID: "def",
+					RedHatManaged: true, OperatorNamespace: "openshift-ingress-operator",
+					OperatorName: "cloud-credentials"},
+			},
+			// Roles created ahead of time for a Hosted Control Plane cluster
+			"ahead": {
+				{RoleName: "ahead-openshift-ingress-operator-cloud-credentials", RedHatManaged: true,
+					OperatorNamespace: "openshift-ingress-operator", OperatorName: "cloud-credentials"},
+			},
+			// Customer role that only looks like an operator role
+			"customer": {
+				{RoleName: "customer-openshift-builds"},
+			},
+		}, nil)
+		awsClient.EXPECT().ListUnattachedOperatorPolicies().Return([]aws.PolicyDetail{
+			{PolicyArn: "arn:aws:iam::123:policy/gone-openshift-ingress-operator-cloud-credentials",
+				RolePrefix: "gone"},
+			{PolicyArn: "arn:aws:iam::123:policy/acct-openshift-ingress-operator-cloud-credentials",
+				RolePrefix: "acct"},
+		}, nil)
+		awsClient.EXPECT().ListAccountRoles("").Return([]aws.Role{{RoleName: "acct-Installer-Role"}}, nil)
+		awsClient.EXPECT().ListOidcProviders("", nil).Return([]aws.OidcProviderOutput{
+			{Arn: providerArn},
+			// Provider of the OIDC config created recently
+			{Arn: "arn:aws:iam::123:oidc-provider/bucket.s3.us-east-1.amazonaws.com/recent"},
+		}, nil)
+
+		testRuntime.ApiServer.AppendHandlers(
+			// Operator roles with prefix 'MyPrefix'
+			RespondWithJSON(http.StatusOK, clusterCount(0)),
+			// Operator roles with prefix 'used'
+			RespondWithJSON(http.StatusOK, clusterCount(1)),
+			RespondWithJSON(http.StatusOK, fmt.Sprintf(`{"kind": "OidcConfigList", "page": 1, "size": 2,
+				"total": 2, "items": [
+					{"id": "2a3b4c", "issuer_url": "%s", "managed": false,
+						"creation_timestamp": "2024-01-01T00:00:00Z"},
+					{"id": "recent", "issuer_url": "https://bucket.s3.us-east-1.amazonaws.com/recent",
+						"managed": true, "creation_timestamp": "%s"}
+				]}`, issuerUrl, time.Now().UTC().Format(time.RFC3339))),
+			// OIDC config
+			RespondWithJSON(http.StatusOK, clusterCount(0)),
+			// OIDC provider
+			RespondWithJSON(http.StatusOK, clusterCount(0)),
+		)
+
+		resources, err := Find(testRuntime.RosaRuntime)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(HaveLen(4))
+
+		Expect(resources[0].Kind).To(Equal(KindOperatorRoles))
+		Expect(resources[0].ID).To(Equal("MyPrefix"))
+		Expect(resources[0].RoleNames).To(HaveLen(2))
+		Expect(resources[0].ManagedPolicies).To(BeTrue())
+		Expect(resources[0].Reason).To(Equal(
+			"No cluster uses operator roles with prefix 'MyPrefix', they were created for cluster 'abc'"))
+
+		Expect(resources[1].Kind).To(Equal(KindOperatorPolicy))
+		Expect(resources[1].ID).To(ContainSubstring("gone-openshift"))
+		Expect(resources[1].Reason).To(ContainSubstring("there are no account roles with prefix 'gone'"))
+
+		Expect(resources[2].Kind).To(Equal(KindOidcConfig))
+		Expect(resources[2].ID).To(Equal("2a3b4c"))
+		Expect(resources[2].Managed).To(BeFalse())
+
+		Expect(resources[3].Kind).To(Equal(KindOidcProvider))
+		Expect(resources[3].ID).To(Equal(providerArn))
+		Expect(resources[3].Reason).To(ContainSubstring("only registered by unused OIDC config '2a3b4c'"))
+	})
+
+	It("Ignores the roles that only look like operator roles", func() {
+		awsClient.EXPECT().ListOperatorRoles("", "").Return(map[string][]aws.OperatorRoleDetail{
+			"customer": {
+				{RoleName: "customer-openshift-builds"},
+				{RoleName: "customer-kube-system-audit", RedHatManaged: true},
+			},
+		}, nil)
+		awsClient.EXPECT().ListUnattachedOperatorPolicies().Return(nil, nil)
+		awsClient.EXPECT().ListOidcProviders("", nil).Return(nil, nil)
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{"kind": "OidcConfigList", "page": 1, "size": 0, "total": 0,
+				"items": []}`),
+		)
+
+		resources, err := Find(testRuntime.RosaRuntime)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(BeEmpty())
+	})
+
+	It("Refuses to delete unmanaged OIDC configs", func() {
+		err := Delete(testRuntime.RosaRuntime, &Resource{Kind: KindOidcConfig, ID: "2a3b4c"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("rosa delete oidc-config --oidc-config-id 2a3b4c"))
+	})
+
+	It("Builds the manual commands", func() {
+		awsClient.EXPECT().GetPolicies([]string{"p-openshift-ingress"}).Return(map[string][]string{
+			"p-openshift-ingress": {"arn:aws:iam::123:policy/p-ingress"},
+		}, nil)
+		commands, err := ManualCommands(testRuntime.RosaRuntime, &Resource{
+			Kind:      KindOperatorRoles,
+			ID:        "p",
+			RoleNames: []string{"p-openshift-ingress"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(Equal([]string{
+			"aws iam detach-role-policy \\\n\t--policy-arn arn:aws:iam::123:policy/p-ingress \\\n" +
+				"\t--role-name p-openshift-ingress",
+			"aws iam delete-role \\\n\t--role-name p-openshift-ingress",
+		}))
+
+		commands, err = ManualCommands(testRuntime.RosaRuntime, &Resource{Kind: KindOidcProvider, ID: providerArn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(HaveLen(1))
+		Expect(commands[0]).To(ContainSubstring("delete-open-id-connect-provider"))
+	})
+})
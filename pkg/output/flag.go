@@ -27,13 +27,17 @@ import (
 const (
 	JSON           = "json"
 	YAML           = "yaml"
+	NAME           = "name"
+	JSONPATH       = "jsonpath"
+	GOTEMPLATE     = "go-template"
+	CUSTOMCOLUMNS  = "custom-columns"
 	FLAG_NAME      = "output"
 	FLAG_SHORTHAND = "o"
 )
 
 var o string
 
-var formats = []string{JSON, YAML}
+var formats = []string{JSON, YAML, NAME, JSONPATH + "=", GOTEMPLATE + "=", CUSTOMCOLUMNS + "="}
 
 // AddFlag adds the interactive flag to the given set of command line flags.
 func AddFlag(cmd *cobra.Command) {
@@ -42,14 +46,16 @@ func AddFlag(cmd *cobra.Command) {
 		FLAG_NAME,
 		FLAG_SHORTHAND,
 		"",
-		fmt.Sprintf("Output format. Allowed formats are %s", formats),
+		fmt.Sprintf("Output format. Allowed formats are %s. The jsonpath and go-template formats take "+
+			"a template, e.g. 'jsonpath={.api.url}', and custom-columns a comma-separated list of "+
+			"'<header>:<jsonpath>' columns, e.g. 'custom-columns=ID:.id,NAME:.name'", formats),
 	)
 
 	cmd.RegisterFlagCompletionFunc(FLAG_NAME, completion)
 }
 
 func completion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	return formats, cobra.ShellCompDirectiveDefault
+	return formats, cobra.ShellCompDirectiveNoSpace
 }
 
 func HasFlag() bool {
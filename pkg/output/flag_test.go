@@ -28,15 +28,16 @@ var _ = Describe("Output flag", func() {
 		Expect(flag.Name).To(Equal(FLAG_NAME))
 		Expect(flag.Shorthand).To(Equal(FLAG_SHORTHAND))
 		Expect(flag.Value.String()).To(Equal(""))
-		Expect(flag.Usage).To(Equal("Output format. Allowed formats are [json yaml]"))
+		Expect(flag.Usage).To(HavePrefix(
+			"Output format. Allowed formats are [json yaml name jsonpath= go-template= custom-columns=]"))
 	})
 
 	It("Has a completion function", func() {
 		args, directive := completion(nil, nil, "")
-		Expect(len(args)).To(Equal(2))
-		Expect(args).To(ContainElements(JSON, YAML))
+		Expect(len(args)).To(Equal(6))
+		Expect(args).To(ContainElements(JSON, YAML, NAME, "jsonpath=", "go-template=", "custom-columns="))
 
-		Expect(directive).To(Equal(cobra.ShellCompDirectiveDefault))
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoSpace))
 	})
 
 	It("Has flag", func() {
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the kubectl style output formats that extract fields of the resources
+// instead of printing the whole document.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Value printed by the custom columns that have no value
+const noneValue = "<none>"
+
+// decodeResource decodes the JSON representation of a resource keeping numbers as they are
+func decodeResource(body []byte) (interface{}, error) {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err := decoder.Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode resource: %v", err)
+	}
+	return data, nil
+}
+
+// resourceItems returns the elements of a list of resources, or the resource itself
+func resourceItems(data interface{}) []interface{} {
+	if items, ok := data.([]interface{}); ok {
+		return items
+	}
+	return []interface{}{data}
+}
+
+func printJSONPath(body []byte, text string) (string, error) {
+	parsed, err := parseJSONPath(text)
+	if err != nil {
+		return "", err
+	}
+	data, err := decodeResource(body)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Execute(data)
+}
+
+func printGoTemplate(body []byte, text string) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("Go template format specified but no template given")
+	}
+	parsed, err := template.New("output").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Invalid Go template: %v", err)
+	}
+	data, err := decodeResource(body)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	err = parsed.Execute(&out, data)
+	if err != nil {
+		return "", fmt.Errorf("Failed to execute Go template: %v", err)
+	}
+	return out.String(), nil
+}
+
+// printCustomColumns prints a table with a row for each resource and a column for each of the
+// '<header>:<jsonpath>' entries of the given comma-separated specification. Without a
+// specification the columns registered for the resource are used.
+func printCustomColumns(body []byte, spec string, registered []Column) (string, error) {
+	columns := registered
+	if spec != "" {
+		columns = []Column{}
+		for _, column := range strings.Split(spec, ",") {
+			parts := strings.SplitN(column, ":", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return "", fmt.Errorf("Expected a custom column in the format '<header>:<jsonpath>', got '%s'",
+					column)
+			}
+			columns = append(columns, Column{Header: parts[0], Path: parts[1]})
+		}
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("Custom columns format requires a list of '<header>:<jsonpath>' columns")
+	}
+	return printTable(body, columns, noneValue, 3)
+}
+
+// printTable prints a table with a row for each resource, using the given value for the columns
+// that have no value
+func printTable(body []byte, columns []Column, missing string, padding int) (string, error) {
+	headers := make([]string, len(columns))
+	templates := make([]*jsonPathTemplate, len(columns))
+	for i, column := range columns {
+		path := column.Path
+		if !strings.HasPrefix(path, "{") {
+			path = fmt.Sprintf("{%s}", path)
+		}
+		parsed, err := parseJSONPath(path)
+		if err != nil {
+			return "", err
+		}
+		headers[i] = column.Header
+		templates[i] = parsed.AllowMissingKeys(true)
+	}
+	data, err := decodeResource(body)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	writer := tabwriter.NewWriter(&out, 0, 4, padding, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, item := range resourceItems(data) {
+		values := make([]string, len(templates))
+		for i, columnTemplate := range templates {
+			value, err := columnTemplate.Execute(item)
+			if err != nil {
+				return "", err
+			}
+			if value == "" {
+				value = missing
+			}
+			values[i] = value
+		}
+		fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+	err = writer.Flush()
+	return out.String(), err
+}
+
+// printNames prints a line with '<kind>/<name>' for each resource. Resources without a kind are
+// printed with their name only, and those without a name with their identifier.
+func printNames(body []byte) (string, error) {
+	data, err := decodeResource(body)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	for _, item := range resourceItems(data) {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := firstString(object, "name", "Name", "RoleName", "id", "ID", "Arn")
+		if name == "" {
+			continue
+		}
+		if kind := firstString(object, "kind"); kind != "" {
+			name = fmt.Sprintf("%s/%s", strings.ToLower(kind), name)
+		}
+		out.WriteString(name)
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
+
+func firstString(object map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := object[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Output formats", func() {
+	clusters := *bytes.NewBufferString(`[
+		{"kind": "Cluster", "id": "123", "name": "first", "api": {"url": "https://api.first:6443"}},
+		{"kind": "Cluster", "id": "456", "name": "second"}
+	]`)
+	roles := *bytes.NewBufferString(`[{"RoleName": "ManagedOpenShift-Installer-Role", "RoleType": "Installer"}]`)
+
+	AfterEach(func() {
+		SetOutput("")
+	})
+
+	It("Prints JSONPath templates", func() {
+		SetOutput("jsonpath={[*].api.url}")
+		Expect(parseResource(clusters, nil)).To(Equal("https://api.first:6443"))
+	})
+
+	It("Fails with empty templates", func() {
+		SetOutput("jsonpath=")
+		_, err := parseResource(clusters, nil)
+		Expect(err).To(MatchError("JSONPath format specified but no template given"))
+		SetOutput("go-template=")
+		_, err = parseResource(clusters, nil)
+		Expect(err).To(MatchError("Go template format specified but no template given"))
+	})
+
+	It("Prints Go templates", func() {
+		SetOutput(`go-template={{range .}}{{.id}} {{end}}`)
+		Expect(parseResource(clusters, nil)).To(Equal("123 456 "))
+	})
+
+	It("Fails with invalid Go templates", func() {
+		SetOutput("go-template={{.id")
+		_, err := parseResource(clusters, nil)
+		Expect(err).To(MatchError(ContainSubstring("Invalid Go template")))
+	})
+
+	It("Prints custom columns", func() {
+		SetOutput("custom-columns=ID:.id,NAME:{.name},API:.api.url")
+		Expect(parseResource(clusters, nil)).To(Equal(
+			"ID    NAME     API\n" +
+				"123   first    https://api.first:6443\n" +
+				"456   second   <none>\n"))
+	})
+
+	It("Prints custom columns of a single resource", func() {
+		SetOutput("custom-columns=NAME:.RoleName")
+		Expect(parseResource(*bytes.NewBufferString(`{"RoleName": "role"}`), nil)).To(Equal("NAME\nrole\n"))
+	})
+
+	It("Fails with invalid custom columns", func() {
+		SetOutput("custom-columns=ID")
+		_, err := parseResource(clusters, nil)
+		Expect(err).To(MatchError(ContainSubstring("<header>:<jsonpath>")))
+	})
+
+	It("Prints the names of the resources", func() {
+		SetOutput(NAME)
+		Expect(parseResource(clusters, nil)).To(Equal("cluster/first\ncluster/second\n"))
+		Expect(parseResource(roles, nil)).To(Equal("ManagedOpenShift-Installer-Role\n"))
+	})
+
+	It("Fails with unknown formats", func() {
+		SetOutput("table")
+		_, err := parseResource(clusters, nil)
+		Expect(err).To(MatchError(ContainSubstring("Unknown format 'table'")))
+	})
+})
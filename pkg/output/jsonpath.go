@@ -0,0 +1,542 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the JSONPath templates used by the 'jsonpath' and 'custom-columns' output
+// formats. The Kubernetes implementation of JSONPath isn't available to this module, so they
+// support the following subset of the syntax of kubectl:
+//
+//	{.api.url}                          field of the resource
+//	{$.id} and {@.id}                   field of the root and of the current value
+//	{.labels.*} and {[*].id}            all the values of an object or array
+//	{[0]}, {[-1]}, {[1:3]}              index and slice of an array, without step
+//	{['kubernetes.io/role']}            field whose name contains dots
+//	{[?(@.state=="ready")].name}        elements matching a filter with ==, !=, <, <=, > or >=
+//	{[?(@.replicas)].name}              elements that have a field
+//	{range [*]}{.id}{"\n"}{end}         iteration over a list of values
+//	{"text"}                            quoted text
+//
+// Recursive descent, unions and slice steps aren't supported. Like kubectl, fields and indexes
+// that don't exist are an error unless missing keys are allowed, as the 'custom-columns' format
+// does, in which case they produce no output.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type jsonPathNode interface{}
+
+type jsonPathText string
+
+type jsonPathRange struct {
+	path jsonPathExpr
+	body []jsonPathNode
+}
+
+type jsonPathExpr struct {
+	fromRoot bool
+	steps    []jsonPathStep
+}
+
+type jsonPathStep struct {
+	field    string
+	wildcard bool
+	index    *int
+	slice    *[2]*int
+	filter   *jsonPathFilter
+}
+
+type jsonPathFilter struct {
+	left     jsonPathExpr
+	operator string
+	right    interface{}
+	rightExp *jsonPathExpr
+}
+
+// jsonPathTemplate is a parsed JSONPath template
+type jsonPathTemplate struct {
+	nodes            []jsonPathNode
+	allowMissingKeys bool
+}
+
+// AllowMissingKeys sets whether fields and indexes that don't exist produce no output instead of
+// an error
+func (t *jsonPathTemplate) AllowMissingKeys(allow bool) *jsonPathTemplate {
+	t.allowMissingKeys = allow
+	return t
+}
+
+func parseJSONPath(template string) (*jsonPathTemplate, error) {
+	if template == "" {
+		return nil, fmt.Errorf("JSONPath format specified but no template given")
+	}
+	stack := [][]jsonPathNode{{}}
+	ranges := []*jsonPathRange{}
+	add := func(node jsonPathNode) {
+		stack[len(stack)-1] = append(stack[len(stack)-1], node)
+	}
+	rest := template
+	for rest != "" {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			add(jsonPathText(rest))
+			break
+		}
+		if start > 0 {
+			add(jsonPathText(rest[:start]))
+		}
+		end := findClosing(rest, start, '{', '}')
+		if end < 0 {
+			return nil, fmt.Errorf("Unclosed action in JSONPath template '%s'", template)
+		}
+		action := strings.TrimSpace(rest[start+1 : end])
+		rest = rest[end+1:]
+		switch {
+		case action == "end":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("Unexpected '{end}' in JSONPath template '%s'", template)
+			}
+			body := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			current := ranges[len(ranges)-1]
+			ranges = ranges[:len(ranges)-1]
+			current.body = body
+			add(current)
+		case strings.HasPrefix(action, "range "):
+			path, err := parseJSONPathExpr(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, &jsonPathRange{path: path})
+			stack = append(stack, []jsonPathNode{})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid string %s in JSONPath template", action)
+			}
+			add(jsonPathText(text))
+		default:
+			path, err := parseJSONPathExpr(action)
+			if err != nil {
+				return nil, err
+			}
+			add(path)
+		}
+	}
+	if len(ranges) > 0 {
+		return nil, fmt.Errorf("Missing '{end}' in JSONPath template '%s'", template)
+	}
+	return &jsonPathTemplate{nodes: stack[0]}, nil
+}
+
+// findClosing returns the position of the character that closes the one at the given position,
+// skipping quoted strings, or -1 if there is none
+func findClosing(value string, position int, open byte, close byte) int {
+	depth := 0
+	for i := position; i < len(value); i++ {
+		switch value[i] {
+		case '"', '\'':
+			quote := value[i]
+			for i++; i < len(value) && value[i] != quote; i++ {
+				if value[i] == '\\' {
+					i++
+				}
+			}
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseJSONPathExpr(value string) (jsonPathExpr, error) {
+	expr := jsonPathExpr{}
+	rest := value
+	switch {
+	case strings.HasPrefix(rest, "$"):
+		expr.fromRoot = true
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "@"):
+		rest = rest[1:]
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			if strings.HasPrefix(rest, "..") {
+				return expr, fmt.Errorf("Recursive descent isn't supported in JSONPath '%s'", value)
+			}
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			field := rest[:end]
+			rest = rest[end:]
+			if field == "*" {
+				expr.steps = append(expr.steps, jsonPathStep{wildcard: true})
+			} else if field != "" {
+				expr.steps = append(expr.steps, jsonPathStep{field: field})
+			}
+		case '[':
+			end := findClosing(rest, 0, '[', ']')
+			if end < 0 {
+				return expr, fmt.Errorf("Unclosed '[' in JSONPath '%s'", value)
+			}
+			step, err := parseJSONPathSubscript(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return expr, fmt.Errorf("Invalid JSONPath '%s': %v", value, err)
+			}
+			expr.steps = append(expr.steps, step)
+			rest = rest[end+1:]
+		default:
+			return expr, fmt.Errorf("Invalid JSONPath '%s', expected '.' or '[' at '%s'", value, rest)
+		}
+	}
+	return expr, nil
+}
+
+func parseJSONPathSubscript(value string) (jsonPathStep, error) {
+	switch {
+	case value == "*":
+		return jsonPathStep{wildcard: true}, nil
+	case strings.HasPrefix(value, "?(") && strings.HasSuffix(value, ")"):
+		filter, err := parseJSONPathFilter(strings.TrimSpace(value[2 : len(value)-1]))
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{filter: filter}, nil
+	case strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`):
+		field, err := unquote(value)
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{field: field}, nil
+	case strings.Contains(value, ":"):
+		parts := strings.SplitN(value, ":", 2)
+		var bounds [2]*int
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			number, err := strconv.Atoi(part)
+			if err != nil {
+				return jsonPathStep{}, fmt.Errorf("invalid slice '%s'", value)
+			}
+			bounds[i] = &number
+		}
+		return jsonPathStep{slice: &bounds}, nil
+	default:
+		index, err := strconv.Atoi(value)
+		if err != nil {
+			return jsonPathStep{}, fmt.Errorf("invalid subscript '%s'", value)
+		}
+		return jsonPathStep{index: &index}, nil
+	}
+}
+
+func parseJSONPathFilter(value string) (*jsonPathFilter, error) {
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		position := indexOutsideQuotes(value, operator)
+		if position < 0 {
+			continue
+		}
+		left, err := parseJSONPathExpr(strings.TrimSpace(value[:position]))
+		if err != nil {
+			return nil, err
+		}
+		filter := &jsonPathFilter{left: left, operator: operator}
+		right := strings.TrimSpace(value[position+len(operator):])
+		switch {
+		case strings.HasPrefix(right, "@") || strings.HasPrefix(right, "$"):
+			rightExp, err := parseJSONPathExpr(right)
+			if err != nil {
+				return nil, err
+			}
+			filter.rightExp = &rightExp
+		case strings.HasPrefix(right, "'") || strings.HasPrefix(right, `"`):
+			filter.right, err = unquote(right)
+			if err != nil {
+				return nil, err
+			}
+		case right == "true" || right == "false":
+			filter.right = right == "true"
+		default:
+			filter.right = json.Number(right)
+		}
+		return filter, nil
+	}
+	left, err := parseJSONPathExpr(value)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathFilter{left: left}, nil
+}
+
+func indexOutsideQuotes(value string, substring string) int {
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' || value[i] == '\'' {
+			quote := value[i]
+			for i++; i < len(value) && value[i] != quote; i++ {
+			}
+			continue
+		}
+		if strings.HasPrefix(value[i:], substring) {
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(value string) (string, error) {
+	if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2 {
+		return value[1 : len(value)-1], nil
+	}
+	return strconv.Unquote(value)
+}
+
+// Execute evaluates the template against the given data, which is the result of decoding a JSON
+// document. Multiple results of the same path are separated by spaces.
+func (t *jsonPathTemplate) Execute(data interface{}) (string, error) {
+	var out bytes.Buffer
+	err := t.execute(&out, t.nodes, data, data)
+	return out.String(), err
+}
+
+func (t *jsonPathTemplate) execute(out *bytes.Buffer, nodes []jsonPathNode, root interface{},
+	current interface{}) error {
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case jsonPathText:
+			out.WriteString(string(node))
+		case jsonPathExpr:
+			values, err := node.evaluate(root, current, t.allowMissingKeys)
+			if err != nil {
+				return err
+			}
+			for i, value := range values {
+				if i > 0 {
+					out.WriteString(" ")
+				}
+				text, err := formatJSONPathValue(value)
+				if err != nil {
+					return err
+				}
+				out.WriteString(text)
+			}
+		case *jsonPathRange:
+			items, err := node.path.evaluate(root, current, t.allowMissingKeys)
+			if err != nil {
+				return err
+			}
+			// Ranging over a single array iterates its elements
+			if len(items) == 1 {
+				if array, ok := items[0].([]interface{}); ok {
+					items = array
+				}
+			}
+			for _, item := range items {
+				err := t.execute(out, node.body, root, item)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// evaluate returns the values selected by the expression. Unless missing keys are allowed it fails
+// when a field isn't in any of the objects it is applied to, or an index is out of range.
+func (e jsonPathExpr) evaluate(root interface{}, current interface{},
+	allowMissingKeys bool) ([]interface{}, error) {
+	values := []interface{}{current}
+	if e.fromRoot {
+		values = []interface{}{root}
+	}
+	for _, step := range e.steps {
+		next := []interface{}{}
+		for _, value := range values {
+			next = append(next, step.apply(root, value)...)
+		}
+		if len(next) == 0 && len(values) > 0 && !allowMissingKeys {
+			switch {
+			case step.index != nil:
+				return nil, fmt.Errorf("Array index %d is out of bounds", *step.index)
+			case step.field != "":
+				return nil, fmt.Errorf("Field '%s' is not found", step.field)
+			}
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (s jsonPathStep) apply(root interface{}, value interface{}) []interface{} {
+	switch {
+	case s.wildcard:
+		switch value := value.(type) {
+		case []interface{}:
+			return value
+		case map[string]interface{}:
+			keys := make([]string, 0, len(value))
+			for key := range value {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			result := make([]interface{}, len(keys))
+			for i, key := range keys {
+				result[i] = value[key]
+			}
+			return result
+		}
+	case s.index != nil:
+		if array, ok := value.([]interface{}); ok {
+			index := *s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index >= 0 && index < len(array) {
+				return []interface{}{array[index]}
+			}
+		}
+	case s.slice != nil:
+		if array, ok := value.([]interface{}); ok {
+			start, end := 0, len(array)
+			if s.slice[0] != nil {
+				start = normalizeIndex(*s.slice[0], len(array))
+			}
+			if s.slice[1] != nil {
+				end = normalizeIndex(*s.slice[1], len(array))
+			}
+			if start < end {
+				return array[start:end]
+			}
+		}
+	case s.filter != nil:
+		items := []interface{}{value}
+		if array, ok := value.([]interface{}); ok {
+			items = array
+		}
+		result := []interface{}{}
+		for _, item := range items {
+			if s.filter.matches(root, item) {
+				result = append(result, item)
+			}
+		}
+		return result
+	default:
+		if object, ok := value.(map[string]interface{}); ok {
+			if field, ok := object[s.field]; ok {
+				return []interface{}{field}
+			}
+		}
+	}
+	return nil
+}
+
+func normalizeIndex(index int, length int) int {
+	if index < 0 {
+		index += length
+	}
+	if index < 0 {
+		return 0
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+func (f *jsonPathFilter) matches(root interface{}, item interface{}) bool {
+	// Elements that don't have the fields used by the filter don't match it
+	left, _ := f.left.evaluate(root, item, true)
+	if f.operator == "" {
+		return len(left) > 0 && left[0] != nil
+	}
+	right := f.right
+	if f.rightExp != nil {
+		values, _ := f.rightExp.evaluate(root, item, true)
+		if len(values) == 0 {
+			return false
+		}
+		right = values[0]
+	}
+	if len(left) == 0 {
+		return f.operator == "!="
+	}
+	leftNumber, leftIsNumber := toFloat(left[0])
+	rightNumber, rightIsNumber := toFloat(right)
+	if leftIsNumber && rightIsNumber {
+		return compare(f.operator, leftNumber < rightNumber, leftNumber == rightNumber)
+	}
+	leftText, _ := formatJSONPathValue(left[0])
+	rightText, _ := formatJSONPathValue(right)
+	return compare(f.operator, leftText < rightText, leftText == rightText)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	if number, ok := value.(json.Number); ok {
+		result, err := number.Float64()
+		return result, err == nil
+	}
+	return 0, false
+}
+
+func compare(operator string, less bool, equal bool) bool {
+	switch operator {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	case ">=":
+		return !less
+	}
+	return false
+}
+
+func formatJSONPathValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		result, err := json.Marshal(value)
+		return string(result), err
+	}
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPath", func() {
+	data, err := decodeResource([]byte(`{
+		"id": "abc",
+		"api": {"url": "https://api.example.com:6443"},
+		"nodes": {"compute": 3},
+		"labels": {"kubernetes.io/role": "worker", "team": "a"},
+		"pools": [
+			{"id": "workers", "replicas": 3, "state": "ready"},
+			{"id": "gpu", "replicas": 0, "state": "pending"},
+			{"id": "infra", "replicas": 2, "state": "ready"}
+		]
+	}`))
+	Expect(err).NotTo(HaveOccurred())
+
+	DescribeTable("Executes templates",
+		func(template string, expected string) {
+			parsed, err := parseJSONPath(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Execute(data)).To(Equal(expected))
+		},
+		Entry("field", "{.api.url}", "https://api.example.com:6443"),
+		Entry("root", "{$.id}", "abc"),
+		Entry("number", "{.nodes.compute}", "3"),
+		Entry("text around fields", "id={.id} compute={.nodes.compute}", "id=abc compute=3"),
+		Entry("quoted field", "{.labels['kubernetes.io/role']}", "worker"),
+		Entry("map wildcard", "{.labels.*}", "worker a"),
+		Entry("array wildcard", "{.pools[*].id}", "workers gpu infra"),
+		Entry("index", "{.pools[0].id}", "workers"),
+		Entry("negative index", "{.pools[-1].id}", "infra"),
+		Entry("slice", "{.pools[1:].id}", "gpu infra"),
+		Entry("object", "{.api}", `{"url":"https://api.example.com:6443"}`),
+		Entry("string filter", `{.pools[?(@.state=="ready")].id}`, "workers infra"),
+		Entry("number filter", "{.pools[?(@.replicas>=2)].id}", "workers infra"),
+		Entry("existence filter", "{.pools[?(@.replicas)].id}", "workers gpu infra"),
+		Entry("range", `{range .pools[*]}{.id}:{.replicas}{"\n"}{end}`, "workers:3\ngpu:0\ninfra:2\n"),
+		Entry("range over array", `{range .pools}{.id},{end}`, "workers,gpu,infra,"),
+		Entry("quoted text", `{.id}{"\t"}{@.id}`, "abc\tabc"),
+		Entry("filter without matches", `{.pools[?(@.state=="failed")].id}`, ""),
+		Entry("filter on missing field", `{.pools[?(@.zone=="a")].id}`, ""),
+		Entry("empty range", `{range .pools[?(@.replicas>5)]}{.id}{end}`, ""),
+	)
+
+	DescribeTable("Fails on missing keys",
+		func(template string, message string) {
+			parsed, err := parseJSONPath(template)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = parsed.Execute(data)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("missing field", "{.missing.field}", "Field 'missing' is not found"),
+		Entry("missing nested field", "{.api.port}", "Field 'port' is not found"),
+		Entry("field of all elements", "{.pools[*].zone}", "Field 'zone' is not found"),
+		Entry("out of range index", "{.pools[5].id}", "Array index 5 is out of bounds"),
+		Entry("missing field in range", "{range .pools[*]}{.zone}{end}", "Field 'zone' is not found"),
+	)
+
+	DescribeTable("Allows missing keys",
+		func(template string, expected string) {
+			parsed, err := parseJSONPath(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.AllowMissingKeys(true).Execute(data)).To(Equal(expected))
+		},
+		Entry("missing field", "{.missing.field}", ""),
+		Entry("out of range index", "{.pools[5].id}", ""),
+		Entry("text around missing field", "id={.id} zone={.zone}", "id=abc zone="),
+	)
+
+	DescribeTable("Rejects invalid templates",
+		func(template string) {
+			_, err := parseJSONPath(template)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty template", ""),
+		Entry("unclosed action", "{.id"),
+		Entry("unclosed subscript", "{.pools[0}"),
+		Entry("missing end", "{range .pools[*]}{.id}"),
+		Entry("unexpected end", "{.id}{end}"),
+		Entry("recursive descent", "{..id}"),
+		Entry("invalid index", "{.pools[a]}"),
+		Entry("union", "{.pools[0,1]}"),
+		Entry("slice step", "{.pools[0:2:1]}"),
+	)
+})
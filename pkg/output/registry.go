@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the registry of the types of resources that can be printed. Each type
+// registers the function that marshals it to JSON and, optionally, the columns of its table,
+// which are used both by the tables of the commands and by the 'custom-columns' format.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Column is a column of the table of a resource, with the JSONPath of its value in the JSON
+// representation of the resource, e.g. '.issuer.url'
+type Column struct {
+	Header string
+	Path   string
+}
+
+type printer struct {
+	marshal func(resource interface{}, writer io.Writer) error
+	columns []Column
+}
+
+var printers = map[reflect.Type]*printer{}
+
+// Register registers the function that marshals resources of type T to JSON and the columns
+// used to print them as a table. Registering the same type twice panics.
+func Register[T any](marshal func(T, io.Writer) error, columns ...Column) {
+	resourceType := reflect.TypeOf((*T)(nil)).Elem()
+	if _, ok := printers[resourceType]; ok {
+		panic(fmt.Sprintf("printer for type '%s' is already registered", resourceType))
+	}
+	printers[resourceType] = &printer{
+		marshal: func(resource interface{}, writer io.Writer) error {
+			return marshal(resource.(T), writer)
+		},
+		columns: columns,
+	}
+}
+
+// RegisterJSON registers resources of type T that are marshalled with the standard JSON encoder
+func RegisterJSON[T any](columns ...Column) {
+	Register(func(resource T, writer io.Writer) error {
+		return json.NewEncoder(writer).Encode(resource)
+	}, columns...)
+}
+
+// Registered returns the types of the resources that can be printed, with the columns of their
+// tables
+func Registered() map[reflect.Type][]Column {
+	result := make(map[reflect.Type][]Column, len(printers))
+	for resourceType, p := range printers {
+		result[resourceType] = append([]Column{}, p.columns...)
+	}
+	return result
+}
+
+func lookupPrinter(resource interface{}) (*printer, error) {
+	resourceType := reflect.TypeOf(resource)
+	if resourceType == nil {
+		return nil, fmt.Errorf("Can't print a nil resource")
+	}
+	result, ok := printers[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("Resources of type '%s' can't be printed, no printer is registered for them",
+			resourceType)
+	}
+	return result, nil
+}
+
+// encode returns the indented JSON representation of the resource
+func (p *printer) encode(resource interface{}) (bytes.Buffer, error) {
+	var b bytes.Buffer
+	var raw bytes.Buffer
+	err := p.marshal(resource, &raw)
+	if err != nil {
+		return b, err
+	}
+	if raw.String() == string(emptyBuffer) {
+		raw = *bytes.NewBufferString("[]")
+	}
+	err = json.Indent(&b, raw.Bytes(), "", "  ")
+	return b, err
+}
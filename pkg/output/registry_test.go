@@ -0,0 +1,64 @@
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+type unregisteredResource struct{}
+
+type duplicatedResource struct{}
+
+var _ = Describe("Printer registry", func() {
+	AfterEach(func() {
+		SetOutput("")
+	})
+
+	It("Fails to print types that aren't registered", func() {
+		_, err := lookupPrinter(unregisteredResource{})
+		Expect(err).To(MatchError(ContainSubstring(
+			"Resources of type 'output.unregisteredResource' can't be printed")))
+	})
+
+	It("Fails to print nil resources", func() {
+		_, err := lookupPrinter(nil)
+		Expect(err).To(MatchError("Can't print a nil resource"))
+	})
+
+	It("Panics when a type is registered twice", func() {
+		RegisterJSON[duplicatedResource]()
+		Expect(func() { RegisterJSON[duplicatedResource]() }).To(Panic())
+	})
+
+	It("Prints empty lists as an empty JSON array", func() {
+		p, err := lookupPrinter([]*cmv1.TuningConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		b, err := p.encode([]*cmv1.TuningConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.String()).To(Equal("[]"))
+	})
+
+	Context("Registered columns", func() {
+		first, _ := cmv1.NewTuningConfig().ID("123").Name("first").Build()
+		second, _ := cmv1.NewTuningConfig().ID("456").Name("second").Build()
+		tuningConfigs := []*cmv1.TuningConfig{first, second}
+
+		It("Are used by the custom columns format without a specification", func() {
+			SetOutput("custom-columns")
+			p, err := lookupPrinter(tuningConfigs)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := p.encode(tuningConfigs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parseResource(b, p.columns)).To(Equal(
+				"ID    NAME\n" +
+					"123   first\n" +
+					"456   second\n"))
+		})
+
+		It("Are required to print tables", func() {
+			err := PrintTable(&cmv1.Cluster{})
+			Expect(err).To(MatchError(ContainSubstring("have no table columns")))
+		})
+	})
+})
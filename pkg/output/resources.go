@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file registers the printers of the OCM resources and of the generic types that the
+// commands build for their output. Types defined in other packages of this module register
+// themselves in those packages, as this package can't import them.
+
+package output
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
+
+	"github.com/openshift/rosa/pkg/object"
+)
+
+func init() {
+	Register(msv1.MarshalManagedServiceList,
+		Column{Header: "SERVICE_ID", Path: ".id"},
+		Column{Header: "SERVICE", Path: ".service"},
+		Column{Header: "SERVICE_STATE", Path: ".service_state"},
+		Column{Header: "CLUSTER_NAME", Path: ".cluster.name"},
+	)
+
+	Register(cmv1.MarshalCloudRegionList)
+	Register(cmv1.MarshalCluster)
+	Register(cmv1.MarshalClusterList)
+	Register(cmv1.MarshalDNSDomainList)
+	Register(cmv1.MarshalExternalAuthList,
+		Column{Header: "NAME", Path: ".id"},
+		Column{Header: "ISSUER URL", Path: ".issuer.url"},
+	)
+	Register(cmv1.MarshalExternalAuth)
+	Register(cmv1.MarshalIdentityProviderList)
+	Register(cmv1.MarshalIngressList)
+	Register(cmv1.MarshalMachinePoolList)
+	Register(cmv1.MarshalMachinePool)
+	Register(cmv1.MarshalMachineTypeList)
+	Register(cmv1.MarshalNodePool)
+	Register(cmv1.MarshalNodePoolList)
+	Register(cmv1.MarshalVersionList)
+	Register(cmv1.MarshalVersionGateList)
+	Register(cmv1.MarshalOidcConfigList,
+		Column{Header: "ID", Path: ".id"},
+		Column{Header: "MANAGED", Path: ".managed"},
+		Column{Header: "ISSUER URL", Path: ".issuer_url"},
+		Column{Header: "SECRET ARN", Path: ".secret_arn"},
+	)
+	Register(cmv1.MarshalOidcConfig)
+	Register(cmv1.MarshalBreakGlassCredentialList,
+		Column{Header: "ID", Path: ".id"},
+		Column{Header: "USERNAME", Path: ".username"},
+		Column{Header: "STATUS", Path: ".status"},
+	)
+	Register(cmv1.MarshalBreakGlassCredential)
+	Register(cmv1.MarshalTuningConfigList,
+		Column{Header: "ID", Path: ".id"},
+		Column{Header: "NAME", Path: ".name"},
+	)
+	Register(cmv1.MarshalTuningConfig)
+	Register(cmv1.MarshalKubeletConfig)
+	Register(cmv1.MarshalClusterAutoscaler)
+	Register(cmv1.MarshalUserList)
+	Register(cmv1.MarshalSubnetNetworkVerification)
+
+	RegisterJSON[map[string]interface{}]()
+	RegisterJSON[[]map[string]interface{}]()
+	RegisterJSON[object.Object]()
+	RegisterJSON[[]string]()
+}
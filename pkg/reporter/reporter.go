@@ -21,26 +21,47 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/rosa/pkg/color"
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/logging"
 )
 
 // Object is the reported object used by the tool. It prints the messages to the standard output or
-// error streams.
+// error streams, and also sends them to the log when it is written to a file or in JSON format.
 type Object struct {
 }
 
+// errorHooks are the functions called with the message of each reported error
+var errorHooks []func(message string)
+
+// OnError registers a function that will be called with the message of each reported error. As
+// most commands exit right after reporting an error, this is the only place where some failures
+// can be observed.
+func OnError(hook func(message string)) {
+	errorHooks = append(errorHooks, hook)
+}
+
 // Debugf prints a debug message with the given format and arguments.
 func (r *Object) Debugf(format string, args ...interface{}) {
 	if !debug.Enabled() {
 		return
 	}
-	r.Infof(format, args...)
+	message := fmt.Sprintf(format, args...)
+	logging.ReportToLog(logrus.DebugLevel, message)
+	r.printInfo(message)
 }
 
-// Infof prints an informative message with the given format and arguments.
+// Infof prints an informative message with the given format and arguments. These messages are
+// the output of the commands, so they are always printed, whatever the selected log level.
 func (r *Object) Infof(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
+	logging.ReportToLog(logrus.InfoLevel, message)
+	r.printInfo(message)
+}
+
+func (r *Object) printInfo(message string) {
 	if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoColorPrefix, message)
 	} else {
@@ -51,6 +72,9 @@ func (r *Object) Infof(format string, args ...interface{}) {
 // Warnf prints an warning message with the given format and arguments.
 func (r *Object) Warnf(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
+	if logging.ReportToLog(logrus.WarnLevel, message) {
+		return
+	}
 	if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnColorPrefix, message)
 	} else {
@@ -63,6 +87,12 @@ func (r *Object) Warnf(format string, args ...interface{}) {
 // report the error and also return it.
 func (r *Object) Errorf(format string, args ...interface{}) error {
 	message := fmt.Sprintf(format, args...)
+	for _, hook := range errorHooks {
+		hook(message)
+	}
+	if logging.ReportToLog(logrus.ErrorLevel, message) {
+		return errors.New(message)
+	}
 	if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorColorPrefix, message)
 	} else {
@@ -8,6 +8,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/rosa/pkg/audit"
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -59,6 +60,7 @@ func (r *Runtime) WithAWS() *Runtime {
 			r.Reporter.Errorf("Failed to get AWS creator: %v", err)
 			os.Exit(1)
 		}
+		audit.SetCreator(r.Creator)
 	}
 	return r
 }
@@ -79,6 +81,7 @@ func (r *Runtime) GetClusterKey() string {
 		os.Exit(1)
 	}
 	r.ClusterKey = clusterKey
+	audit.SetCluster(clusterKey, "")
 	return clusterKey
 }
 
@@ -106,5 +109,6 @@ func (r *Runtime) FetchCluster() *cmv1.Cluster {
 		os.Exit(1)
 	}
 	r.Cluster = cluster
+	audit.SetCluster(cluster.Name(), cluster.ID())
 	return cluster
 }
@@ -0,0 +1,13 @@
+package fakeocm
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFakeOCM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fake OCM Suite")
+}
@@ -0,0 +1,392 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the evaluator of the subset of the OCM search language used by rosa:
+// comparisons with '=', '!=', '<>', '<', '<=', '>', '>=', 'LIKE', 'ILIKE', 'IN' and 'IS NULL',
+// combined with 'AND', 'OR', 'NOT' and parenthesis.
+
+package fakeocm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// predicate decides if an object matches a search
+type predicate func(object map[string]interface{}) bool
+
+// parseSearch parses the given search expression. The empty expression matches all the objects.
+func parseSearch(search string) (predicate, error) {
+	if strings.TrimSpace(search) == "" {
+		return func(map[string]interface{}) bool { return true }, nil
+	}
+	tokens, err := tokenize(search)
+	if err != nil {
+		return nil, err
+	}
+	p := &searchParser{tokens: tokens}
+	result, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected '%s' in search '%s'", p.peek().text, search)
+	}
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	stringToken
+	numberToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(search string) ([]token, error) {
+	var tokens []token
+	runes := []rune(search)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string in search '%s'", search)
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: stringToken, text: value.String()})
+		case strings.ContainsRune("(),", r):
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune("=<>", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: string(runes[i:j])})
+			i = j
+		case unicode.IsDigit(r) || r == '-':
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) ||
+				strings.ContainsRune("_.", runes[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: identToken, text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in search '%s'", r, search)
+		}
+	}
+	return tokens, nil
+}
+
+type searchParser struct {
+	tokens []token
+	next   int
+}
+
+func (p *searchParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *searchParser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.next]
+}
+
+// keyword consumes the next token if it is the given keyword or symbol
+func (p *searchParser) keyword(text string) bool {
+	next := p.peek()
+	if (next.kind == identToken || next.kind == symbolToken) && strings.EqualFold(next.text, text) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *searchParser) expect(text string) error {
+	if !p.keyword(text) {
+		return fmt.Errorf("expected '%s' but found '%s'", text, p.peek().text)
+	}
+	return nil
+}
+
+func (p *searchParser) or() (predicate, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(object map[string]interface{}) bool {
+			return first(object) || right(object)
+		}
+	}
+	return left, nil
+}
+
+func (p *searchParser) and() (predicate, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(object map[string]interface{}) bool {
+			return first(object) && right(object)
+		}
+	}
+	return left, nil
+}
+
+func (p *searchParser) unary() (predicate, error) {
+	if p.keyword("NOT") {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			return !operand(object)
+		}, nil
+	}
+	if p.keyword("(") {
+		result, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return result, p.expect(")")
+	}
+	return p.comparison()
+}
+
+func (p *searchParser) comparison() (predicate, error) {
+	field := p.peek()
+	if field.kind != identToken {
+		return nil, fmt.Errorf("expected field name but found '%s'", field.text)
+	}
+	p.next++
+
+	if p.keyword("IS") {
+		negate := p.keyword("NOT")
+		err := p.expect("NULL")
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			_, ok := lookup(object, field.text)
+			return ok == negate
+		}, nil
+	}
+
+	negate := p.keyword("NOT")
+	switch {
+	case p.keyword("IN"):
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for {
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if !p.keyword(",") {
+				break
+			}
+		}
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			actual, ok := lookup(object, field.text)
+			if !ok {
+				return false
+			}
+			for _, value := range values {
+				if actual == value {
+					return !negate
+				}
+			}
+			return negate
+		}, nil
+	case p.keyword("LIKE"), p.keyword("ILIKE"):
+		insensitive := strings.EqualFold(p.tokens[p.next-1].text, "ILIKE")
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		pattern := likePattern(value, insensitive)
+		return func(object map[string]interface{}) bool {
+			actual, ok := lookup(object, field.text)
+			return ok && pattern.MatchString(actual) != negate
+		}, nil
+	case negate:
+		return nil, fmt.Errorf("expected 'IN' or 'LIKE' after 'NOT' but found '%s'", p.peek().text)
+	}
+
+	operator := p.peek()
+	if operator.kind != symbolToken {
+		return nil, fmt.Errorf("expected operator but found '%s'", operator.text)
+	}
+	p.next++
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	var compare func(actual string) bool
+	switch operator.text {
+	case "=":
+		compare = func(actual string) bool { return actual == value }
+	case "!=", "<>":
+		compare = func(actual string) bool { return actual != value }
+	case "<", "<=", ">", ">=":
+		compare = func(actual string) bool { return order(actual, value, operator.text) }
+	default:
+		return nil, fmt.Errorf("unsupported operator '%s'", operator.text)
+	}
+	return func(object map[string]interface{}) bool {
+		actual, ok := lookup(object, field.text)
+		return ok && compare(actual)
+	}, nil
+}
+
+func (p *searchParser) value() (string, error) {
+	next := p.peek()
+	switch {
+	case next.kind == stringToken || next.kind == numberToken:
+		p.next++
+		return next.text, nil
+	case next.kind == identToken && (next.text == "true" || next.text == "false"):
+		p.next++
+		return next.text, nil
+	default:
+		return "", fmt.Errorf("expected value but found '%s'", next.text)
+	}
+}
+
+// lookup returns the value of the given dotted field of the object converted to a string
+func lookup(object map[string]interface{}, field string) (string, bool) {
+	var current interface{} = object
+	for _, name := range strings.Split(field, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		current, ok = fields[name]
+		if !ok || current == nil {
+			return "", false
+		}
+	}
+	switch typed := current.(type) {
+	case string:
+		return typed, true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	default:
+		return fmt.Sprintf("%v", typed), true
+	}
+}
+
+func order(actual string, value string, operator string) bool {
+	var result int
+	a, errA := strconv.ParseFloat(actual, 64)
+	b, errB := strconv.ParseFloat(value, 64)
+	switch {
+	case errA == nil && errB == nil && a < b:
+		result = -1
+	case errA == nil && errB == nil && a > b:
+		result = 1
+	case errA == nil && errB == nil:
+		result = 0
+	default:
+		result = strings.Compare(actual, value)
+	}
+	switch operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	default:
+		return result >= 0
+	}
+}
+
+func likePattern(value string, insensitive bool) *regexp.Regexp {
+	var pattern strings.Builder
+	if insensitive {
+		pattern.WriteString("(?i)")
+	}
+	pattern.WriteString("^")
+	for _, r := range value {
+		switch r {
+		case '%':
+			pattern.WriteString(".*")
+		case '_':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
@@ -0,0 +1,61 @@
+package fakeocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Search", func() {
+	object := map[string]interface{}{
+		"id":    "123",
+		"name":  "my-cluster",
+		"state": "ready",
+		"nodes": map[string]interface{}{
+			"compute": float64(3),
+		},
+		"product": map[string]interface{}{
+			"id": "rosa",
+		},
+		"properties": map[string]interface{}{
+			"rosa_creator_arn": "arn:aws:iam::123456789012:user/rosa",
+		},
+	}
+
+	DescribeTable("Evaluates the expression",
+		func(search string, expected bool) {
+			matches, err := parseSearch(search)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches(object)).To(Equal(expected))
+		},
+		Entry("empty", "", true),
+		Entry("equal", "name = 'my-cluster'", true),
+		Entry("not equal", "name != 'my-cluster'", false),
+		Entry("nested field", "product.id = 'rosa'", true),
+		Entry("missing field", "aws.sts.role_arn = 'x'", false),
+		Entry("number", "nodes.compute >= 3", true),
+		Entry("like", "properties.rosa_creator_arn LIKE '%:123456789012:%'", true),
+		Entry("ilike", "name ILIKE 'MY-%'", true),
+		Entry("not like", "name NOT LIKE 'my-%'", false),
+		Entry("in", "state IN ('installing', 'ready')", true),
+		Entry("not in", "state NOT IN ('installing', 'ready')", false),
+		Entry("is null", "dns IS NULL", true),
+		Entry("is not null", "name IS NOT NULL", true),
+		Entry("as used by rosa",
+			"product.id = 'rosa' AND (id = 'my-cluster' OR name = 'my-cluster' OR external_id = 'my-cluster')",
+			true),
+		Entry("precedence", "name = 'other' AND id = '123' OR state = 'ready'", true),
+		Entry("not", "NOT (state = 'ready')", false),
+		Entry("quotes", "name = 'it''s'", false),
+	)
+
+	DescribeTable("Rejects invalid expressions",
+		func(search string) {
+			_, err := parseSearch(search)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unterminated string", "name = 'my-cluster"),
+		Entry("missing value", "name ="),
+		Entry("missing parenthesis", "(name = 'x'"),
+		Entry("trailing tokens", "name = 'x' 'y'"),
+	)
+})
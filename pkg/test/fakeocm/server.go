@@ -0,0 +1,760 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeocm contains an in-process fake of the clusters_mgmt API of OCM that keeps the
+// clusters, machine pools, node pools, identity providers, users, ingresses and upgrade policies
+// created through it, so that complete workflows can be tested without a real OCM. The regions,
+// machine types and flavours that clusters are created with are fixed, and so is the current
+// account. Point rosa to it using the configuration returned by the Config method, or with the
+// '--env' flag of 'rosa login' and the URL and token of the server.
+//
+// The fake doesn't simulate provisioning: clusters are ready as soon as they are created, and
+// they are removed as soon as they are deleted.
+package fakeocm
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+const apiPrefix = "/api/clusters_mgmt/v1/"
+
+// accountsPrefix is the prefix of the accounts_mgmt API, of which the fake only supports the
+// current account and an empty quota
+const accountsPrefix = "/api/accounts_mgmt/v1/"
+
+const organizationID = "1a2b3c4d5e6f7g8h9i0j1k2l3m4"
+
+// resourceType describes a collection of objects of the API
+type resourceType struct {
+	collection *regexp.Regexp
+	kind       string
+	readOnly   bool
+	// Generated identifiers of the objects of the collection, as the real OCM does. Otherwise the
+	// identifier must be given in the body of the request.
+	generateID bool
+}
+
+var resourceTypes = []resourceType{
+	{
+		collection: regexp.MustCompile(`^clusters$`),
+		kind:       "Cluster",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/machine_pools$`),
+		kind:       "MachinePool",
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/node_pools$`),
+		kind:       "NodePool",
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/identity_providers$`),
+		kind:       "IdentityProvider",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/groups$`),
+		kind:       "Group",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/groups/[^/]+/users$`),
+		kind:       "User",
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/ingresses$`),
+		kind:       "Ingress",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/inflight_checks$`),
+		kind:       "InflightCheck",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/limited_support_reasons$`),
+		kind:       "LimitedSupportReason",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/upgrade_policies$`),
+		kind:       "UpgradePolicy",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/control_plane/upgrade_policies$`),
+		kind:       "ControlPlaneUpgradePolicy",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^clusters/[^/]+/node_pools/[^/]+/upgrade_policies$`),
+		kind:       "NodePoolUpgradePolicy",
+		generateID: true,
+	},
+	{
+		collection: regexp.MustCompile(`^versions$`),
+		kind:       "Version",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^machine_types$`),
+		kind:       "MachineType",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^flavours$`),
+		kind:       "Flavour",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^cloud_providers$`),
+		kind:       "CloudProvider",
+		readOnly:   true,
+	},
+	{
+		collection: regexp.MustCompile(`^cloud_providers/[^/]+/regions$`),
+		kind:       "CloudRegion",
+		readOnly:   true,
+	},
+}
+
+// awsInquiries are the collections returned by the inquiries about the AWS account
+var awsInquiries = map[string]string{
+	"aws_inquiries/machine_types": "machine_types",
+	"aws_inquiries/regions":       "cloud_providers/aws/regions",
+}
+
+// Server is the fake OCM server. Don't create instances of this type directly; use the NewServer
+// function instead.
+type Server struct {
+	server *httptest.Server
+	token  string
+
+	lock sync.Mutex
+	// Objects by collection path, relative to the API prefix, and identifier
+	collections map[string]*collection
+}
+
+type collection struct {
+	ids   []string
+	items map[string]map[string]interface{}
+}
+
+// NewServer starts a fake OCM server with the default versions. Call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		collections: map[string]*collection{},
+	}
+	s.token = makeToken()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	for _, version := range defaultVersions {
+		s.AddVersion(version.rawID, version.channelGroup, version.isDefault, version.availableUpgrades...)
+	}
+	s.store("flavours", "osd-4", map[string]interface{}{
+		"kind": "Flavour",
+		"id":   "osd-4",
+		"href": apiPrefix + "flavours/osd-4",
+		"aws": map[string]interface{}{
+			"compute_instance_type": "m5.xlarge",
+			"worker_volume":         map[string]interface{}{"size": 300},
+		},
+		"network": map[string]interface{}{
+			"machine_cidr": "10.0.0.0/16",
+			"service_cidr": "172.30.0.0/16",
+			"pod_cidr":     "10.128.0.0/14",
+			"host_prefix":  23,
+		},
+	})
+	s.store("cloud_providers", "aws", map[string]interface{}{
+		"kind":         "CloudProvider",
+		"id":           "aws",
+		"href":         apiPrefix + "cloud_providers/aws",
+		"name":         "aws",
+		"display_name": "AWS",
+	})
+	for _, region := range defaultRegions {
+		s.store("cloud_providers/aws/regions", region, map[string]interface{}{
+			"kind":                "CloudRegion",
+			"id":                  region,
+			"href":                apiPrefix + "cloud_providers/aws/regions/" + region,
+			"display_name":        region,
+			"enabled":             true,
+			"supports_multi_az":   true,
+			"supports_hypershift": true,
+			"cloud_provider":      map[string]interface{}{"kind": "CloudProviderLink", "id": "aws"},
+		})
+	}
+	for _, machineType := range defaultMachineTypes {
+		s.store("machine_types", machineType.id, map[string]interface{}{
+			"kind":           "MachineType",
+			"id":             machineType.id,
+			"href":           apiPrefix + "machine_types/" + machineType.id,
+			"name":           machineType.id,
+			"category":       "general_purpose",
+			"size":           machineType.size,
+			"cpu":            map[string]interface{}{"value": machineType.cpu, "unit": "vCPU"},
+			"memory":         map[string]interface{}{"value": machineType.memory, "unit": "B"},
+			"cloud_provider": map[string]interface{}{"kind": "CloudProviderLink", "id": "aws"},
+		})
+	}
+	return s
+}
+
+var defaultRegions = []string{"us-east-1", "us-west-2"}
+
+var defaultMachineTypes = []struct {
+	id     string
+	size   string
+	cpu    int
+	memory int64
+}{
+	{id: "m5.xlarge", size: "xlarge", cpu: 4, memory: 16 << 30},
+	{id: "m5.2xlarge", size: "2xlarge", cpu: 8, memory: 32 << 30},
+}
+
+var defaultVersions = []struct {
+	rawID             string
+	channelGroup      string
+	isDefault         bool
+	availableUpgrades []string
+}{
+	{rawID: "4.14.16", channelGroup: "stable", availableUpgrades: []string{"4.15.2"}},
+	{rawID: "4.15.2", channelGroup: "stable", isDefault: true},
+}
+
+// URL returns the URL of the server
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Token returns an access token accepted by the server
+func (s *Server) Token() string {
+	return s.token
+}
+
+// Config returns a configuration that makes rosa use the server
+func (s *Server) Config() *config.Config {
+	return &config.Config{
+		URL:         s.server.URL,
+		AccessToken: s.token,
+	}
+}
+
+// Close stops the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddVersion adds a version of OpenShift that clusters can be created with and upgraded to
+func (s *Server) AddVersion(rawID string, channelGroup string, isDefault bool, availableUpgrades ...string) {
+	id := "openshift-v" + rawID
+	if channelGroup != "stable" {
+		id = fmt.Sprintf("%s-%s", id, channelGroup)
+	}
+	upgrades := make([]interface{}, len(availableUpgrades))
+	for i, upgrade := range availableUpgrades {
+		upgrades[i] = upgrade
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.store("versions", id, map[string]interface{}{
+		"kind":                         "Version",
+		"id":                           id,
+		"href":                         apiPrefix + "versions/" + id,
+		"raw_id":                       rawID,
+		"channel_group":                channelGroup,
+		"default":                      isDefault,
+		"enabled":                      true,
+		"rosa_enabled":                 true,
+		"hosted_control_plane_enabled": true,
+		"available_upgrades":           upgrades,
+	})
+}
+
+// Get returns a copy of the object with the given path, relative to '/api/clusters_mgmt/v1', so
+// that tests can check the state of the server.
+func (s *Server) Get(objectPath string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	parent, id := path.Split(strings.Trim(objectPath, "/"))
+	items, ok := s.collections[strings.TrimSuffix(parent, "/")]
+	if !ok {
+		return nil, false
+	}
+	object, ok := items.items[id]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(object), true
+}
+
+// List returns copies of the objects of the collection with the given path, relative to
+// '/api/clusters_mgmt/v1', in the order they were created.
+func (s *Server) List(collectionPath string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	result := []map[string]interface{}{}
+	items, ok := s.collections[strings.Trim(collectionPath, "/")]
+	if !ok {
+		return result
+	}
+	for _, id := range items.ids {
+		result = append(result, deepCopy(items.items[id]))
+	}
+	return result
+}
+
+// Update merges the given fields into the object with the given path, so that tests can simulate
+// changes made by the service, like the state of a cluster.
+func (s *Server) Update(objectPath string, fields map[string]interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	parent, id := path.Split(strings.Trim(objectPath, "/"))
+	items, ok := s.collections[strings.TrimSuffix(parent, "/")]
+	if !ok {
+		return false
+	}
+	object, ok := items.items[id]
+	if !ok {
+		return false
+	}
+	merge(object, fields)
+	return true
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Operation-Id", randomID())
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "Request doesn't contain the 'Authorization' header")
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, accountsPrefix) && r.Method == http.MethodGet {
+		s.serveAccounts(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Can't find resource for path '%s'", r.URL.Path))
+		return
+	}
+	relative := strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The inquiries check the AWS account with the credentials in the body, which the fake
+	// accepts without checking:
+	if inquired, ok := awsInquiries[relative]; ok && r.Method == http.MethodPost {
+		resource, _ := findResource(inquired)
+		s.list(w, r, inquired, resource)
+		return
+	}
+
+	if resource, ok := findResource(relative); ok {
+		switch {
+		case r.Method == http.MethodGet:
+			s.list(w, r, relative, resource)
+		case r.Method == http.MethodPost && !resource.readOnly:
+			s.create(w, r, relative, resource)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method '%s' isn't allowed for path '%s'",
+				r.Method, r.URL.Path))
+		}
+		return
+	}
+
+	parent, id := path.Split(relative)
+	parent = strings.TrimSuffix(parent, "/")
+	if resource, ok := findResource(parent); ok {
+		object, found := s.lookupObject(parent, id)
+		if !found {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s '%s' not found", resource.kind, id))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, object)
+		case r.Method == http.MethodPatch && !resource.readOnly:
+			s.update(w, r, object)
+		case r.Method == http.MethodDelete && !resource.readOnly:
+			s.delete(parent, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method '%s' isn't allowed for path '%s'",
+				r.Method, r.URL.Path))
+		}
+		return
+	}
+
+	// State of the objects, which the real service keeps in separate resources:
+	grandparent, object := path.Split(parent)
+	grandparent = strings.TrimSuffix(grandparent, "/")
+	if r.Method == http.MethodGet {
+		if item, found := s.lookupObject(grandparent, object); found {
+			switch {
+			case grandparent == "clusters" && id == "status":
+				writeJSON(w, http.StatusOK, map[string]interface{}{
+					"kind":  "ClusterStatus",
+					"id":    object,
+					"state": item["state"],
+				})
+				return
+			case strings.HasSuffix(grandparent, "upgrade_policies") && id == "state":
+				writeJSON(w, http.StatusOK, item["state"])
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Can't find resource for path '%s'", r.URL.Path))
+}
+
+func (s *Server) serveAccounts(w http.ResponseWriter, r *http.Request) {
+	switch strings.Trim(strings.TrimPrefix(r.URL.Path, accountsPrefix), "/") {
+	case "current_account":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":     "Account",
+			"id":       "2a3b4c5d6e7f8g9h0i1j2k3l4m5",
+			"username": "developer",
+			"organization": map[string]interface{}{
+				"kind": "Organization",
+				"id":   organizationID,
+			},
+		})
+	case "organizations/" + organizationID + "/quota_cost":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":  "QuotaCostList",
+			"page":  1,
+			"size":  0,
+			"total": 0,
+			"items": []interface{}{},
+		})
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Can't find resource for path '%s'", r.URL.Path))
+	}
+}
+
+func findResource(collectionPath string) (resourceType, bool) {
+	for _, resource := range resourceTypes {
+		if resource.collection.MatchString(collectionPath) {
+			return resource, true
+		}
+	}
+	return resourceType{}, false
+}
+
+// parentExists checks that the object that contains the given collection exists, for example
+// the cluster of a collection of machine pools.
+func (s *Server) parentExists(collectionPath string) bool {
+	parent := path.Dir(collectionPath)
+	if parent == "." {
+		return true
+	}
+	parent = strings.TrimSuffix(parent, "/control_plane")
+	grandparent, id := path.Split(parent)
+	_, found := s.lookupObject(strings.TrimSuffix(grandparent, "/"), id)
+	return found
+}
+
+func (s *Server) lookupObject(collectionPath string, id string) (map[string]interface{}, bool) {
+	items, ok := s.collections[collectionPath]
+	if !ok {
+		return nil, false
+	}
+	object, ok := items.items[id]
+	return object, ok
+}
+
+func (s *Server) store(collectionPath string, id string, object map[string]interface{}) {
+	items, ok := s.collections[collectionPath]
+	if !ok {
+		items = &collection{
+			items: map[string]map[string]interface{}{},
+		}
+		s.collections[collectionPath] = items
+	}
+	if _, exists := items.items[id]; !exists {
+		items.ids = append(items.ids, id)
+	}
+	items.items[id] = object
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, collectionPath string, resource resourceType) {
+	if !s.parentExists(collectionPath) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Can't find resource for path '%s'", r.URL.Path))
+		return
+	}
+	query := r.URL.Query()
+	matches, err := parseSearch(query.Get("search"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, size := 1, 100
+	if value := query.Get("page"); value != "" {
+		page, err = strconv.Atoi(value)
+		if err != nil || page < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid page '%s'", value))
+			return
+		}
+	}
+	if value := query.Get("size"); value != "" {
+		size, err = strconv.Atoi(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid size '%s'", value))
+			return
+		}
+	}
+
+	found := []interface{}{}
+	if items, ok := s.collections[collectionPath]; ok {
+		for _, id := range items.ids {
+			if matches(items.items[id]) {
+				found = append(found, items.items[id])
+			}
+		}
+	}
+	// A negative size, like '-1', returns all the objects:
+	if size < 0 {
+		size = len(found)
+	}
+	start := min((page-1)*size, len(found))
+	end := min(start+size, len(found))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":  resource.kind + "List",
+		"page":  page,
+		"size":  end - start,
+		"total": len(found),
+		"items": found[start:end],
+	})
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, collectionPath string, resource resourceType) {
+	if !s.parentExists(collectionPath) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Can't find resource for path '%s'", r.URL.Path))
+		return
+	}
+	object := map[string]interface{}{}
+	err := json.NewDecoder(r.Body).Decode(&object)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Can't parse body: %v", err))
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	id, _ := object["id"].(string)
+	if id == "" {
+		if !resource.generateID {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s identifier is mandatory", resource.kind))
+			return
+		}
+		id = randomID()
+	}
+	if _, exists := s.lookupObject(collectionPath, id); exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("%s '%s' already exists", resource.kind, id))
+		return
+	}
+	object["kind"] = resource.kind
+	object["id"] = id
+	object["href"] = apiPrefix + collectionPath + "/" + id
+
+	switch resource.kind {
+	case "Cluster":
+		name, _ := object["name"].(string)
+		if name == "" {
+			writeError(w, http.StatusBadRequest, "Cluster name is mandatory")
+			return
+		}
+		if items, ok := s.collections[collectionPath]; ok {
+			for _, existing := range items.items {
+				if existing["name"] == name {
+					writeError(w, http.StatusBadRequest, fmt.Sprintf("Cluster name '%s' already exists", name))
+					return
+				}
+			}
+		}
+		// The real service completes the version with the details of the requested one:
+		if version, ok := object["version"].(map[string]interface{}); ok {
+			if versionID, ok := version["id"].(string); ok {
+				if found, ok := s.lookupObject("versions", versionID); ok {
+					object["version"] = deepCopy(found)
+				}
+			}
+		}
+		setDefault(object, "state", "ready")
+		setDefault(object, "product", map[string]interface{}{"kind": "ProductLink", "id": "rosa"})
+		setDefault(object, "external_id", randomUUID())
+		setDefault(object, "creation_timestamp", time.Now().UTC().Format(time.RFC3339))
+	case "UpgradePolicy":
+		setDefault(object, "state", map[string]interface{}{
+			"kind":  "UpgradePolicyState",
+			"id":    id,
+			"href":  object["href"].(string) + "/state",
+			"value": "scheduled",
+		})
+	case "ControlPlaneUpgradePolicy", "NodePoolUpgradePolicy":
+		setDefault(object, "state", map[string]interface{}{"value": "scheduled"})
+	}
+	if dryRun {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.store(collectionPath, id, object)
+	if resource.kind == "Cluster" {
+		ingressID := randomID()
+		s.store(collectionPath+"/"+id+"/ingresses", ingressID, map[string]interface{}{
+			"kind":      "Ingress",
+			"id":        ingressID,
+			"href":      apiPrefix + collectionPath + "/" + id + "/ingresses/" + ingressID,
+			"default":   true,
+			"listening": "external",
+		})
+		for _, group := range []string{"cluster-admins", "dedicated-admins"} {
+			s.store(collectionPath+"/"+id+"/groups", group, map[string]interface{}{
+				"kind": "Group",
+				"id":   group,
+				"href": apiPrefix + collectionPath + "/" + id + "/groups/" + group,
+			})
+		}
+	}
+	writeJSON(w, http.StatusCreated, object)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, object map[string]interface{}) {
+	fields := map[string]interface{}{}
+	err := json.NewDecoder(r.Body).Decode(&fields)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Can't parse body: %v", err))
+		return
+	}
+	delete(fields, "kind")
+	delete(fields, "id")
+	delete(fields, "href")
+	merge(object, fields)
+	writeJSON(w, http.StatusOK, object)
+}
+
+// delete removes the object and all the objects that it contains
+func (s *Server) delete(collectionPath string, id string) {
+	items := s.collections[collectionPath]
+	delete(items.items, id)
+	for i, existing := range items.ids {
+		if existing == id {
+			items.ids = append(items.ids[:i], items.ids[i+1:]...)
+			break
+		}
+	}
+	prefix := collectionPath + "/" + id + "/"
+	for nested := range s.collections {
+		if strings.HasPrefix(nested, prefix) {
+			delete(s.collections, nested)
+		}
+	}
+}
+
+func setDefault(object map[string]interface{}, field string, value interface{}) {
+	if _, ok := object[field]; !ok {
+		object[field] = value
+	}
+}
+
+// merge copies the given fields into the object, merging nested objects
+func merge(object map[string]interface{}, fields map[string]interface{}) {
+	for name, value := range fields {
+		nested, ok := value.(map[string]interface{})
+		existing, isObject := object[name].(map[string]interface{})
+		if ok && isObject {
+			merge(existing, nested)
+			continue
+		}
+		object[name] = value
+	}
+}
+
+func deepCopy(object map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(object)
+	result := map[string]interface{}{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in the same format as the real service
+func writeError(w http.ResponseWriter, status int, reason string) {
+	writeJSON(w, status, map[string]interface{}{
+		"kind":         "Error",
+		"id":           strconv.Itoa(status),
+		"href":         apiPrefix + "errors/" + strconv.Itoa(status),
+		"code":         fmt.Sprintf("CLUSTERS-MGMT-%d", status),
+		"reason":       reason,
+		"operation_id": w.Header().Get("X-Operation-Id"),
+	})
+}
+
+// randomID returns an identifier in the format that the real service uses for clusters
+func randomID() string {
+	const alphabet = "0123456789abcdefghijklmnopqrstuv"
+	id := make([]byte, 32)
+	for i := range id {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		id[i] = alphabet[n.Int64()]
+	}
+	return string(id)
+}
+
+func randomUUID() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+	data[6] = (data[6] & 0x0f) | 0x40
+	data[8] = (data[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:])
+}
+
+// makeToken returns an unsigned access token that doesn't expire in the life of the server. The
+// server doesn't check the signature, and the clients don't either.
+func makeToken() string {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ":      "Bearer",
+		"iat":      now.Unix(),
+		"exp":      now.Add(24 * time.Hour).Unix(),
+		"sub":      "fake-user",
+		"username": "fake-user",
+	})
+	text, _ := token.SignedString([]byte("fakeocm"))
+	return text
+}
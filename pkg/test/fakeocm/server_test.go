@@ -0,0 +1,204 @@
+package fakeocm
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Server", func() {
+	var server *Server
+	var connection *sdk.Connection
+	var client *ocm.Client
+
+	BeforeEach(func() {
+		server = NewServer()
+		var err error
+		client, err = ocm.NewClient().
+			Logger(logging.NewLogger()).
+			Config(server.Config()).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(server.Token()).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(connection.Close()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+		server.Close()
+	})
+
+	createCluster := func(name string) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().
+			Name(name).
+			Version(cmv1.NewVersion().ID("openshift-v4.14.16").RawID("4.14.16")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := connection.ClustersMgmt().V1().Clusters().Add().Body(cluster).Send()
+		Expect(err).NotTo(HaveOccurred())
+		return response.Body()
+	}
+
+	It("Supports the create, add pool, upgrade and delete workflow", func() {
+		versions, err := client.GetVersions("stable", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(2))
+		Expect(versions[0].RawID()).To(Equal("4.15.2"))
+
+		created := createCluster("my-cluster")
+		Expect(created.ID()).To(HaveLen(32))
+		Expect(created.State()).To(Equal(cmv1.ClusterStateReady))
+
+		cluster, err := client.GetCluster("my-cluster", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.ID()).To(Equal(created.ID()))
+		state, err := client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateReady))
+
+		ingresses, err := client.GetIngresses(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ingresses).To(HaveLen(1))
+		Expect(ingresses[0].Default()).To(BeTrue())
+
+		machinePool, err := cmv1.NewMachinePool().ID("workers-2").Replicas(3).InstanceType("m5.xlarge").Build()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.CreateMachinePool(cluster.ID(), machinePool)
+		Expect(err).NotTo(HaveOccurred())
+		machinePool, err = cmv1.NewMachinePool().ID("workers-2").Replicas(5).Build()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.UpdateMachinePool(cluster.ID(), machinePool)
+		Expect(err).NotTo(HaveOccurred())
+		machinePools, err := client.GetMachinePools(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePools).To(HaveLen(1))
+		Expect(machinePools[0].Replicas()).To(Equal(5))
+		Expect(machinePools[0].InstanceType()).To(Equal("m5.xlarge"))
+
+		upgrades, err := client.GetAvailableUpgrades(cluster.Version().ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upgrades).To(Equal([]string{"4.15.2"}))
+		upgradePolicy, err := cmv1.NewUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeOSD).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version("4.15.2").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.ScheduleUpgrade(cluster.ID(), upgradePolicy)).To(Succeed())
+		scheduled, upgradeState, err := client.GetScheduledUpgrade(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scheduled.Version()).To(Equal("4.15.2"))
+		Expect(upgradeState.Value()).To(Equal(cmv1.UpgradePolicyStateValueScheduled))
+
+		_, err = client.DeleteCluster("my-cluster", false, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.GetCluster("my-cluster", nil)
+		Expect(err).To(MatchError(ContainSubstring("There is no cluster with identifier or name 'my-cluster'")))
+		_, found := server.Get("clusters/" + cluster.ID() + "/machine_pools/workers-2")
+		Expect(found).To(BeFalse())
+	})
+
+	It("Supports node pools and their upgrades", func() {
+		cluster := createCluster("my-hcp-cluster")
+		nodePool, err := cmv1.NewNodePool().ID("workers").Replicas(2).Build()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.CreateNodePool(cluster.ID(), nodePool)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodePool, found, err := client.GetNodePool(cluster.ID(), "workers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(nodePool.Replicas()).To(Equal(2))
+
+		upgradePolicy, err := cmv1.NewNodePoolUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeNodePool).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version("4.15.2").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := connection.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).
+			NodePools().NodePool("workers").UpgradePolicies().Add().Body(upgradePolicy).Send()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Body().State().Value()).To(Equal(cmv1.UpgradePolicyStateValueScheduled))
+
+		Expect(client.DeleteNodePool(cluster.ID(), "workers")).To(Succeed())
+		_, found, err = client.GetNodePool(cluster.ID(), "workers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("Keeps identity providers", func() {
+		cluster := createCluster("my-cluster")
+		idp, err := cmv1.NewIdentityProvider().
+			Type(cmv1.IdentityProviderTypeHtpasswd).
+			Name("htpasswd").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		created, err := client.CreateIdentityProvider(cluster.ID(), idp)
+		Expect(err).NotTo(HaveOccurred())
+		idps, err := client.GetIdentityProviders(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(idps).To(HaveLen(1))
+		Expect(idps[0].Name()).To(Equal("htpasswd"))
+		Expect(client.DeleteIdentityProvider(cluster.ID(), created.ID())).To(Succeed())
+	})
+
+	It("Rejects duplicated cluster names", func() {
+		createCluster("my-cluster")
+		cluster, err := cmv1.NewCluster().Name("my-cluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := connection.ClustersMgmt().V1().Clusters().Add().Body(cluster).Send()
+		Expect(err).To(HaveOccurred())
+		Expect(response.Status()).To(Equal(http.StatusBadRequest))
+		Expect(response.Error().Reason()).To(Equal("Cluster name 'my-cluster' already exists"))
+	})
+
+	It("Returns not found for objects of missing clusters", func() {
+		_, err := client.GetMachinePools("123")
+		Expect(err).To(HaveOccurred())
+		_, found, err := client.GetMachinePool("123", "workers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("Answers the queries used to create clusters", func() {
+		account, err := client.GetCurrentAccount()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(account.Organization().ID()).NotTo(BeEmpty())
+
+		machineTypes, err := client.GetAvailableMachineTypes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineTypes.IDs()).To(Equal([]string{"m5.xlarge", "m5.2xlarge"}))
+
+		data, err := cmv1.NewCloudProviderData().Region(cmv1.NewCloudRegion().ID("us-east-1")).Build()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := connection.ClustersMgmt().V1().AWSInquiries().Regions().Search().Body(data).Send()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Items().Len()).To(Equal(2))
+
+		created := createCluster("my-cluster")
+		Expect(created.Version().RawID()).To(Equal("4.14.16"))
+		Expect(created.Version().AvailableUpgrades()).To(Equal([]string{"4.15.2"}))
+		Expect(server.List("clusters")).To(HaveLen(1))
+	})
+
+	It("Lets tests simulate changes made by the service", func() {
+		cluster := createCluster("my-cluster")
+		Expect(server.Update("clusters/"+cluster.ID(), map[string]interface{}{
+			"state": "installing",
+		})).To(BeTrue())
+		state, err := client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateInstalling))
+	})
+})